@@ -0,0 +1,1497 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.1
+// 	protoc        (unknown)
+// source: api/v1/echosphere.admin.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// EventType classifies the lifecycle events of the server.
+type EventType int32
+
+const (
+	EventType_EVENT_TYPE_UNSPECIFIED  EventType = 0
+	EventType_EVENT_TYPE_REGISTERED   EventType = 1
+	EventType_EVENT_TYPE_UNREGISTERED EventType = 2
+	EventType_EVENT_TYPE_KICKED       EventType = 3
+	// a message was relayed from owner_id to peer_id
+	EventType_EVENT_TYPE_RELAYED EventType = 4
+	// an ack from owner_id was forwarded to peer_id
+	EventType_EVENT_TYPE_ACK_FORWARDED EventType = 5
+	// a nack from owner_id was forwarded to peer_id
+	EventType_EVENT_TYPE_NACK_FORWARDED EventType = 6
+	// a message, ack or nack from owner_id could not be delivered
+	EventType_EVENT_TYPE_DROPPED EventType = 7
+)
+
+// Enum value maps for EventType.
+var (
+	EventType_name = map[int32]string{
+		0: "EVENT_TYPE_UNSPECIFIED",
+		1: "EVENT_TYPE_REGISTERED",
+		2: "EVENT_TYPE_UNREGISTERED",
+		3: "EVENT_TYPE_KICKED",
+		4: "EVENT_TYPE_RELAYED",
+		5: "EVENT_TYPE_ACK_FORWARDED",
+		6: "EVENT_TYPE_NACK_FORWARDED",
+		7: "EVENT_TYPE_DROPPED",
+	}
+	EventType_value = map[string]int32{
+		"EVENT_TYPE_UNSPECIFIED":    0,
+		"EVENT_TYPE_REGISTERED":     1,
+		"EVENT_TYPE_UNREGISTERED":   2,
+		"EVENT_TYPE_KICKED":         3,
+		"EVENT_TYPE_RELAYED":        4,
+		"EVENT_TYPE_ACK_FORWARDED":  5,
+		"EVENT_TYPE_NACK_FORWARDED": 6,
+		"EVENT_TYPE_DROPPED":        7,
+	}
+)
+
+func (x EventType) Enum() *EventType {
+	p := new(EventType)
+	*p = x
+	return p
+}
+
+func (x EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_echosphere_admin_proto_enumTypes[0].Descriptor()
+}
+
+func (EventType) Type() protoreflect.EnumType {
+	return &file_api_v1_echosphere_admin_proto_enumTypes[0]
+}
+
+func (x EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventType.Descriptor instead.
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_admin_proto_rawDescGZIP(), []int{0}
+}
+
+// Connection is a client connected to this server.
+type Connection struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OwnerId     string                 `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	ConnectedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=connected_at,json=connectedAt,proto3" json:"connected_at,omitempty"`
+	// stream_age is how long the stream has been open
+	StreamAge *durationpb.Duration `protobuf:"bytes,3,opt,name=stream_age,json=streamAge,proto3" json:"stream_age,omitempty"`
+}
+
+func (x *Connection) Reset() {
+	*x = Connection{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_admin_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Connection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Connection) ProtoMessage() {}
+
+func (x *Connection) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_admin_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Connection.ProtoReflect.Descriptor instead.
+func (*Connection) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Connection) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *Connection) GetConnectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ConnectedAt
+	}
+	return nil
+}
+
+func (x *Connection) GetStreamAge() *durationpb.Duration {
+	if x != nil {
+		return x.StreamAge
+	}
+	return nil
+}
+
+type EchoSphereAdminServiceListConnectionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *EchoSphereAdminServiceListConnectionsRequest) Reset() {
+	*x = EchoSphereAdminServiceListConnectionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_admin_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoSphereAdminServiceListConnectionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoSphereAdminServiceListConnectionsRequest) ProtoMessage() {}
+
+func (x *EchoSphereAdminServiceListConnectionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_admin_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoSphereAdminServiceListConnectionsRequest.ProtoReflect.Descriptor instead.
+func (*EchoSphereAdminServiceListConnectionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_admin_proto_rawDescGZIP(), []int{1}
+}
+
+type EchoSphereAdminServiceListConnectionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Connections []*Connection `protobuf:"bytes,1,rep,name=connections,proto3" json:"connections,omitempty"`
+}
+
+func (x *EchoSphereAdminServiceListConnectionsResponse) Reset() {
+	*x = EchoSphereAdminServiceListConnectionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_admin_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoSphereAdminServiceListConnectionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoSphereAdminServiceListConnectionsResponse) ProtoMessage() {}
+
+func (x *EchoSphereAdminServiceListConnectionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_admin_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoSphereAdminServiceListConnectionsResponse.ProtoReflect.Descriptor instead.
+func (*EchoSphereAdminServiceListConnectionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EchoSphereAdminServiceListConnectionsResponse) GetConnections() []*Connection {
+	if x != nil {
+		return x.Connections
+	}
+	return nil
+}
+
+type EchoSphereAdminServiceCountPendingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *EchoSphereAdminServiceCountPendingRequest) Reset() {
+	*x = EchoSphereAdminServiceCountPendingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_admin_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoSphereAdminServiceCountPendingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoSphereAdminServiceCountPendingRequest) ProtoMessage() {}
+
+func (x *EchoSphereAdminServiceCountPendingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_admin_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoSphereAdminServiceCountPendingRequest.ProtoReflect.Descriptor instead.
+func (*EchoSphereAdminServiceCountPendingRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_admin_proto_rawDescGZIP(), []int{3}
+}
+
+type EchoSphereAdminServiceCountPendingResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// pending_acks is the number of relayed messages whose originator has not been acked or nacked yet
+	PendingAcks int64 `protobuf:"varint,1,opt,name=pending_acks,json=pendingAcks,proto3" json:"pending_acks,omitempty"`
+}
+
+func (x *EchoSphereAdminServiceCountPendingResponse) Reset() {
+	*x = EchoSphereAdminServiceCountPendingResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_admin_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoSphereAdminServiceCountPendingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoSphereAdminServiceCountPendingResponse) ProtoMessage() {}
+
+func (x *EchoSphereAdminServiceCountPendingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_admin_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoSphereAdminServiceCountPendingResponse.ProtoReflect.Descriptor instead.
+func (*EchoSphereAdminServiceCountPendingResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EchoSphereAdminServiceCountPendingResponse) GetPendingAcks() int64 {
+	if x != nil {
+		return x.PendingAcks
+	}
+	return 0
+}
+
+// PendingMessage is a relayed message whose originator has not been acked or nacked yet.
+type PendingMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Originator string `protobuf:"bytes,1,opt,name=originator,proto3" json:"originator,omitempty"`
+	Recipient  string `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	// content is what the audit redaction of the server keeps of the message, empty when it keeps only its hash
+	Content     string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	RelayedAt   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=relayed_at,json=relayedAt,proto3" json:"relayed_at,omitempty"`
+	ContentHash string                 `protobuf:"bytes,5,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	// truncated tells the content was cut by the redaction
+	Truncated bool `protobuf:"varint,6,opt,name=truncated,proto3" json:"truncated,omitempty"`
+}
+
+func (x *PendingMessage) Reset() {
+	*x = PendingMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_admin_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PendingMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingMessage) ProtoMessage() {}
+
+func (x *PendingMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_admin_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingMessage.ProtoReflect.Descriptor instead.
+func (*PendingMessage) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PendingMessage) GetOriginator() string {
+	if x != nil {
+		return x.Originator
+	}
+	return ""
+}
+
+func (x *PendingMessage) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *PendingMessage) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *PendingMessage) GetRelayedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RelayedAt
+	}
+	return nil
+}
+
+func (x *PendingMessage) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
+	}
+	return ""
+}
+
+func (x *PendingMessage) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+type EchoSphereAdminServiceListPendingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *EchoSphereAdminServiceListPendingRequest) Reset() {
+	*x = EchoSphereAdminServiceListPendingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_admin_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoSphereAdminServiceListPendingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoSphereAdminServiceListPendingRequest) ProtoMessage() {}
+
+func (x *EchoSphereAdminServiceListPendingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_admin_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoSphereAdminServiceListPendingRequest.ProtoReflect.Descriptor instead.
+func (*EchoSphereAdminServiceListPendingRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_admin_proto_rawDescGZIP(), []int{6}
+}
+
+type EchoSphereAdminServiceListPendingResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pending []*PendingMessage `protobuf:"bytes,1,rep,name=pending,proto3" json:"pending,omitempty"`
+}
+
+func (x *EchoSphereAdminServiceListPendingResponse) Reset() {
+	*x = EchoSphereAdminServiceListPendingResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_admin_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoSphereAdminServiceListPendingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoSphereAdminServiceListPendingResponse) ProtoMessage() {}
+
+func (x *EchoSphereAdminServiceListPendingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_admin_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoSphereAdminServiceListPendingResponse.ProtoReflect.Descriptor instead.
+func (*EchoSphereAdminServiceListPendingResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *EchoSphereAdminServiceListPendingResponse) GetPending() []*PendingMessage {
+	if x != nil {
+		return x.Pending
+	}
+	return nil
+}
+
+type EchoSphereAdminServiceKickRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OwnerId string `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+}
+
+func (x *EchoSphereAdminServiceKickRequest) Reset() {
+	*x = EchoSphereAdminServiceKickRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_admin_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoSphereAdminServiceKickRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoSphereAdminServiceKickRequest) ProtoMessage() {}
+
+func (x *EchoSphereAdminServiceKickRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_admin_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoSphereAdminServiceKickRequest.ProtoReflect.Descriptor instead.
+func (*EchoSphereAdminServiceKickRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EchoSphereAdminServiceKickRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+type EchoSphereAdminServiceKickResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *EchoSphereAdminServiceKickResponse) Reset() {
+	*x = EchoSphereAdminServiceKickResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_admin_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoSphereAdminServiceKickResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoSphereAdminServiceKickResponse) ProtoMessage() {}
+
+func (x *EchoSphereAdminServiceKickResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_admin_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoSphereAdminServiceKickResponse.ProtoReflect.Descriptor instead.
+func (*EchoSphereAdminServiceKickResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_admin_proto_rawDescGZIP(), []int{9}
+}
+
+type EchoSphereAdminServiceDrainRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *EchoSphereAdminServiceDrainRequest) Reset() {
+	*x = EchoSphereAdminServiceDrainRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_admin_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoSphereAdminServiceDrainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoSphereAdminServiceDrainRequest) ProtoMessage() {}
+
+func (x *EchoSphereAdminServiceDrainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_admin_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoSphereAdminServiceDrainRequest.ProtoReflect.Descriptor instead.
+func (*EchoSphereAdminServiceDrainRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_admin_proto_rawDescGZIP(), []int{10}
+}
+
+type EchoSphereAdminServiceDrainResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// connections is the number of connections left to finish
+	Connections int64 `protobuf:"varint,1,opt,name=connections,proto3" json:"connections,omitempty"`
+}
+
+func (x *EchoSphereAdminServiceDrainResponse) Reset() {
+	*x = EchoSphereAdminServiceDrainResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_admin_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoSphereAdminServiceDrainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoSphereAdminServiceDrainResponse) ProtoMessage() {}
+
+func (x *EchoSphereAdminServiceDrainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_admin_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoSphereAdminServiceDrainResponse.ProtoReflect.Descriptor instead.
+func (*EchoSphereAdminServiceDrainResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_admin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *EchoSphereAdminServiceDrainResponse) GetConnections() int64 {
+	if x != nil {
+		return x.Connections
+	}
+	return 0
+}
+
+type EchoSphereAdminServiceStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *EchoSphereAdminServiceStatsRequest) Reset() {
+	*x = EchoSphereAdminServiceStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_admin_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoSphereAdminServiceStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoSphereAdminServiceStatsRequest) ProtoMessage() {}
+
+func (x *EchoSphereAdminServiceStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_admin_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoSphereAdminServiceStatsRequest.ProtoReflect.Descriptor instead.
+func (*EchoSphereAdminServiceStatsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_admin_proto_rawDescGZIP(), []int{12}
+}
+
+type EchoSphereAdminServiceStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Connections int64 `protobuf:"varint,1,opt,name=connections,proto3" json:"connections,omitempty"`
+	// available_relayers is the number of connections the multiplexer can relay to right now
+	AvailableRelayers int64  `protobuf:"varint,2,opt,name=available_relayers,json=availableRelayers,proto3" json:"available_relayers,omitempty"`
+	Registrations     uint64 `protobuf:"varint,3,opt,name=registrations,proto3" json:"registrations,omitempty"`
+	Acquisitions      uint64 `protobuf:"varint,4,opt,name=acquisitions,proto3" json:"acquisitions,omitempty"`
+	Releases          uint64 `protobuf:"varint,5,opt,name=releases,proto3" json:"releases,omitempty"`
+	PendingAcks       int64  `protobuf:"varint,6,opt,name=pending_acks,json=pendingAcks,proto3" json:"pending_acks,omitempty"`
+	QueueDepth        int64  `protobuf:"varint,7,opt,name=queue_depth,json=queueDepth,proto3" json:"queue_depth,omitempty"`
+	Draining          bool   `protobuf:"varint,8,opt,name=draining,proto3" json:"draining,omitempty"`
+}
+
+func (x *EchoSphereAdminServiceStatsResponse) Reset() {
+	*x = EchoSphereAdminServiceStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_admin_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoSphereAdminServiceStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoSphereAdminServiceStatsResponse) ProtoMessage() {}
+
+func (x *EchoSphereAdminServiceStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_admin_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoSphereAdminServiceStatsResponse.ProtoReflect.Descriptor instead.
+func (*EchoSphereAdminServiceStatsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_admin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *EchoSphereAdminServiceStatsResponse) GetConnections() int64 {
+	if x != nil {
+		return x.Connections
+	}
+	return 0
+}
+
+func (x *EchoSphereAdminServiceStatsResponse) GetAvailableRelayers() int64 {
+	if x != nil {
+		return x.AvailableRelayers
+	}
+	return 0
+}
+
+func (x *EchoSphereAdminServiceStatsResponse) GetRegistrations() uint64 {
+	if x != nil {
+		return x.Registrations
+	}
+	return 0
+}
+
+func (x *EchoSphereAdminServiceStatsResponse) GetAcquisitions() uint64 {
+	if x != nil {
+		return x.Acquisitions
+	}
+	return 0
+}
+
+func (x *EchoSphereAdminServiceStatsResponse) GetReleases() uint64 {
+	if x != nil {
+		return x.Releases
+	}
+	return 0
+}
+
+func (x *EchoSphereAdminServiceStatsResponse) GetPendingAcks() int64 {
+	if x != nil {
+		return x.PendingAcks
+	}
+	return 0
+}
+
+func (x *EchoSphereAdminServiceStatsResponse) GetQueueDepth() int64 {
+	if x != nil {
+		return x.QueueDepth
+	}
+	return 0
+}
+
+func (x *EchoSphereAdminServiceStatsResponse) GetDraining() bool {
+	if x != nil {
+		return x.Draining
+	}
+	return false
+}
+
+// Event is something that happened to a connection or to one of its messages.
+type Event struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type    EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=api.v1.EventType" json:"type,omitempty"`
+	OwnerId string                 `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	At      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=at,proto3" json:"at,omitempty"`
+	// peer_id is the other end of a relay, ack or nack
+	PeerId string `protobuf:"bytes,4,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	// content_hash identifies the message without exposing its content
+	ContentHash string `protobuf:"bytes,5,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	// reason tells why a message was dropped or nacked
+	Reason string `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_admin_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_admin_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_admin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Event) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *Event) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *Event) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *Event) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *Event) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
+	}
+	return ""
+}
+
+func (x *Event) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// EchoSphereAdminServiceWatchRequest filters the events, an empty field matches every event.
+type EchoSphereAdminServiceWatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// owner_ids matches the events whose owner or peer is one of them
+	OwnerIds []string    `protobuf:"bytes,1,rep,name=owner_ids,json=ownerIds,proto3" json:"owner_ids,omitempty"`
+	Types    []EventType `protobuf:"varint,2,rep,packed,name=types,proto3,enum=api.v1.EventType" json:"types,omitempty"`
+}
+
+func (x *EchoSphereAdminServiceWatchRequest) Reset() {
+	*x = EchoSphereAdminServiceWatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_admin_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoSphereAdminServiceWatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoSphereAdminServiceWatchRequest) ProtoMessage() {}
+
+func (x *EchoSphereAdminServiceWatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_admin_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoSphereAdminServiceWatchRequest.ProtoReflect.Descriptor instead.
+func (*EchoSphereAdminServiceWatchRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_admin_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *EchoSphereAdminServiceWatchRequest) GetOwnerIds() []string {
+	if x != nil {
+		return x.OwnerIds
+	}
+	return nil
+}
+
+func (x *EchoSphereAdminServiceWatchRequest) GetTypes() []EventType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+type EchoSphereAdminServiceWatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event *Event `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+}
+
+func (x *EchoSphereAdminServiceWatchResponse) Reset() {
+	*x = EchoSphereAdminServiceWatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_admin_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoSphereAdminServiceWatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoSphereAdminServiceWatchResponse) ProtoMessage() {}
+
+func (x *EchoSphereAdminServiceWatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_admin_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoSphereAdminServiceWatchResponse.ProtoReflect.Descriptor instead.
+func (*EchoSphereAdminServiceWatchResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_admin_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *EchoSphereAdminServiceWatchResponse) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+var File_api_v1_echosphere_admin_proto protoreflect.FileDescriptor
+
+var file_api_v1_echosphere_admin_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x63, 0x68, 0x6f, 0x73, 0x70, 0x68,
+	0x65, 0x72, 0x65, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x06, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa0, 0x01, 0x0a, 0x0a, 0x43, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x77, 0x6e, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x3d, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f,
+	0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x38, 0x0a, 0x0a, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x61, 0x67, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x09, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x67, 0x65, 0x22, 0x2e, 0x0a, 0x2c, 0x45,
+	0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x65, 0x0a, 0x2d, 0x45,
+	0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x0b,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x22, 0x2b, 0x0a, 0x29, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65,
+	0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
+	0x4f, 0x0a, 0x2a, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x64, 0x6d,
+	0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x50, 0x65,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a,
+	0x0c, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x61, 0x63, 0x6b, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0b, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x41, 0x63, 0x6b, 0x73,
+	0x22, 0xe4, 0x01, 0x0a, 0x0e, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61,
+	0x74, 0x6f, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x72,
+	0x65, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x72, 0x65, 0x6c,
+	0x61, 0x79, 0x65, 0x64, 0x41, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x72, 0x75,
+	0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x74, 0x72,
+	0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x22, 0x2a, 0x0a, 0x28, 0x45, 0x63, 0x68, 0x6f, 0x53,
+	0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0x5d, 0x0a, 0x29, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72,
+	0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x73,
+	0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x30, 0x0a, 0x07, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x70, 0x65, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x22, 0x3e, 0x0a, 0x21, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65,
+	0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4b, 0x69, 0x63, 0x6b,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x77, 0x6e, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72,
+	0x49, 0x64, 0x22, 0x24, 0x0a, 0x22, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65,
+	0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4b, 0x69, 0x63, 0x6b,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x24, 0x0a, 0x22, 0x45, 0x63, 0x68, 0x6f,
+	0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x47,
+	0x0a, 0x23, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x64, 0x6d, 0x69,
+	0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x24, 0x0a, 0x22, 0x45, 0x63, 0x68, 0x6f, 0x53,
+	0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xbc, 0x02,
+	0x0a, 0x23, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x64, 0x6d, 0x69,
+	0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x61, 0x76, 0x61, 0x69, 0x6c,
+	0x61, 0x62, 0x6c, 0x65, 0x5f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x11, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x72,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x22, 0x0a, 0x0c,
+	0x61, 0x63, 0x71, 0x75, 0x69, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0c, 0x61, 0x63, 0x71, 0x75, 0x69, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c,
+	0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x61, 0x63, 0x6b, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x41, 0x63, 0x6b, 0x73, 0x12,
+	0x1f, 0x0a, 0x0b, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x71, 0x75, 0x65, 0x75, 0x65, 0x44, 0x65, 0x70, 0x74, 0x68,
+	0x12, 0x1a, 0x0a, 0x08, 0x64, 0x72, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x64, 0x72, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x22, 0xc9, 0x01, 0x0a,
+	0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x19, 0x0a,
+	0x08, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x02, 0x61, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x02, 0x61, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x12, 0x21, 0x0a,
+	0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68,
+	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x6a, 0x0a, 0x22, 0x45, 0x63, 0x68, 0x6f,
+	0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x08, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x73, 0x12, 0x27, 0x0a, 0x05, 0x74,
+	0x79, 0x70, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x74,
+	0x79, 0x70, 0x65, 0x73, 0x22, 0x4a, 0x0a, 0x23, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65,
+	0x72, 0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x05, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x2a, 0xe3, 0x01, 0x0a, 0x09, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a,
+	0x0a, 0x16, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x45, 0x56,
+	0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x45,
+	0x52, 0x45, 0x44, 0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x45, 0x52, 0x45, 0x44,
+	0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x4b, 0x49, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x03, 0x12, 0x16, 0x0a, 0x12, 0x45, 0x56, 0x45,
+	0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x52, 0x45, 0x4c, 0x41, 0x59, 0x45, 0x44, 0x10,
+	0x04, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x41, 0x43, 0x4b, 0x5f, 0x46, 0x4f, 0x52, 0x57, 0x41, 0x52, 0x44, 0x45, 0x44, 0x10, 0x05, 0x12,
+	0x1d, 0x0a, 0x19, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4e, 0x41,
+	0x43, 0x4b, 0x5f, 0x46, 0x4f, 0x52, 0x57, 0x41, 0x52, 0x44, 0x45, 0x44, 0x10, 0x06, 0x12, 0x16,
+	0x0a, 0x12, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x52, 0x4f,
+	0x50, 0x50, 0x45, 0x44, 0x10, 0x07, 0x32, 0x8a, 0x06, 0x0a, 0x16, 0x45, 0x63, 0x68, 0x6f, 0x53,
+	0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x7e, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x34, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x63,
+	0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x35, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x64,
+	0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x75, 0x0a, 0x0c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x12, 0x31, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x63, 0x68, 0x6f, 0x53,
+	0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x32, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x63,
+	0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x72, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74,
+	0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x30, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x31, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x64, 0x6d,
+	0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5d, 0x0a, 0x04,
+	0x4b, 0x69, 0x63, 0x6b, 0x12, 0x29, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x63,
+	0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x4b, 0x69, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x2a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68,
+	0x65, 0x72, 0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4b,
+	0x69, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x05, 0x44,
+	0x72, 0x61, 0x69, 0x6e, 0x12, 0x2a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x63,
+	0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x2b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70,
+	0x68, 0x65, 0x72, 0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x44, 0x72, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a,
+	0x05, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x2a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x63, 0x68, 0x6f,
+	0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x62, 0x0a, 0x05, 0x57, 0x61, 0x74, 0x63, 0x68, 0x12, 0x2a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x64, 0x6d, 0x69,
+	0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x63,
+	0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x30, 0x01, 0x42, 0x08, 0x5a, 0x06, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_api_v1_echosphere_admin_proto_rawDescOnce sync.Once
+	file_api_v1_echosphere_admin_proto_rawDescData = file_api_v1_echosphere_admin_proto_rawDesc
+)
+
+func file_api_v1_echosphere_admin_proto_rawDescGZIP() []byte {
+	file_api_v1_echosphere_admin_proto_rawDescOnce.Do(func() {
+		file_api_v1_echosphere_admin_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_v1_echosphere_admin_proto_rawDescData)
+	})
+	return file_api_v1_echosphere_admin_proto_rawDescData
+}
+
+var file_api_v1_echosphere_admin_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_v1_echosphere_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_api_v1_echosphere_admin_proto_goTypes = []interface{}{
+	(EventType)(0),     // 0: api.v1.EventType
+	(*Connection)(nil), // 1: api.v1.Connection
+	(*EchoSphereAdminServiceListConnectionsRequest)(nil),  // 2: api.v1.EchoSphereAdminServiceListConnectionsRequest
+	(*EchoSphereAdminServiceListConnectionsResponse)(nil), // 3: api.v1.EchoSphereAdminServiceListConnectionsResponse
+	(*EchoSphereAdminServiceCountPendingRequest)(nil),     // 4: api.v1.EchoSphereAdminServiceCountPendingRequest
+	(*EchoSphereAdminServiceCountPendingResponse)(nil),    // 5: api.v1.EchoSphereAdminServiceCountPendingResponse
+	(*PendingMessage)(nil),                                // 6: api.v1.PendingMessage
+	(*EchoSphereAdminServiceListPendingRequest)(nil),      // 7: api.v1.EchoSphereAdminServiceListPendingRequest
+	(*EchoSphereAdminServiceListPendingResponse)(nil),     // 8: api.v1.EchoSphereAdminServiceListPendingResponse
+	(*EchoSphereAdminServiceKickRequest)(nil),             // 9: api.v1.EchoSphereAdminServiceKickRequest
+	(*EchoSphereAdminServiceKickResponse)(nil),            // 10: api.v1.EchoSphereAdminServiceKickResponse
+	(*EchoSphereAdminServiceDrainRequest)(nil),            // 11: api.v1.EchoSphereAdminServiceDrainRequest
+	(*EchoSphereAdminServiceDrainResponse)(nil),           // 12: api.v1.EchoSphereAdminServiceDrainResponse
+	(*EchoSphereAdminServiceStatsRequest)(nil),            // 13: api.v1.EchoSphereAdminServiceStatsRequest
+	(*EchoSphereAdminServiceStatsResponse)(nil),           // 14: api.v1.EchoSphereAdminServiceStatsResponse
+	(*Event)(nil), // 15: api.v1.Event
+	(*EchoSphereAdminServiceWatchRequest)(nil),  // 16: api.v1.EchoSphereAdminServiceWatchRequest
+	(*EchoSphereAdminServiceWatchResponse)(nil), // 17: api.v1.EchoSphereAdminServiceWatchResponse
+	(*timestamppb.Timestamp)(nil),               // 18: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),                 // 19: google.protobuf.Duration
+}
+var file_api_v1_echosphere_admin_proto_depIdxs = []int32{
+	18, // 0: api.v1.Connection.connected_at:type_name -> google.protobuf.Timestamp
+	19, // 1: api.v1.Connection.stream_age:type_name -> google.protobuf.Duration
+	1,  // 2: api.v1.EchoSphereAdminServiceListConnectionsResponse.connections:type_name -> api.v1.Connection
+	18, // 3: api.v1.PendingMessage.relayed_at:type_name -> google.protobuf.Timestamp
+	6,  // 4: api.v1.EchoSphereAdminServiceListPendingResponse.pending:type_name -> api.v1.PendingMessage
+	0,  // 5: api.v1.Event.type:type_name -> api.v1.EventType
+	18, // 6: api.v1.Event.at:type_name -> google.protobuf.Timestamp
+	0,  // 7: api.v1.EchoSphereAdminServiceWatchRequest.types:type_name -> api.v1.EventType
+	15, // 8: api.v1.EchoSphereAdminServiceWatchResponse.event:type_name -> api.v1.Event
+	2,  // 9: api.v1.EchoSphereAdminService.ListConnections:input_type -> api.v1.EchoSphereAdminServiceListConnectionsRequest
+	4,  // 10: api.v1.EchoSphereAdminService.CountPending:input_type -> api.v1.EchoSphereAdminServiceCountPendingRequest
+	7,  // 11: api.v1.EchoSphereAdminService.ListPending:input_type -> api.v1.EchoSphereAdminServiceListPendingRequest
+	9,  // 12: api.v1.EchoSphereAdminService.Kick:input_type -> api.v1.EchoSphereAdminServiceKickRequest
+	11, // 13: api.v1.EchoSphereAdminService.Drain:input_type -> api.v1.EchoSphereAdminServiceDrainRequest
+	13, // 14: api.v1.EchoSphereAdminService.Stats:input_type -> api.v1.EchoSphereAdminServiceStatsRequest
+	16, // 15: api.v1.EchoSphereAdminService.Watch:input_type -> api.v1.EchoSphereAdminServiceWatchRequest
+	3,  // 16: api.v1.EchoSphereAdminService.ListConnections:output_type -> api.v1.EchoSphereAdminServiceListConnectionsResponse
+	5,  // 17: api.v1.EchoSphereAdminService.CountPending:output_type -> api.v1.EchoSphereAdminServiceCountPendingResponse
+	8,  // 18: api.v1.EchoSphereAdminService.ListPending:output_type -> api.v1.EchoSphereAdminServiceListPendingResponse
+	10, // 19: api.v1.EchoSphereAdminService.Kick:output_type -> api.v1.EchoSphereAdminServiceKickResponse
+	12, // 20: api.v1.EchoSphereAdminService.Drain:output_type -> api.v1.EchoSphereAdminServiceDrainResponse
+	14, // 21: api.v1.EchoSphereAdminService.Stats:output_type -> api.v1.EchoSphereAdminServiceStatsResponse
+	17, // 22: api.v1.EchoSphereAdminService.Watch:output_type -> api.v1.EchoSphereAdminServiceWatchResponse
+	16, // [16:23] is the sub-list for method output_type
+	9,  // [9:16] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_api_v1_echosphere_admin_proto_init() }
+func file_api_v1_echosphere_admin_proto_init() {
+	if File_api_v1_echosphere_admin_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_api_v1_echosphere_admin_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Connection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_echosphere_admin_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoSphereAdminServiceListConnectionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_echosphere_admin_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoSphereAdminServiceListConnectionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_echosphere_admin_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoSphereAdminServiceCountPendingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_echosphere_admin_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoSphereAdminServiceCountPendingResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_echosphere_admin_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PendingMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_echosphere_admin_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoSphereAdminServiceListPendingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_echosphere_admin_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoSphereAdminServiceListPendingResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_echosphere_admin_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoSphereAdminServiceKickRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_echosphere_admin_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoSphereAdminServiceKickResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_echosphere_admin_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoSphereAdminServiceDrainRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_echosphere_admin_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoSphereAdminServiceDrainResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_echosphere_admin_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoSphereAdminServiceStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_echosphere_admin_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoSphereAdminServiceStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_echosphere_admin_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Event); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_echosphere_admin_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoSphereAdminServiceWatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_echosphere_admin_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoSphereAdminServiceWatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_api_v1_echosphere_admin_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_v1_echosphere_admin_proto_goTypes,
+		DependencyIndexes: file_api_v1_echosphere_admin_proto_depIdxs,
+		EnumInfos:         file_api_v1_echosphere_admin_proto_enumTypes,
+		MessageInfos:      file_api_v1_echosphere_admin_proto_msgTypes,
+	}.Build()
+	File_api_v1_echosphere_admin_proto = out.File
+	file_api_v1_echosphere_admin_proto_rawDesc = nil
+	file_api_v1_echosphere_admin_proto_goTypes = nil
+	file_api_v1_echosphere_admin_proto_depIdxs = nil
+}
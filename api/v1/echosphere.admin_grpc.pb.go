@@ -0,0 +1,359 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: api/v1/echosphere.admin.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	EchoSphereAdminService_ListConnections_FullMethodName = "/api.v1.EchoSphereAdminService/ListConnections"
+	EchoSphereAdminService_CountPending_FullMethodName    = "/api.v1.EchoSphereAdminService/CountPending"
+	EchoSphereAdminService_ListPending_FullMethodName     = "/api.v1.EchoSphereAdminService/ListPending"
+	EchoSphereAdminService_Kick_FullMethodName            = "/api.v1.EchoSphereAdminService/Kick"
+	EchoSphereAdminService_Drain_FullMethodName           = "/api.v1.EchoSphereAdminService/Drain"
+	EchoSphereAdminService_Stats_FullMethodName           = "/api.v1.EchoSphereAdminService/Stats"
+	EchoSphereAdminService_Watch_FullMethodName           = "/api.v1.EchoSphereAdminService/Watch"
+)
+
+// EchoSphereAdminServiceClient is the client API for EchoSphereAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EchoSphereAdminServiceClient interface {
+	ListConnections(ctx context.Context, in *EchoSphereAdminServiceListConnectionsRequest, opts ...grpc.CallOption) (*EchoSphereAdminServiceListConnectionsResponse, error)
+	CountPending(ctx context.Context, in *EchoSphereAdminServiceCountPendingRequest, opts ...grpc.CallOption) (*EchoSphereAdminServiceCountPendingResponse, error)
+	ListPending(ctx context.Context, in *EchoSphereAdminServiceListPendingRequest, opts ...grpc.CallOption) (*EchoSphereAdminServiceListPendingResponse, error)
+	Kick(ctx context.Context, in *EchoSphereAdminServiceKickRequest, opts ...grpc.CallOption) (*EchoSphereAdminServiceKickResponse, error)
+	Drain(ctx context.Context, in *EchoSphereAdminServiceDrainRequest, opts ...grpc.CallOption) (*EchoSphereAdminServiceDrainResponse, error)
+	Stats(ctx context.Context, in *EchoSphereAdminServiceStatsRequest, opts ...grpc.CallOption) (*EchoSphereAdminServiceStatsResponse, error)
+	// Watch streams the lifecycle events of the server as they happen.
+	Watch(ctx context.Context, in *EchoSphereAdminServiceWatchRequest, opts ...grpc.CallOption) (EchoSphereAdminService_WatchClient, error)
+}
+
+type echoSphereAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEchoSphereAdminServiceClient(cc grpc.ClientConnInterface) EchoSphereAdminServiceClient {
+	return &echoSphereAdminServiceClient{cc}
+}
+
+func (c *echoSphereAdminServiceClient) ListConnections(ctx context.Context, in *EchoSphereAdminServiceListConnectionsRequest, opts ...grpc.CallOption) (*EchoSphereAdminServiceListConnectionsResponse, error) {
+	out := new(EchoSphereAdminServiceListConnectionsResponse)
+	err := c.cc.Invoke(ctx, EchoSphereAdminService_ListConnections_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *echoSphereAdminServiceClient) CountPending(ctx context.Context, in *EchoSphereAdminServiceCountPendingRequest, opts ...grpc.CallOption) (*EchoSphereAdminServiceCountPendingResponse, error) {
+	out := new(EchoSphereAdminServiceCountPendingResponse)
+	err := c.cc.Invoke(ctx, EchoSphereAdminService_CountPending_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *echoSphereAdminServiceClient) ListPending(ctx context.Context, in *EchoSphereAdminServiceListPendingRequest, opts ...grpc.CallOption) (*EchoSphereAdminServiceListPendingResponse, error) {
+	out := new(EchoSphereAdminServiceListPendingResponse)
+	err := c.cc.Invoke(ctx, EchoSphereAdminService_ListPending_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *echoSphereAdminServiceClient) Kick(ctx context.Context, in *EchoSphereAdminServiceKickRequest, opts ...grpc.CallOption) (*EchoSphereAdminServiceKickResponse, error) {
+	out := new(EchoSphereAdminServiceKickResponse)
+	err := c.cc.Invoke(ctx, EchoSphereAdminService_Kick_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *echoSphereAdminServiceClient) Drain(ctx context.Context, in *EchoSphereAdminServiceDrainRequest, opts ...grpc.CallOption) (*EchoSphereAdminServiceDrainResponse, error) {
+	out := new(EchoSphereAdminServiceDrainResponse)
+	err := c.cc.Invoke(ctx, EchoSphereAdminService_Drain_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *echoSphereAdminServiceClient) Stats(ctx context.Context, in *EchoSphereAdminServiceStatsRequest, opts ...grpc.CallOption) (*EchoSphereAdminServiceStatsResponse, error) {
+	out := new(EchoSphereAdminServiceStatsResponse)
+	err := c.cc.Invoke(ctx, EchoSphereAdminService_Stats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *echoSphereAdminServiceClient) Watch(ctx context.Context, in *EchoSphereAdminServiceWatchRequest, opts ...grpc.CallOption) (EchoSphereAdminService_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &EchoSphereAdminService_ServiceDesc.Streams[0], EchoSphereAdminService_Watch_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &echoSphereAdminServiceWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EchoSphereAdminService_WatchClient interface {
+	Recv() (*EchoSphereAdminServiceWatchResponse, error)
+	grpc.ClientStream
+}
+
+type echoSphereAdminServiceWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *echoSphereAdminServiceWatchClient) Recv() (*EchoSphereAdminServiceWatchResponse, error) {
+	m := new(EchoSphereAdminServiceWatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// EchoSphereAdminServiceServer is the server API for EchoSphereAdminService service.
+// All implementations should embed UnimplementedEchoSphereAdminServiceServer
+// for forward compatibility
+type EchoSphereAdminServiceServer interface {
+	ListConnections(context.Context, *EchoSphereAdminServiceListConnectionsRequest) (*EchoSphereAdminServiceListConnectionsResponse, error)
+	CountPending(context.Context, *EchoSphereAdminServiceCountPendingRequest) (*EchoSphereAdminServiceCountPendingResponse, error)
+	ListPending(context.Context, *EchoSphereAdminServiceListPendingRequest) (*EchoSphereAdminServiceListPendingResponse, error)
+	Kick(context.Context, *EchoSphereAdminServiceKickRequest) (*EchoSphereAdminServiceKickResponse, error)
+	Drain(context.Context, *EchoSphereAdminServiceDrainRequest) (*EchoSphereAdminServiceDrainResponse, error)
+	Stats(context.Context, *EchoSphereAdminServiceStatsRequest) (*EchoSphereAdminServiceStatsResponse, error)
+	// Watch streams the lifecycle events of the server as they happen.
+	Watch(*EchoSphereAdminServiceWatchRequest, EchoSphereAdminService_WatchServer) error
+}
+
+// UnimplementedEchoSphereAdminServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedEchoSphereAdminServiceServer struct {
+}
+
+func (UnimplementedEchoSphereAdminServiceServer) ListConnections(context.Context, *EchoSphereAdminServiceListConnectionsRequest) (*EchoSphereAdminServiceListConnectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConnections not implemented")
+}
+func (UnimplementedEchoSphereAdminServiceServer) CountPending(context.Context, *EchoSphereAdminServiceCountPendingRequest) (*EchoSphereAdminServiceCountPendingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountPending not implemented")
+}
+func (UnimplementedEchoSphereAdminServiceServer) ListPending(context.Context, *EchoSphereAdminServiceListPendingRequest) (*EchoSphereAdminServiceListPendingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPending not implemented")
+}
+func (UnimplementedEchoSphereAdminServiceServer) Kick(context.Context, *EchoSphereAdminServiceKickRequest) (*EchoSphereAdminServiceKickResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Kick not implemented")
+}
+func (UnimplementedEchoSphereAdminServiceServer) Drain(context.Context, *EchoSphereAdminServiceDrainRequest) (*EchoSphereAdminServiceDrainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Drain not implemented")
+}
+func (UnimplementedEchoSphereAdminServiceServer) Stats(context.Context, *EchoSphereAdminServiceStatsRequest) (*EchoSphereAdminServiceStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stats not implemented")
+}
+func (UnimplementedEchoSphereAdminServiceServer) Watch(*EchoSphereAdminServiceWatchRequest, EchoSphereAdminService_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+
+// UnsafeEchoSphereAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EchoSphereAdminServiceServer will
+// result in compilation errors.
+type UnsafeEchoSphereAdminServiceServer interface {
+	mustEmbedUnimplementedEchoSphereAdminServiceServer()
+}
+
+func RegisterEchoSphereAdminServiceServer(s grpc.ServiceRegistrar, srv EchoSphereAdminServiceServer) {
+	s.RegisterService(&EchoSphereAdminService_ServiceDesc, srv)
+}
+
+func _EchoSphereAdminService_ListConnections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EchoSphereAdminServiceListConnectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EchoSphereAdminServiceServer).ListConnections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EchoSphereAdminService_ListConnections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EchoSphereAdminServiceServer).ListConnections(ctx, req.(*EchoSphereAdminServiceListConnectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EchoSphereAdminService_CountPending_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EchoSphereAdminServiceCountPendingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EchoSphereAdminServiceServer).CountPending(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EchoSphereAdminService_CountPending_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EchoSphereAdminServiceServer).CountPending(ctx, req.(*EchoSphereAdminServiceCountPendingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EchoSphereAdminService_ListPending_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EchoSphereAdminServiceListPendingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EchoSphereAdminServiceServer).ListPending(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EchoSphereAdminService_ListPending_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EchoSphereAdminServiceServer).ListPending(ctx, req.(*EchoSphereAdminServiceListPendingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EchoSphereAdminService_Kick_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EchoSphereAdminServiceKickRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EchoSphereAdminServiceServer).Kick(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EchoSphereAdminService_Kick_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EchoSphereAdminServiceServer).Kick(ctx, req.(*EchoSphereAdminServiceKickRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EchoSphereAdminService_Drain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EchoSphereAdminServiceDrainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EchoSphereAdminServiceServer).Drain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EchoSphereAdminService_Drain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EchoSphereAdminServiceServer).Drain(ctx, req.(*EchoSphereAdminServiceDrainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EchoSphereAdminService_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EchoSphereAdminServiceStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EchoSphereAdminServiceServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EchoSphereAdminService_Stats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EchoSphereAdminServiceServer).Stats(ctx, req.(*EchoSphereAdminServiceStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EchoSphereAdminService_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EchoSphereAdminServiceWatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EchoSphereAdminServiceServer).Watch(m, &echoSphereAdminServiceWatchServer{stream})
+}
+
+type EchoSphereAdminService_WatchServer interface {
+	Send(*EchoSphereAdminServiceWatchResponse) error
+	grpc.ServerStream
+}
+
+type echoSphereAdminServiceWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *echoSphereAdminServiceWatchServer) Send(m *EchoSphereAdminServiceWatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// EchoSphereAdminService_ServiceDesc is the grpc.ServiceDesc for EchoSphereAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EchoSphereAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.v1.EchoSphereAdminService",
+	HandlerType: (*EchoSphereAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListConnections",
+			Handler:    _EchoSphereAdminService_ListConnections_Handler,
+		},
+		{
+			MethodName: "CountPending",
+			Handler:    _EchoSphereAdminService_CountPending_Handler,
+		},
+		{
+			MethodName: "ListPending",
+			Handler:    _EchoSphereAdminService_ListPending_Handler,
+		},
+		{
+			MethodName: "Kick",
+			Handler:    _EchoSphereAdminService_Kick_Handler,
+		},
+		{
+			MethodName: "Drain",
+			Handler:    _EchoSphereAdminService_Drain_Handler,
+		},
+		{
+			MethodName: "Stats",
+			Handler:    _EchoSphereAdminService_Stats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _EchoSphereAdminService_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/v1/echosphere.admin.proto",
+}
@@ -0,0 +1,209 @@
+package v1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"time"
+)
+
+// Capture selects how much of the frames reaches the logs and the spans.
+type Capture string
+
+const (
+	// CaptureOff keeps only the type of the frames.
+	CaptureOff Capture = "off"
+	// CaptureHash keeps the parties of the frames and the hash of their content.
+	CaptureHash Capture = "hash"
+	// CaptureTruncated keeps the first bytes of the content as well.
+	CaptureTruncated Capture = "truncated"
+	// CaptureFull keeps the whole frames.
+	CaptureFull Capture = "full"
+)
+
+// CapturePolicy is how much of the frames the interceptors log and trace, TruncateAt is the number of content bytes
+// kept by CaptureTruncated. The zero policy is CaptureHash.
+type CapturePolicy struct {
+	Capture    Capture
+	TruncateAt int
+}
+
+// ParseCapture returns the Capture named s, CaptureHash when empty.
+func ParseCapture(s string) (Capture, error) {
+	switch capture := Capture(s); capture {
+	case CaptureOff, CaptureHash, CaptureTruncated, CaptureFull:
+		return capture, nil
+	case "":
+		return CaptureHash, nil
+	default:
+		return "", fmt.Errorf("unknown capture %q", s)
+	}
+}
+
+// LogSampling keeps, every second, the first First frame logs with the same message and then one in Thereafter,
+// a zero First logs every frame.
+type LogSampling struct {
+	First      int
+	Thereafter int
+}
+
+// Sampled returns the logger of the frames, sampled when asked to.
+func (l LogSampling) Sampled(logger *zap.Logger) *zap.Logger {
+	if l.First <= 0 {
+		return logger
+	}
+
+	return logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewSamplerWithOptions(core, time.Second, l.First, l.Thereafter)
+	}))
+}
+
+// ContentHash returns a short, stable fingerprint of a message content.
+func ContentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+
+	return hex.EncodeToString(sum[:8])
+}
+
+// Object returns what the policy keeps of the frame as a log object.
+func (p CapturePolicy) Object(frame any) zapcore.ObjectMarshaler { //nolint:ireturn
+	if p.Capture == CaptureFull {
+		if marshaler, ok := frame.(zapcore.ObjectMarshaler); ok {
+			return marshaler
+		}
+	}
+
+	return capturedFrame{policy: p, frame: frame}
+}
+
+// Attributes returns what the policy keeps of the frame as span attributes.
+func (p CapturePolicy) Attributes(frame any) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{attribute.String("echosphere.frame", FrameType(frame))}
+
+	switch p.Capture {
+	case CaptureOff:
+		return attrs
+	case CaptureFull:
+		if message, ok := frame.(proto.Message); ok {
+			bytes, err := protojson.Marshal(message)
+			if err == nil {
+				return append(attrs, attribute.String("Message", string(bytes)))
+			}
+		}
+	case CaptureHash, CaptureTruncated, "":
+	}
+
+	parts := framePartsOf(frame)
+
+	if parts.from != "" {
+		attrs = append(attrs, attribute.String("echosphere.from", parts.from))
+	}
+
+	if parts.to != "" {
+		attrs = append(attrs, attribute.String("echosphere.to", parts.to))
+	}
+
+	if parts.content != "" {
+		attrs = append(attrs, attribute.String("echosphere.content_hash", ContentHash(parts.content)))
+
+		if content, ok := p.truncate(parts.content); ok {
+			attrs = append(attrs, attribute.String("echosphere.content", content))
+		}
+	}
+
+	return attrs
+}
+
+// truncate returns the part of the content the policy keeps, false when it keeps none.
+func (p CapturePolicy) truncate(content string) (string, bool) {
+	switch p.Capture {
+	case CaptureTruncated:
+		if len(content) > p.TruncateAt {
+			return content[:max(p.TruncateAt, 0)], true
+		}
+
+		return content, true
+	case CaptureFull:
+		return content, true
+	case CaptureOff, CaptureHash:
+	}
+
+	return "", false
+}
+
+// capturedFrame logs what the policy keeps of a frame.
+type capturedFrame struct {
+	policy CapturePolicy
+	frame  any
+}
+
+func (c capturedFrame) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("Type", FrameType(c.frame))
+
+	if c.policy.Capture == CaptureOff {
+		return nil
+	}
+
+	parts := framePartsOf(c.frame)
+
+	if parts.from != "" {
+		enc.AddString("From", parts.from)
+	}
+
+	if parts.to != "" {
+		enc.AddString("To", parts.to)
+	}
+
+	if parts.content != "" {
+		enc.AddString("ContentHash", ContentHash(parts.content))
+
+		if content, ok := c.policy.truncate(parts.content); ok {
+			enc.AddString("Content", content)
+		}
+	}
+
+	if parts.reason != "" {
+		enc.AddString("Reason", parts.reason)
+	}
+
+	return nil
+}
+
+// frameParts are the fields of a frame the capture policies tell apart, the content is the only payload.
+type frameParts struct {
+	from, to, content, reason string
+}
+
+func framePartsOf(frame any) frameParts {
+	var (
+		message *Message
+		ack     *Ack
+		nack    *Nack
+		e       *Error
+	)
+
+	switch f := frame.(type) {
+	case *EchoSphereTransmissionServiceTransmitRequest:
+		message, ack, nack = f.GetMessage(), f.GetAck(), f.GetNack()
+	case *EchoSphereTransmissionServiceTransmitResponse:
+		message, ack, nack, e = f.GetMessage(), f.GetAck(), f.GetNack(), f.GetError()
+	}
+
+	switch {
+	case message != nil:
+		return frameParts{from: message.GetFrom(), content: message.GetContent()}
+	case ack != nil:
+		return frameParts{from: ack.GetFrom(), to: ack.GetTo(), content: ack.GetContent()}
+	case nack != nil:
+		return frameParts{from: nack.GetFrom(), to: nack.GetTo(), content: nack.GetContent(), reason: nack.GetReason().String()}
+	case e != nil:
+		return frameParts{reason: e.GetCode().String()}
+	}
+
+	return frameParts{}
+}
@@ -0,0 +1,23 @@
+package v1
+
+// NewErrorResponse builds the Error frame answering the offending request.
+func NewErrorResponse(
+	code ErrorCode,
+	message string,
+	offending *EchoSphereTransmissionServiceTransmitRequest,
+) *EchoSphereTransmissionServiceTransmitResponse {
+	frameErr := &Error{Code: code, Message: message}
+
+	switch {
+	case offending.GetMessage() != nil:
+		frameErr.Reference = &Error_OffendingMessage{OffendingMessage: offending.GetMessage()}
+	case offending.GetAck() != nil:
+		frameErr.Reference = &Error_OffendingAck{OffendingAck: offending.GetAck()}
+	case offending.GetNack() != nil:
+		frameErr.Reference = &Error_OffendingNack{OffendingNack: offending.GetNack()}
+	}
+
+	return &EchoSphereTransmissionServiceTransmitResponse{
+		OutgoingData: &EchoSphereTransmissionServiceTransmitResponse_Error{Error: frameErr},
+	}
+}
@@ -0,0 +1,630 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.1
+// 	protoc        (unknown)
+// source: api/v1/echosphere.federation.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EchoSphereFederationServiceAcquireRelayerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OwnerId string `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+}
+
+func (x *EchoSphereFederationServiceAcquireRelayerRequest) Reset() {
+	*x = EchoSphereFederationServiceAcquireRelayerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_federation_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoSphereFederationServiceAcquireRelayerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoSphereFederationServiceAcquireRelayerRequest) ProtoMessage() {}
+
+func (x *EchoSphereFederationServiceAcquireRelayerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_federation_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoSphereFederationServiceAcquireRelayerRequest.ProtoReflect.Descriptor instead.
+func (*EchoSphereFederationServiceAcquireRelayerRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_federation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EchoSphereFederationServiceAcquireRelayerRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+type EchoSphereFederationServiceAcquireRelayerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *EchoSphereFederationServiceAcquireRelayerResponse) Reset() {
+	*x = EchoSphereFederationServiceAcquireRelayerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_federation_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoSphereFederationServiceAcquireRelayerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoSphereFederationServiceAcquireRelayerResponse) ProtoMessage() {}
+
+func (x *EchoSphereFederationServiceAcquireRelayerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_federation_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoSphereFederationServiceAcquireRelayerResponse.ProtoReflect.Descriptor instead.
+func (*EchoSphereFederationServiceAcquireRelayerResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_federation_proto_rawDescGZIP(), []int{1}
+}
+
+type EchoSphereFederationServiceAcquireRandomRelayerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExcludeOwnerId string `protobuf:"bytes,1,opt,name=exclude_owner_id,json=excludeOwnerId,proto3" json:"exclude_owner_id,omitempty"`
+}
+
+func (x *EchoSphereFederationServiceAcquireRandomRelayerRequest) Reset() {
+	*x = EchoSphereFederationServiceAcquireRandomRelayerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_federation_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoSphereFederationServiceAcquireRandomRelayerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoSphereFederationServiceAcquireRandomRelayerRequest) ProtoMessage() {}
+
+func (x *EchoSphereFederationServiceAcquireRandomRelayerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_federation_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoSphereFederationServiceAcquireRandomRelayerRequest.ProtoReflect.Descriptor instead.
+func (*EchoSphereFederationServiceAcquireRandomRelayerRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_federation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EchoSphereFederationServiceAcquireRandomRelayerRequest) GetExcludeOwnerId() string {
+	if x != nil {
+		return x.ExcludeOwnerId
+	}
+	return ""
+}
+
+type EchoSphereFederationServiceAcquireRandomRelayerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OwnerId string `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+}
+
+func (x *EchoSphereFederationServiceAcquireRandomRelayerResponse) Reset() {
+	*x = EchoSphereFederationServiceAcquireRandomRelayerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_federation_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoSphereFederationServiceAcquireRandomRelayerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoSphereFederationServiceAcquireRandomRelayerResponse) ProtoMessage() {}
+
+func (x *EchoSphereFederationServiceAcquireRandomRelayerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_federation_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoSphereFederationServiceAcquireRandomRelayerResponse.ProtoReflect.Descriptor instead.
+func (*EchoSphereFederationServiceAcquireRandomRelayerResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_federation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EchoSphereFederationServiceAcquireRandomRelayerResponse) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+type EchoSphereFederationServiceReleaseRelayerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OwnerId string `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+}
+
+func (x *EchoSphereFederationServiceReleaseRelayerRequest) Reset() {
+	*x = EchoSphereFederationServiceReleaseRelayerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_federation_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoSphereFederationServiceReleaseRelayerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoSphereFederationServiceReleaseRelayerRequest) ProtoMessage() {}
+
+func (x *EchoSphereFederationServiceReleaseRelayerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_federation_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoSphereFederationServiceReleaseRelayerRequest.ProtoReflect.Descriptor instead.
+func (*EchoSphereFederationServiceReleaseRelayerRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_federation_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EchoSphereFederationServiceReleaseRelayerRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+type EchoSphereFederationServiceReleaseRelayerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *EchoSphereFederationServiceReleaseRelayerResponse) Reset() {
+	*x = EchoSphereFederationServiceReleaseRelayerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_federation_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoSphereFederationServiceReleaseRelayerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoSphereFederationServiceReleaseRelayerResponse) ProtoMessage() {}
+
+func (x *EchoSphereFederationServiceReleaseRelayerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_federation_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoSphereFederationServiceReleaseRelayerResponse.ProtoReflect.Descriptor instead.
+func (*EchoSphereFederationServiceReleaseRelayerResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_federation_proto_rawDescGZIP(), []int{5}
+}
+
+type EchoSphereFederationServiceDeliverRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OwnerId string                                         `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Frame   *EchoSphereTransmissionServiceTransmitResponse `protobuf:"bytes,2,opt,name=frame,proto3" json:"frame,omitempty"`
+}
+
+func (x *EchoSphereFederationServiceDeliverRequest) Reset() {
+	*x = EchoSphereFederationServiceDeliverRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_federation_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoSphereFederationServiceDeliverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoSphereFederationServiceDeliverRequest) ProtoMessage() {}
+
+func (x *EchoSphereFederationServiceDeliverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_federation_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoSphereFederationServiceDeliverRequest.ProtoReflect.Descriptor instead.
+func (*EchoSphereFederationServiceDeliverRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_federation_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *EchoSphereFederationServiceDeliverRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *EchoSphereFederationServiceDeliverRequest) GetFrame() *EchoSphereTransmissionServiceTransmitResponse {
+	if x != nil {
+		return x.Frame
+	}
+	return nil
+}
+
+type EchoSphereFederationServiceDeliverResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *EchoSphereFederationServiceDeliverResponse) Reset() {
+	*x = EchoSphereFederationServiceDeliverResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_federation_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoSphereFederationServiceDeliverResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoSphereFederationServiceDeliverResponse) ProtoMessage() {}
+
+func (x *EchoSphereFederationServiceDeliverResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_federation_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoSphereFederationServiceDeliverResponse.ProtoReflect.Descriptor instead.
+func (*EchoSphereFederationServiceDeliverResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_federation_proto_rawDescGZIP(), []int{7}
+}
+
+var File_api_v1_echosphere_federation_proto protoreflect.FileDescriptor
+
+var file_api_v1_echosphere_federation_proto_rawDesc = []byte{
+	0x0a, 0x22, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x63, 0x68, 0x6f, 0x73, 0x70, 0x68,
+	0x65, 0x72, 0x65, 0x2e, 0x66, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x1a, 0x17, 0x61, 0x70,
+	0x69, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x63, 0x68, 0x6f, 0x73, 0x70, 0x68, 0x65, 0x72, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4d, 0x0a, 0x30, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68,
+	0x65, 0x72, 0x65, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x71, 0x75, 0x69, 0x72, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x49, 0x64, 0x22, 0x33, 0x0a, 0x31, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65,
+	0x72, 0x65, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x41, 0x63, 0x71, 0x75, 0x69, 0x72, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x62, 0x0a, 0x36, 0x45, 0x63, 0x68,
+	0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x71, 0x75, 0x69, 0x72, 0x65, 0x52,
+	0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x6f,
+	0x77, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x65,
+	0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x22, 0x54, 0x0a,
+	0x37, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x46, 0x65, 0x64, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x71, 0x75,
+	0x69, 0x72, 0x65, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x77, 0x6e, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x77, 0x6e, 0x65,
+	0x72, 0x49, 0x64, 0x22, 0x4d, 0x0a, 0x30, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72,
+	0x65, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x77, 0x6e, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72,
+	0x49, 0x64, 0x22, 0x33, 0x0a, 0x31, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65,
+	0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x93, 0x01, 0x0a, 0x29, 0x45, 0x63, 0x68, 0x6f,
+	0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x4b, 0x0a, 0x05, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x35, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68,
+	0x65, 0x72, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x05, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x22, 0x2c, 0x0a,
+	0x2a, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x46, 0x65, 0x64, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x69,
+	0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xb9, 0x04, 0x0a, 0x1b,
+	0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x85, 0x01, 0x0a, 0x0e,
+	0x41, 0x63, 0x71, 0x75, 0x69, 0x72, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x38,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65,
+	0x72, 0x65, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x41, 0x63, 0x71, 0x75, 0x69, 0x72, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x39, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x46, 0x65, 0x64, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x71,
+	0x75, 0x69, 0x72, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x97, 0x01, 0x0a, 0x14, 0x41, 0x63, 0x71, 0x75, 0x69, 0x72, 0x65, 0x52,
+	0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x3e, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65,
+	0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x41, 0x63, 0x71, 0x75, 0x69, 0x72, 0x65, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x52, 0x65,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3f, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65,
+	0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x41, 0x63, 0x71, 0x75, 0x69, 0x72, 0x65, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x52, 0x65,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x85, 0x01,
+	0x0a, 0x0e, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x12, 0x38, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70,
+	0x68, 0x65, 0x72, 0x65, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x39, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x46, 0x65,
+	0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52,
+	0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x70, 0x0a, 0x07, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72,
+	0x12, 0x31, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70,
+	0x68, 0x65, 0x72, 0x65, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x32, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x63, 0x68,
+	0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x08, 0x5a, 0x06, 0x61, 0x70, 0x69, 0x2f, 0x76,
+	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_api_v1_echosphere_federation_proto_rawDescOnce sync.Once
+	file_api_v1_echosphere_federation_proto_rawDescData = file_api_v1_echosphere_federation_proto_rawDesc
+)
+
+func file_api_v1_echosphere_federation_proto_rawDescGZIP() []byte {
+	file_api_v1_echosphere_federation_proto_rawDescOnce.Do(func() {
+		file_api_v1_echosphere_federation_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_v1_echosphere_federation_proto_rawDescData)
+	})
+	return file_api_v1_echosphere_federation_proto_rawDescData
+}
+
+var file_api_v1_echosphere_federation_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_api_v1_echosphere_federation_proto_goTypes = []interface{}{
+	(*EchoSphereFederationServiceAcquireRelayerRequest)(nil),        // 0: api.v1.EchoSphereFederationServiceAcquireRelayerRequest
+	(*EchoSphereFederationServiceAcquireRelayerResponse)(nil),       // 1: api.v1.EchoSphereFederationServiceAcquireRelayerResponse
+	(*EchoSphereFederationServiceAcquireRandomRelayerRequest)(nil),  // 2: api.v1.EchoSphereFederationServiceAcquireRandomRelayerRequest
+	(*EchoSphereFederationServiceAcquireRandomRelayerResponse)(nil), // 3: api.v1.EchoSphereFederationServiceAcquireRandomRelayerResponse
+	(*EchoSphereFederationServiceReleaseRelayerRequest)(nil),        // 4: api.v1.EchoSphereFederationServiceReleaseRelayerRequest
+	(*EchoSphereFederationServiceReleaseRelayerResponse)(nil),       // 5: api.v1.EchoSphereFederationServiceReleaseRelayerResponse
+	(*EchoSphereFederationServiceDeliverRequest)(nil),               // 6: api.v1.EchoSphereFederationServiceDeliverRequest
+	(*EchoSphereFederationServiceDeliverResponse)(nil),              // 7: api.v1.EchoSphereFederationServiceDeliverResponse
+	(*EchoSphereTransmissionServiceTransmitResponse)(nil),           // 8: api.v1.EchoSphereTransmissionServiceTransmitResponse
+}
+var file_api_v1_echosphere_federation_proto_depIdxs = []int32{
+	8, // 0: api.v1.EchoSphereFederationServiceDeliverRequest.frame:type_name -> api.v1.EchoSphereTransmissionServiceTransmitResponse
+	0, // 1: api.v1.EchoSphereFederationService.AcquireRelayer:input_type -> api.v1.EchoSphereFederationServiceAcquireRelayerRequest
+	2, // 2: api.v1.EchoSphereFederationService.AcquireRandomRelayer:input_type -> api.v1.EchoSphereFederationServiceAcquireRandomRelayerRequest
+	4, // 3: api.v1.EchoSphereFederationService.ReleaseRelayer:input_type -> api.v1.EchoSphereFederationServiceReleaseRelayerRequest
+	6, // 4: api.v1.EchoSphereFederationService.Deliver:input_type -> api.v1.EchoSphereFederationServiceDeliverRequest
+	1, // 5: api.v1.EchoSphereFederationService.AcquireRelayer:output_type -> api.v1.EchoSphereFederationServiceAcquireRelayerResponse
+	3, // 6: api.v1.EchoSphereFederationService.AcquireRandomRelayer:output_type -> api.v1.EchoSphereFederationServiceAcquireRandomRelayerResponse
+	5, // 7: api.v1.EchoSphereFederationService.ReleaseRelayer:output_type -> api.v1.EchoSphereFederationServiceReleaseRelayerResponse
+	7, // 8: api.v1.EchoSphereFederationService.Deliver:output_type -> api.v1.EchoSphereFederationServiceDeliverResponse
+	5, // [5:9] is the sub-list for method output_type
+	1, // [1:5] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_api_v1_echosphere_federation_proto_init() }
+func file_api_v1_echosphere_federation_proto_init() {
+	if File_api_v1_echosphere_federation_proto != nil {
+		return
+	}
+	file_api_v1_echosphere_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_api_v1_echosphere_federation_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoSphereFederationServiceAcquireRelayerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_echosphere_federation_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoSphereFederationServiceAcquireRelayerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_echosphere_federation_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoSphereFederationServiceAcquireRandomRelayerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_echosphere_federation_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoSphereFederationServiceAcquireRandomRelayerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_echosphere_federation_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoSphereFederationServiceReleaseRelayerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_echosphere_federation_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoSphereFederationServiceReleaseRelayerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_echosphere_federation_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoSphereFederationServiceDeliverRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_echosphere_federation_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoSphereFederationServiceDeliverResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_api_v1_echosphere_federation_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_v1_echosphere_federation_proto_goTypes,
+		DependencyIndexes: file_api_v1_echosphere_federation_proto_depIdxs,
+		MessageInfos:      file_api_v1_echosphere_federation_proto_msgTypes,
+	}.Build()
+	File_api_v1_echosphere_federation_proto = out.File
+	file_api_v1_echosphere_federation_proto_rawDesc = nil
+	file_api_v1_echosphere_federation_proto_goTypes = nil
+	file_api_v1_echosphere_federation_proto_depIdxs = nil
+}
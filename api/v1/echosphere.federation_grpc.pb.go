@@ -0,0 +1,218 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: api/v1/echosphere.federation.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	EchoSphereFederationService_AcquireRelayer_FullMethodName       = "/api.v1.EchoSphereFederationService/AcquireRelayer"
+	EchoSphereFederationService_AcquireRandomRelayer_FullMethodName = "/api.v1.EchoSphereFederationService/AcquireRandomRelayer"
+	EchoSphereFederationService_ReleaseRelayer_FullMethodName       = "/api.v1.EchoSphereFederationService/ReleaseRelayer"
+	EchoSphereFederationService_Deliver_FullMethodName              = "/api.v1.EchoSphereFederationService/Deliver"
+)
+
+// EchoSphereFederationServiceClient is the client API for EchoSphereFederationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EchoSphereFederationServiceClient interface {
+	AcquireRelayer(ctx context.Context, in *EchoSphereFederationServiceAcquireRelayerRequest, opts ...grpc.CallOption) (*EchoSphereFederationServiceAcquireRelayerResponse, error)
+	AcquireRandomRelayer(ctx context.Context, in *EchoSphereFederationServiceAcquireRandomRelayerRequest, opts ...grpc.CallOption) (*EchoSphereFederationServiceAcquireRandomRelayerResponse, error)
+	ReleaseRelayer(ctx context.Context, in *EchoSphereFederationServiceReleaseRelayerRequest, opts ...grpc.CallOption) (*EchoSphereFederationServiceReleaseRelayerResponse, error)
+	Deliver(ctx context.Context, in *EchoSphereFederationServiceDeliverRequest, opts ...grpc.CallOption) (*EchoSphereFederationServiceDeliverResponse, error)
+}
+
+type echoSphereFederationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEchoSphereFederationServiceClient(cc grpc.ClientConnInterface) EchoSphereFederationServiceClient {
+	return &echoSphereFederationServiceClient{cc}
+}
+
+func (c *echoSphereFederationServiceClient) AcquireRelayer(ctx context.Context, in *EchoSphereFederationServiceAcquireRelayerRequest, opts ...grpc.CallOption) (*EchoSphereFederationServiceAcquireRelayerResponse, error) {
+	out := new(EchoSphereFederationServiceAcquireRelayerResponse)
+	err := c.cc.Invoke(ctx, EchoSphereFederationService_AcquireRelayer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *echoSphereFederationServiceClient) AcquireRandomRelayer(ctx context.Context, in *EchoSphereFederationServiceAcquireRandomRelayerRequest, opts ...grpc.CallOption) (*EchoSphereFederationServiceAcquireRandomRelayerResponse, error) {
+	out := new(EchoSphereFederationServiceAcquireRandomRelayerResponse)
+	err := c.cc.Invoke(ctx, EchoSphereFederationService_AcquireRandomRelayer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *echoSphereFederationServiceClient) ReleaseRelayer(ctx context.Context, in *EchoSphereFederationServiceReleaseRelayerRequest, opts ...grpc.CallOption) (*EchoSphereFederationServiceReleaseRelayerResponse, error) {
+	out := new(EchoSphereFederationServiceReleaseRelayerResponse)
+	err := c.cc.Invoke(ctx, EchoSphereFederationService_ReleaseRelayer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *echoSphereFederationServiceClient) Deliver(ctx context.Context, in *EchoSphereFederationServiceDeliverRequest, opts ...grpc.CallOption) (*EchoSphereFederationServiceDeliverResponse, error) {
+	out := new(EchoSphereFederationServiceDeliverResponse)
+	err := c.cc.Invoke(ctx, EchoSphereFederationService_Deliver_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EchoSphereFederationServiceServer is the server API for EchoSphereFederationService service.
+// All implementations should embed UnimplementedEchoSphereFederationServiceServer
+// for forward compatibility
+type EchoSphereFederationServiceServer interface {
+	AcquireRelayer(context.Context, *EchoSphereFederationServiceAcquireRelayerRequest) (*EchoSphereFederationServiceAcquireRelayerResponse, error)
+	AcquireRandomRelayer(context.Context, *EchoSphereFederationServiceAcquireRandomRelayerRequest) (*EchoSphereFederationServiceAcquireRandomRelayerResponse, error)
+	ReleaseRelayer(context.Context, *EchoSphereFederationServiceReleaseRelayerRequest) (*EchoSphereFederationServiceReleaseRelayerResponse, error)
+	Deliver(context.Context, *EchoSphereFederationServiceDeliverRequest) (*EchoSphereFederationServiceDeliverResponse, error)
+}
+
+// UnimplementedEchoSphereFederationServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedEchoSphereFederationServiceServer struct {
+}
+
+func (UnimplementedEchoSphereFederationServiceServer) AcquireRelayer(context.Context, *EchoSphereFederationServiceAcquireRelayerRequest) (*EchoSphereFederationServiceAcquireRelayerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcquireRelayer not implemented")
+}
+func (UnimplementedEchoSphereFederationServiceServer) AcquireRandomRelayer(context.Context, *EchoSphereFederationServiceAcquireRandomRelayerRequest) (*EchoSphereFederationServiceAcquireRandomRelayerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcquireRandomRelayer not implemented")
+}
+func (UnimplementedEchoSphereFederationServiceServer) ReleaseRelayer(context.Context, *EchoSphereFederationServiceReleaseRelayerRequest) (*EchoSphereFederationServiceReleaseRelayerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseRelayer not implemented")
+}
+func (UnimplementedEchoSphereFederationServiceServer) Deliver(context.Context, *EchoSphereFederationServiceDeliverRequest) (*EchoSphereFederationServiceDeliverResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Deliver not implemented")
+}
+
+// UnsafeEchoSphereFederationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EchoSphereFederationServiceServer will
+// result in compilation errors.
+type UnsafeEchoSphereFederationServiceServer interface {
+	mustEmbedUnimplementedEchoSphereFederationServiceServer()
+}
+
+func RegisterEchoSphereFederationServiceServer(s grpc.ServiceRegistrar, srv EchoSphereFederationServiceServer) {
+	s.RegisterService(&EchoSphereFederationService_ServiceDesc, srv)
+}
+
+func _EchoSphereFederationService_AcquireRelayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EchoSphereFederationServiceAcquireRelayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EchoSphereFederationServiceServer).AcquireRelayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EchoSphereFederationService_AcquireRelayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EchoSphereFederationServiceServer).AcquireRelayer(ctx, req.(*EchoSphereFederationServiceAcquireRelayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EchoSphereFederationService_AcquireRandomRelayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EchoSphereFederationServiceAcquireRandomRelayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EchoSphereFederationServiceServer).AcquireRandomRelayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EchoSphereFederationService_AcquireRandomRelayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EchoSphereFederationServiceServer).AcquireRandomRelayer(ctx, req.(*EchoSphereFederationServiceAcquireRandomRelayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EchoSphereFederationService_ReleaseRelayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EchoSphereFederationServiceReleaseRelayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EchoSphereFederationServiceServer).ReleaseRelayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EchoSphereFederationService_ReleaseRelayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EchoSphereFederationServiceServer).ReleaseRelayer(ctx, req.(*EchoSphereFederationServiceReleaseRelayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EchoSphereFederationService_Deliver_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EchoSphereFederationServiceDeliverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EchoSphereFederationServiceServer).Deliver(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EchoSphereFederationService_Deliver_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EchoSphereFederationServiceServer).Deliver(ctx, req.(*EchoSphereFederationServiceDeliverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EchoSphereFederationService_ServiceDesc is the grpc.ServiceDesc for EchoSphereFederationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EchoSphereFederationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.v1.EchoSphereFederationService",
+	HandlerType: (*EchoSphereFederationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AcquireRelayer",
+			Handler:    _EchoSphereFederationService_AcquireRelayer_Handler,
+		},
+		{
+			MethodName: "AcquireRandomRelayer",
+			Handler:    _EchoSphereFederationService_AcquireRandomRelayer_Handler,
+		},
+		{
+			MethodName: "ReleaseRelayer",
+			Handler:    _EchoSphereFederationService_ReleaseRelayer_Handler,
+		},
+		{
+			MethodName: "Deliver",
+			Handler:    _EchoSphereFederationService_Deliver_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/v1/echosphere.federation.proto",
+}
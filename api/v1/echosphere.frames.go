@@ -0,0 +1,53 @@
+package v1
+
+// FrameType names what a Transmit request or response carries: message, ack, nack or error,
+// none when it is empty and unknown for anything else.
+func FrameType(frame any) string {
+	switch f := frame.(type) {
+	case *EchoSphereTransmissionServiceTransmitRequest:
+		switch {
+		case f.GetMessage() != nil:
+			return "message"
+		case f.GetAck() != nil:
+			return "ack"
+		case f.GetNack() != nil:
+			return "nack"
+		}
+	case *EchoSphereTransmissionServiceTransmitResponse:
+		switch {
+		case f.GetMessage() != nil:
+			return "message"
+		case f.GetAck() != nil:
+			return "ack"
+		case f.GetNack() != nil:
+			return "nack"
+		case f.GetError() != nil:
+			return "error"
+		}
+	default:
+		return "unknown"
+	}
+
+	return "none"
+}
+
+// FrameTraceContext returns the W3C trace context a message or an ack carries, nil for any other frame.
+func FrameTraceContext(frame any) map[string]string {
+	var (
+		message *Message
+		ack     *Ack
+	)
+
+	switch f := frame.(type) {
+	case *EchoSphereTransmissionServiceTransmitRequest:
+		message, ack = f.GetMessage(), f.GetAck()
+	case *EchoSphereTransmissionServiceTransmitResponse:
+		message, ack = f.GetMessage(), f.GetAck()
+	}
+
+	if message != nil {
+		return message.GetTraceContext()
+	}
+
+	return ack.GetTraceContext()
+}
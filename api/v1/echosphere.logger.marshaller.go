@@ -17,6 +17,40 @@ func (m *Message) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	return nil
 }
 
+func (n *Nack) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("From", n.GetFrom())
+	enc.AddString("To", n.GetTo())
+	enc.AddString("Content", n.GetContent())
+	enc.AddString("Reason", n.GetReason().String())
+
+	return nil
+}
+
+func (e *Error) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("Code", e.GetCode().String())
+	enc.AddString("Message", e.GetMessage())
+
+	if message := e.GetOffendingMessage(); message != nil {
+		if err := enc.AddObject("OffendingMessage", message); err != nil {
+			return err
+		}
+	}
+
+	if ack := e.GetOffendingAck(); ack != nil {
+		if err := enc.AddObject("OffendingAck", ack); err != nil {
+			return err
+		}
+	}
+
+	if nack := e.GetOffendingNack(); nack != nil {
+		if err := enc.AddObject("OffendingNack", nack); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *EchoSphereTransmissionServiceTransmitRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	if message := r.GetMessage(); message != nil {
 		if err := enc.AddObject("Content", message); err != nil {
@@ -30,6 +64,12 @@ func (r *EchoSphereTransmissionServiceTransmitRequest) MarshalLogObject(enc zapc
 		}
 	}
 
+	if nack := r.GetNack(); nack != nil {
+		if err := enc.AddObject("Nack", nack); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -46,5 +86,17 @@ func (r *EchoSphereTransmissionServiceTransmitResponse) MarshalLogObject(enc zap
 		}
 	}
 
+	if nack := r.GetNack(); nack != nil {
+		if err := enc.AddObject("Nack", nack); err != nil {
+			return err
+		}
+	}
+
+	if e := r.GetError(); e != nil {
+		if err := enc.AddObject("Error", e); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
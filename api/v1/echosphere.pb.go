@@ -20,6 +20,122 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// NackReason tells the originator why its message was not acknowledged.
+type NackReason int32
+
+const (
+	NackReason_NACK_REASON_UNSPECIFIED NackReason = 0
+	// no ack arrived before the server's ack deadline
+	NackReason_NACK_REASON_TIMEOUT NackReason = 1
+	// the recipient disconnected before acknowledging
+	NackReason_NACK_REASON_RECIPIENT_GONE NackReason = 2
+	// the recipient refused the message
+	NackReason_NACK_REASON_REJECTED NackReason = 3
+)
+
+// Enum value maps for NackReason.
+var (
+	NackReason_name = map[int32]string{
+		0: "NACK_REASON_UNSPECIFIED",
+		1: "NACK_REASON_TIMEOUT",
+		2: "NACK_REASON_RECIPIENT_GONE",
+		3: "NACK_REASON_REJECTED",
+	}
+	NackReason_value = map[string]int32{
+		"NACK_REASON_UNSPECIFIED":    0,
+		"NACK_REASON_TIMEOUT":        1,
+		"NACK_REASON_RECIPIENT_GONE": 2,
+		"NACK_REASON_REJECTED":       3,
+	}
+)
+
+func (x NackReason) Enum() *NackReason {
+	p := new(NackReason)
+	*p = x
+	return p
+}
+
+func (x NackReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NackReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_echosphere_proto_enumTypes[0].Descriptor()
+}
+
+func (NackReason) Type() protoreflect.EnumType {
+	return &file_api_v1_echosphere_proto_enumTypes[0]
+}
+
+func (x NackReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NackReason.Descriptor instead.
+func (NackReason) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_proto_rawDescGZIP(), []int{0}
+}
+
+// ErrorCode classifies the failures reported in Error frames.
+type ErrorCode int32
+
+const (
+	ErrorCode_ERROR_CODE_UNSPECIFIED ErrorCode = 0
+	// the frame was dropped because its owner or address sent too many frames
+	ErrorCode_ERROR_CODE_RATE_LIMITED ErrorCode = 1
+	// the frame is malformed, e.g. it has no sender
+	ErrorCode_ERROR_CODE_INVALID_FRAME ErrorCode = 2
+	// the ack or nack is addressed to a connection that is not registered
+	ErrorCode_ERROR_CODE_UNKNOWN_ACK_TARGET ErrorCode = 3
+	// the frame could not be delivered to its recipient
+	ErrorCode_ERROR_CODE_RELAY_FAILED ErrorCode = 4
+)
+
+// Enum value maps for ErrorCode.
+var (
+	ErrorCode_name = map[int32]string{
+		0: "ERROR_CODE_UNSPECIFIED",
+		1: "ERROR_CODE_RATE_LIMITED",
+		2: "ERROR_CODE_INVALID_FRAME",
+		3: "ERROR_CODE_UNKNOWN_ACK_TARGET",
+		4: "ERROR_CODE_RELAY_FAILED",
+	}
+	ErrorCode_value = map[string]int32{
+		"ERROR_CODE_UNSPECIFIED":        0,
+		"ERROR_CODE_RATE_LIMITED":       1,
+		"ERROR_CODE_INVALID_FRAME":      2,
+		"ERROR_CODE_UNKNOWN_ACK_TARGET": 3,
+		"ERROR_CODE_RELAY_FAILED":       4,
+	}
+)
+
+func (x ErrorCode) Enum() *ErrorCode {
+	p := new(ErrorCode)
+	*p = x
+	return p
+}
+
+func (x ErrorCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_echosphere_proto_enumTypes[1].Descriptor()
+}
+
+func (ErrorCode) Type() protoreflect.EnumType {
+	return &file_api_v1_echosphere_proto_enumTypes[1]
+}
+
+func (x ErrorCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorCode.Descriptor instead.
+func (ErrorCode) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_proto_rawDescGZIP(), []int{1}
+}
+
 type Message struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -27,6 +143,8 @@ type Message struct {
 
 	From    string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
 	Content string `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	// the W3C trace context (traceparent and tracestate) of the span that sent the message
+	TraceContext map[string]string `protobuf:"bytes,4,rep,name=trace_context,json=traceContext,proto3" json:"trace_context,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *Message) Reset() {
@@ -75,6 +193,13 @@ func (x *Message) GetContent() string {
 	return ""
 }
 
+func (x *Message) GetTraceContext() map[string]string {
+	if x != nil {
+		return x.TraceContext
+	}
+	return nil
+}
+
 type Ack struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -83,6 +208,8 @@ type Ack struct {
 	From    string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
 	To      string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
 	Content string `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	// the W3C trace context (traceparent and tracestate) of the span that acknowledged the message
+	TraceContext map[string]string `protobuf:"bytes,4,rep,name=trace_context,json=traceContext,proto3" json:"trace_context,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *Ack) Reset() {
@@ -138,6 +265,199 @@ func (x *Ack) GetContent() string {
 	return ""
 }
 
+func (x *Ack) GetTraceContext() map[string]string {
+	if x != nil {
+		return x.TraceContext
+	}
+	return nil
+}
+
+// Nack tells the originator "to" that the message "content" was not acknowledged by "from".
+type Nack struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From    string     `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To      string     `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Content string     `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	Reason  NackReason `protobuf:"varint,4,opt,name=reason,proto3,enum=api.v1.NackReason" json:"reason,omitempty"`
+}
+
+func (x *Nack) Reset() {
+	*x = Nack{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Nack) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Nack) ProtoMessage() {}
+
+func (x *Nack) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Nack.ProtoReflect.Descriptor instead.
+func (*Nack) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Nack) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Nack) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *Nack) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Nack) GetReason() NackReason {
+	if x != nil {
+		return x.Reason
+	}
+	return NackReason_NACK_REASON_UNSPECIFIED
+}
+
+// Error reports that a frame was not processed, the stream stays open.
+type Error struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code    ErrorCode `protobuf:"varint,1,opt,name=code,proto3,enum=api.v1.ErrorCode" json:"code,omitempty"`
+	Message string    `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// reference is the offending frame
+	//
+	// Types that are assignable to Reference:
+	//
+	//	*Error_OffendingMessage
+	//	*Error_OffendingAck
+	//	*Error_OffendingNack
+	Reference isError_Reference `protobuf_oneof:"reference"`
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_echosphere_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_echosphere_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_api_v1_echosphere_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Error) GetCode() ErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return ErrorCode_ERROR_CODE_UNSPECIFIED
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (m *Error) GetReference() isError_Reference {
+	if m != nil {
+		return m.Reference
+	}
+	return nil
+}
+
+func (x *Error) GetOffendingMessage() *Message {
+	if x, ok := x.GetReference().(*Error_OffendingMessage); ok {
+		return x.OffendingMessage
+	}
+	return nil
+}
+
+func (x *Error) GetOffendingAck() *Ack {
+	if x, ok := x.GetReference().(*Error_OffendingAck); ok {
+		return x.OffendingAck
+	}
+	return nil
+}
+
+func (x *Error) GetOffendingNack() *Nack {
+	if x, ok := x.GetReference().(*Error_OffendingNack); ok {
+		return x.OffendingNack
+	}
+	return nil
+}
+
+type isError_Reference interface {
+	isError_Reference()
+}
+
+type Error_OffendingMessage struct {
+	OffendingMessage *Message `protobuf:"bytes,3,opt,name=offending_message,json=offendingMessage,proto3,oneof"`
+}
+
+type Error_OffendingAck struct {
+	OffendingAck *Ack `protobuf:"bytes,4,opt,name=offending_ack,json=offendingAck,proto3,oneof"`
+}
+
+type Error_OffendingNack struct {
+	OffendingNack *Nack `protobuf:"bytes,5,opt,name=offending_nack,json=offendingNack,proto3,oneof"`
+}
+
+func (*Error_OffendingMessage) isError_Reference() {}
+
+func (*Error_OffendingAck) isError_Reference() {}
+
+func (*Error_OffendingNack) isError_Reference() {}
+
 type EchoSphereTransmissionServiceTransmitRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -147,13 +467,14 @@ type EchoSphereTransmissionServiceTransmitRequest struct {
 	//
 	//	*EchoSphereTransmissionServiceTransmitRequest_Message
 	//	*EchoSphereTransmissionServiceTransmitRequest_Ack
+	//	*EchoSphereTransmissionServiceTransmitRequest_Nack
 	IncomingData isEchoSphereTransmissionServiceTransmitRequest_IncomingData `protobuf_oneof:"incoming_data"`
 }
 
 func (x *EchoSphereTransmissionServiceTransmitRequest) Reset() {
 	*x = EchoSphereTransmissionServiceTransmitRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_echosphere_proto_msgTypes[2]
+		mi := &file_api_v1_echosphere_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -166,7 +487,7 @@ func (x *EchoSphereTransmissionServiceTransmitRequest) String() string {
 func (*EchoSphereTransmissionServiceTransmitRequest) ProtoMessage() {}
 
 func (x *EchoSphereTransmissionServiceTransmitRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_echosphere_proto_msgTypes[2]
+	mi := &file_api_v1_echosphere_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -179,7 +500,7 @@ func (x *EchoSphereTransmissionServiceTransmitRequest) ProtoReflect() protorefle
 
 // Deprecated: Use EchoSphereTransmissionServiceTransmitRequest.ProtoReflect.Descriptor instead.
 func (*EchoSphereTransmissionServiceTransmitRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_echosphere_proto_rawDescGZIP(), []int{2}
+	return file_api_v1_echosphere_proto_rawDescGZIP(), []int{4}
 }
 
 func (m *EchoSphereTransmissionServiceTransmitRequest) GetIncomingData() isEchoSphereTransmissionServiceTransmitRequest_IncomingData {
@@ -203,6 +524,13 @@ func (x *EchoSphereTransmissionServiceTransmitRequest) GetAck() *Ack {
 	return nil
 }
 
+func (x *EchoSphereTransmissionServiceTransmitRequest) GetNack() *Nack {
+	if x, ok := x.GetIncomingData().(*EchoSphereTransmissionServiceTransmitRequest_Nack); ok {
+		return x.Nack
+	}
+	return nil
+}
+
 type isEchoSphereTransmissionServiceTransmitRequest_IncomingData interface {
 	isEchoSphereTransmissionServiceTransmitRequest_IncomingData()
 }
@@ -215,12 +543,19 @@ type EchoSphereTransmissionServiceTransmitRequest_Ack struct {
 	Ack *Ack `protobuf:"bytes,2,opt,name=ack,proto3,oneof"`
 }
 
+type EchoSphereTransmissionServiceTransmitRequest_Nack struct {
+	Nack *Nack `protobuf:"bytes,3,opt,name=nack,proto3,oneof"`
+}
+
 func (*EchoSphereTransmissionServiceTransmitRequest_Message) isEchoSphereTransmissionServiceTransmitRequest_IncomingData() {
 }
 
 func (*EchoSphereTransmissionServiceTransmitRequest_Ack) isEchoSphereTransmissionServiceTransmitRequest_IncomingData() {
 }
 
+func (*EchoSphereTransmissionServiceTransmitRequest_Nack) isEchoSphereTransmissionServiceTransmitRequest_IncomingData() {
+}
+
 type EchoSphereTransmissionServiceTransmitResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -230,13 +565,15 @@ type EchoSphereTransmissionServiceTransmitResponse struct {
 	//
 	//	*EchoSphereTransmissionServiceTransmitResponse_Message
 	//	*EchoSphereTransmissionServiceTransmitResponse_Ack
+	//	*EchoSphereTransmissionServiceTransmitResponse_Error
+	//	*EchoSphereTransmissionServiceTransmitResponse_Nack
 	OutgoingData isEchoSphereTransmissionServiceTransmitResponse_OutgoingData `protobuf_oneof:"outgoing_data"`
 }
 
 func (x *EchoSphereTransmissionServiceTransmitResponse) Reset() {
 	*x = EchoSphereTransmissionServiceTransmitResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_echosphere_proto_msgTypes[3]
+		mi := &file_api_v1_echosphere_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -249,7 +586,7 @@ func (x *EchoSphereTransmissionServiceTransmitResponse) String() string {
 func (*EchoSphereTransmissionServiceTransmitResponse) ProtoMessage() {}
 
 func (x *EchoSphereTransmissionServiceTransmitResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_echosphere_proto_msgTypes[3]
+	mi := &file_api_v1_echosphere_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -262,7 +599,7 @@ func (x *EchoSphereTransmissionServiceTransmitResponse) ProtoReflect() protorefl
 
 // Deprecated: Use EchoSphereTransmissionServiceTransmitResponse.ProtoReflect.Descriptor instead.
 func (*EchoSphereTransmissionServiceTransmitResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_echosphere_proto_rawDescGZIP(), []int{3}
+	return file_api_v1_echosphere_proto_rawDescGZIP(), []int{5}
 }
 
 func (m *EchoSphereTransmissionServiceTransmitResponse) GetOutgoingData() isEchoSphereTransmissionServiceTransmitResponse_OutgoingData {
@@ -286,6 +623,20 @@ func (x *EchoSphereTransmissionServiceTransmitResponse) GetAck() *Ack {
 	return nil
 }
 
+func (x *EchoSphereTransmissionServiceTransmitResponse) GetError() *Error {
+	if x, ok := x.GetOutgoingData().(*EchoSphereTransmissionServiceTransmitResponse_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *EchoSphereTransmissionServiceTransmitResponse) GetNack() *Nack {
+	if x, ok := x.GetOutgoingData().(*EchoSphereTransmissionServiceTransmitResponse_Nack); ok {
+		return x.Nack
+	}
+	return nil
+}
+
 type isEchoSphereTransmissionServiceTransmitResponse_OutgoingData interface {
 	isEchoSphereTransmissionServiceTransmitResponse_OutgoingData()
 }
@@ -298,55 +649,134 @@ type EchoSphereTransmissionServiceTransmitResponse_Ack struct {
 	Ack *Ack `protobuf:"bytes,2,opt,name=ack,proto3,oneof"`
 }
 
+type EchoSphereTransmissionServiceTransmitResponse_Error struct {
+	Error *Error `protobuf:"bytes,3,opt,name=error,proto3,oneof"`
+}
+
+type EchoSphereTransmissionServiceTransmitResponse_Nack struct {
+	Nack *Nack `protobuf:"bytes,4,opt,name=nack,proto3,oneof"`
+}
+
 func (*EchoSphereTransmissionServiceTransmitResponse_Message) isEchoSphereTransmissionServiceTransmitResponse_OutgoingData() {
 }
 
 func (*EchoSphereTransmissionServiceTransmitResponse_Ack) isEchoSphereTransmissionServiceTransmitResponse_OutgoingData() {
 }
 
+func (*EchoSphereTransmissionServiceTransmitResponse_Error) isEchoSphereTransmissionServiceTransmitResponse_OutgoingData() {
+}
+
+func (*EchoSphereTransmissionServiceTransmitResponse_Nack) isEchoSphereTransmissionServiceTransmitResponse_OutgoingData() {
+}
+
 var File_api_v1_echosphere_proto protoreflect.FileDescriptor
 
 var file_api_v1_echosphere_proto_rawDesc = []byte{
 	0x0a, 0x17, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x63, 0x68, 0x6f, 0x73, 0x70, 0x68,
 	0x65, 0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x61, 0x70, 0x69, 0x2e, 0x76,
-	0x31, 0x22, 0x37, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x31, 0x22, 0xc0, 0x01, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f,
+	0x6d, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x46, 0x0a, 0x0d, 0x74,
+	0x72, 0x61, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x74,
+	0x65, 0x78, 0x74, 0x1a, 0x3f, 0x0a, 0x11, 0x54, 0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x74,
+	0x65, 0x78, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0xc8, 0x01, 0x0a, 0x03, 0x41, 0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04,
 	0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d,
+	0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f,
 	0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x43, 0x0a, 0x03, 0x41, 0x63,
-	0x6b, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22,
-	0x8d, 0x01, 0x0a, 0x2c, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x54, 0x72,
-	0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x2b, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1f, 0x0a,
-	0x03, 0x61, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x61, 0x70, 0x69,
-	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x03, 0x61, 0x63, 0x6b, 0x42, 0x0f,
-	0x0a, 0x0d, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x22,
-	0x8e, 0x01, 0x0a, 0x2d, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x54, 0x72,
-	0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x2b, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1f,
-	0x0a, 0x03, 0x61, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x03, 0x61, 0x63, 0x6b, 0x42,
-	0x0f, 0x0a, 0x0d, 0x6f, 0x75, 0x74, 0x67, 0x6f, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x61, 0x74, 0x61,
-	0x32, 0x9c, 0x01, 0x0a, 0x1d, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x54,
+	0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x42, 0x0a, 0x0d, 0x74, 0x72,
+	0x61, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x6b, 0x2e, 0x54,
+	0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x0c, 0x74, 0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x1a, 0x3f,
+	0x0a, 0x11, 0x54, 0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x70, 0x0a, 0x04, 0x4e, 0x61, 0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74,
+	0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4e,
+	0x61, 0x63, 0x6b, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x22, 0x80, 0x02, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x25, 0x0a, 0x04, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f,
+	0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x3e, 0x0a, 0x11,
+	0x6f, 0x66, 0x66, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x10, 0x6f, 0x66, 0x66, 0x65,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x32, 0x0a, 0x0d,
+	0x6f, 0x66, 0x66, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x61, 0x63, 0x6b, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x6b,
+	0x48, 0x00, 0x52, 0x0c, 0x6f, 0x66, 0x66, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x41, 0x63, 0x6b,
+	0x12, 0x35, 0x0a, 0x0e, 0x6f, 0x66, 0x66, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x6e, 0x61,
+	0x63, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x4e, 0x61, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x0d, 0x6f, 0x66, 0x66, 0x65, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x4e, 0x61, 0x63, 0x6b, 0x42, 0x0b, 0x0a, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x22, 0xb1, 0x01, 0x0a, 0x2c, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68,
+	0x65, 0x72, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x1f, 0x0a, 0x03, 0x61, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x03,
+	0x61, 0x63, 0x6b, 0x12, 0x22, 0x0a, 0x04, 0x6e, 0x61, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x61, 0x63, 0x6b, 0x48,
+	0x00, 0x52, 0x04, 0x6e, 0x61, 0x63, 0x6b, 0x42, 0x0f, 0x0a, 0x0d, 0x69, 0x6e, 0x63, 0x6f, 0x6d,
+	0x69, 0x6e, 0x67, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x22, 0xd9, 0x01, 0x0a, 0x2d, 0x45, 0x63, 0x68,
+	0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d,
+	0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1f, 0x0a, 0x03, 0x61, 0x63, 0x6b, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63,
+	0x6b, 0x48, 0x00, 0x52, 0x03, 0x61, 0x63, 0x6b, 0x12, 0x25, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12,
+	0x22, 0x0a, 0x04, 0x6e, 0x61, 0x63, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x61, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x04, 0x6e,
+	0x61, 0x63, 0x6b, 0x42, 0x0f, 0x0a, 0x0d, 0x6f, 0x75, 0x74, 0x67, 0x6f, 0x69, 0x6e, 0x67, 0x5f,
+	0x64, 0x61, 0x74, 0x61, 0x2a, 0x7c, 0x0a, 0x0a, 0x4e, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x17, 0x4e, 0x41, 0x43, 0x4b, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f,
+	0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x17, 0x0a, 0x13, 0x4e, 0x41, 0x43, 0x4b, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x54,
+	0x49, 0x4d, 0x45, 0x4f, 0x55, 0x54, 0x10, 0x01, 0x12, 0x1e, 0x0a, 0x1a, 0x4e, 0x41, 0x43, 0x4b,
+	0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x52, 0x45, 0x43, 0x49, 0x50, 0x49, 0x45, 0x4e,
+	0x54, 0x5f, 0x47, 0x4f, 0x4e, 0x45, 0x10, 0x02, 0x12, 0x18, 0x0a, 0x14, 0x4e, 0x41, 0x43, 0x4b,
+	0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x52, 0x45, 0x4a, 0x45, 0x43, 0x54, 0x45, 0x44,
+	0x10, 0x03, 0x2a, 0xa2, 0x01, 0x0a, 0x09, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65,
+	0x12, 0x1a, 0x0a, 0x16, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17,
+	0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x52, 0x41, 0x54, 0x45, 0x5f,
+	0x4c, 0x49, 0x4d, 0x49, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x52, 0x52,
+	0x4f, 0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f,
+	0x46, 0x52, 0x41, 0x4d, 0x45, 0x10, 0x02, 0x12, 0x21, 0x0a, 0x1d, 0x45, 0x52, 0x52, 0x4f, 0x52,
+	0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x41, 0x43,
+	0x4b, 0x5f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x10, 0x03, 0x12, 0x1b, 0x0a, 0x17, 0x45, 0x52,
+	0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x52, 0x45, 0x4c, 0x41, 0x59, 0x5f, 0x46,
+	0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x32, 0x9c, 0x01, 0x0a, 0x1d, 0x45, 0x63, 0x68, 0x6f,
+	0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x7b, 0x0a, 0x08, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x6d, 0x69, 0x74, 0x12, 0x34, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45,
+	0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x35, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x54,
 	0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x12, 0x7b, 0x0a, 0x08, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x74, 0x12, 0x34,
-	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x63, 0x68, 0x6f, 0x53, 0x70, 0x68, 0x65,
-	0x72, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x35, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x63,
-	0x68, 0x6f, 0x53, 0x70, 0x68, 0x65, 0x72, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73,
-	0x6d, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42,
-	0x08, 0x5a, 0x06, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x63, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0x08, 0x5a, 0x06, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -361,25 +791,42 @@ func file_api_v1_echosphere_proto_rawDescGZIP() []byte {
 	return file_api_v1_echosphere_proto_rawDescData
 }
 
-var file_api_v1_echosphere_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_api_v1_echosphere_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_api_v1_echosphere_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_api_v1_echosphere_proto_goTypes = []interface{}{
-	(*Message)(nil), // 0: api.v1.Message
-	(*Ack)(nil),     // 1: api.v1.Ack
-	(*EchoSphereTransmissionServiceTransmitRequest)(nil),  // 2: api.v1.EchoSphereTransmissionServiceTransmitRequest
-	(*EchoSphereTransmissionServiceTransmitResponse)(nil), // 3: api.v1.EchoSphereTransmissionServiceTransmitResponse
+	(NackReason)(0), // 0: api.v1.NackReason
+	(ErrorCode)(0),  // 1: api.v1.ErrorCode
+	(*Message)(nil), // 2: api.v1.Message
+	(*Ack)(nil),     // 3: api.v1.Ack
+	(*Nack)(nil),    // 4: api.v1.Nack
+	(*Error)(nil),   // 5: api.v1.Error
+	(*EchoSphereTransmissionServiceTransmitRequest)(nil),  // 6: api.v1.EchoSphereTransmissionServiceTransmitRequest
+	(*EchoSphereTransmissionServiceTransmitResponse)(nil), // 7: api.v1.EchoSphereTransmissionServiceTransmitResponse
+	nil, // 8: api.v1.Message.TraceContextEntry
+	nil, // 9: api.v1.Ack.TraceContextEntry
 }
 var file_api_v1_echosphere_proto_depIdxs = []int32{
-	0, // 0: api.v1.EchoSphereTransmissionServiceTransmitRequest.message:type_name -> api.v1.Message
-	1, // 1: api.v1.EchoSphereTransmissionServiceTransmitRequest.ack:type_name -> api.v1.Ack
-	0, // 2: api.v1.EchoSphereTransmissionServiceTransmitResponse.message:type_name -> api.v1.Message
-	1, // 3: api.v1.EchoSphereTransmissionServiceTransmitResponse.ack:type_name -> api.v1.Ack
-	2, // 4: api.v1.EchoSphereTransmissionService.Transmit:input_type -> api.v1.EchoSphereTransmissionServiceTransmitRequest
-	3, // 5: api.v1.EchoSphereTransmissionService.Transmit:output_type -> api.v1.EchoSphereTransmissionServiceTransmitResponse
-	5, // [5:6] is the sub-list for method output_type
-	4, // [4:5] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	8,  // 0: api.v1.Message.trace_context:type_name -> api.v1.Message.TraceContextEntry
+	9,  // 1: api.v1.Ack.trace_context:type_name -> api.v1.Ack.TraceContextEntry
+	0,  // 2: api.v1.Nack.reason:type_name -> api.v1.NackReason
+	1,  // 3: api.v1.Error.code:type_name -> api.v1.ErrorCode
+	2,  // 4: api.v1.Error.offending_message:type_name -> api.v1.Message
+	3,  // 5: api.v1.Error.offending_ack:type_name -> api.v1.Ack
+	4,  // 6: api.v1.Error.offending_nack:type_name -> api.v1.Nack
+	2,  // 7: api.v1.EchoSphereTransmissionServiceTransmitRequest.message:type_name -> api.v1.Message
+	3,  // 8: api.v1.EchoSphereTransmissionServiceTransmitRequest.ack:type_name -> api.v1.Ack
+	4,  // 9: api.v1.EchoSphereTransmissionServiceTransmitRequest.nack:type_name -> api.v1.Nack
+	2,  // 10: api.v1.EchoSphereTransmissionServiceTransmitResponse.message:type_name -> api.v1.Message
+	3,  // 11: api.v1.EchoSphereTransmissionServiceTransmitResponse.ack:type_name -> api.v1.Ack
+	5,  // 12: api.v1.EchoSphereTransmissionServiceTransmitResponse.error:type_name -> api.v1.Error
+	4,  // 13: api.v1.EchoSphereTransmissionServiceTransmitResponse.nack:type_name -> api.v1.Nack
+	6,  // 14: api.v1.EchoSphereTransmissionService.Transmit:input_type -> api.v1.EchoSphereTransmissionServiceTransmitRequest
+	7,  // 15: api.v1.EchoSphereTransmissionService.Transmit:output_type -> api.v1.EchoSphereTransmissionServiceTransmitResponse
+	15, // [15:16] is the sub-list for method output_type
+	14, // [14:15] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_echosphere_proto_init() }
@@ -413,7 +860,7 @@ func file_api_v1_echosphere_proto_init() {
 			}
 		}
 		file_api_v1_echosphere_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EchoSphereTransmissionServiceTransmitRequest); i {
+			switch v := v.(*Nack); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -425,6 +872,30 @@ func file_api_v1_echosphere_proto_init() {
 			}
 		}
 		file_api_v1_echosphere_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Error); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_echosphere_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoSphereTransmissionServiceTransmitRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_echosphere_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*EchoSphereTransmissionServiceTransmitResponse); i {
 			case 0:
 				return &v.state
@@ -437,26 +908,35 @@ func file_api_v1_echosphere_proto_init() {
 			}
 		}
 	}
-	file_api_v1_echosphere_proto_msgTypes[2].OneofWrappers = []interface{}{
+	file_api_v1_echosphere_proto_msgTypes[3].OneofWrappers = []interface{}{
+		(*Error_OffendingMessage)(nil),
+		(*Error_OffendingAck)(nil),
+		(*Error_OffendingNack)(nil),
+	}
+	file_api_v1_echosphere_proto_msgTypes[4].OneofWrappers = []interface{}{
 		(*EchoSphereTransmissionServiceTransmitRequest_Message)(nil),
 		(*EchoSphereTransmissionServiceTransmitRequest_Ack)(nil),
+		(*EchoSphereTransmissionServiceTransmitRequest_Nack)(nil),
 	}
-	file_api_v1_echosphere_proto_msgTypes[3].OneofWrappers = []interface{}{
+	file_api_v1_echosphere_proto_msgTypes[5].OneofWrappers = []interface{}{
 		(*EchoSphereTransmissionServiceTransmitResponse_Message)(nil),
 		(*EchoSphereTransmissionServiceTransmitResponse_Ack)(nil),
+		(*EchoSphereTransmissionServiceTransmitResponse_Error)(nil),
+		(*EchoSphereTransmissionServiceTransmitResponse_Nack)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_v1_echosphere_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   4,
+			NumEnums:      2,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_api_v1_echosphere_proto_goTypes,
 		DependencyIndexes: file_api_v1_echosphere_proto_depIdxs,
+		EnumInfos:         file_api_v1_echosphere_proto_enumTypes,
 		MessageInfos:      file_api_v1_echosphere_proto_msgTypes,
 	}.Build()
 	File_api_v1_echosphere_proto = out.File
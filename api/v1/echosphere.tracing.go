@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// traceContext is the W3C trace context carried by messages and acks, whatever the global propagator is.
+var traceContext = propagation.TraceContext{}
+
+// InjectTraceContext returns the W3C trace context of the span in ctx, nil when there is none.
+func InjectTraceContext(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	traceContext.Inject(ctx, carrier)
+
+	if len(carrier) == 0 {
+		return nil
+	}
+
+	return carrier
+}
+
+// ExtractTraceContext returns ctx with the remote span of the W3C trace context tc, ctx as is when tc carries none.
+func ExtractTraceContext(ctx context.Context, tc map[string]string) context.Context {
+	return traceContext.Extract(ctx, propagation.MapCarrier(tc))
+}
+
+// TraceField adds the trace_id and span_id of the span in ctx to a log entry, nothing when there is none.
+// The field is ctx as well, which the OpenTelemetry logs bridge emits the entry in, so that the log record is
+// correlated with the span.
+func TraceField(ctx context.Context) zap.Field {
+	return zap.Inline(traceField{Context: ctx})
+}
+
+// FrameTraceField is the TraceField of the W3C trace context the frame carries, that of the span it was sent in.
+func FrameTraceField(frame any) zap.Field {
+	return TraceField(ExtractTraceContext(context.Background(), FrameTraceContext(frame)))
+}
+
+type traceField struct {
+	context.Context
+}
+
+func (t traceField) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	spanContext := trace.SpanContextFromContext(t.Context)
+	if !spanContext.IsValid() {
+		return nil
+	}
+
+	enc.AddString("trace_id", spanContext.TraceID().String())
+	enc.AddString("span_id", spanContext.SpanID().String())
+
+	return nil
+}
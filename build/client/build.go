@@ -12,19 +12,25 @@ import (
 const Name = "client.echosphre.io"
 
 func Run(ctx context.Context, cfg Config) error {
-	cleanUp := common.InitTracing(ctx, Name)
+	// the level is changed at runtime through the sidecar
+	logCfg := zap.NewProductionConfig()
+	logger := zap.Must(logCfg.Build()).Named(Name)
+
+	cleanUp := common.InitTracing(ctx, Name, cfg.Tracing, logger)
 	defer cleanUp()
 
+	logger, closeLogs := common.InitLogs(ctx, Name, cfg.Tracing, logger)
+	defer closeLogs()
+
 	diContainer := do.New()
 
 	do.ProvideValue[Config](diContainer, cfg)
-	do.ProvideValue[*zap.Logger](diContainer, zap.Must(zap.NewProduction()).Named(Name))
+	do.ProvideValue[*zap.Logger](diContainer, logger)
+	do.ProvideValue[zap.AtomicLevel](diContainer, logCfg.Level)
 	do.Provide[*grpc.EchoSphereClient](diContainer, ProvideEchoSphereClient)
 	do.Provide[common.HTTPSideCarServer](diContainer, common.ProvideHTTPSideCar[Config])
 
 	echoSphereClient := do.MustInvoke[*grpc.EchoSphereClient](diContainer)
-	httpSideCar := do.MustInvoke[common.HTTPSideCarServer](diContainer)
-	logger := do.MustInvoke[*zap.Logger](diContainer)
 
 	g, ctx := errgroup.WithContext(ctx)
 
@@ -32,7 +38,10 @@ func Run(ctx context.Context, cfg Config) error {
 
 	g.Go(func() error { return echoSphereClient.Run(ctx) })
 
+	// a disabled sidecar is not even built, nor its Prometheus exporter
 	if cfg.SideCar.Enabled {
+		httpSideCar := do.MustInvoke[common.HTTPSideCarServer](diContainer)
+
 		g.Go(func() error { return httpSideCar.Run(ctx) })
 	}
 
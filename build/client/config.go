@@ -1,26 +1,40 @@
 package client
 
 import (
+	"github.com/k4l1ma/EchoSphere/build/common"
 	"time"
 )
 
 type Config struct {
-	Target   string        `snout:"target" default:"localhost:8080"`
-	DeadLine time.Duration `snout:"deadline" default:"30s"`
-	SideCar  SideCar       `snout:"sidecar"`
+	Target   string               `snout:"target" default:"localhost:8080"`
+	DeadLine time.Duration        `snout:"deadline" default:"30s"`
+	SideCar  SideCar              `snout:"sidecar"`
+	Capture  common.CaptureConfig `snout:"capture"`
+	// Tracing configures the exporter and the sampling of the traces.
+	Tracing common.TracingConfig `snout:"tracing"`
 }
 
+// SideCar configures the HTTP sidecar, it is not built unless Enabled, and loadgen runs its clients without any.
+// LatencyBuckets are the comma-separated boundaries in seconds of the latency histograms,
+// common.DefaultLatencyBuckets when empty. ProfileDir is where the heap profiles are written, the temporary
+// directory when empty.
 type SideCar struct {
-	Enabled bool `snout:"enabled" default:"true"`
-	Port    int  `snout:"port" default:"9091"`
+	Enabled        bool   `snout:"enabled" default:"true"`
+	Port           int    `snout:"port" default:"9091"`
+	LatencyBuckets string `snout:"latency_buckets"`
+	ProfileDir     string `snout:"profile_dir"`
 }
 
 func (c Config) GetSideCar() struct {
-	Enabled bool
-	Port    int
+	Enabled        bool
+	Port           int
+	LatencyBuckets string
+	ProfileDir     string
 } {
 	return struct {
-		Enabled bool
-		Port    int
+		Enabled        bool
+		Port           int
+		LatencyBuckets string
+		ProfileDir     string
 	}(c.SideCar)
 }
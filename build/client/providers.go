@@ -9,10 +9,17 @@ import (
 func ProvideEchoSphereClient(i do.Injector) (*grpc.EchoSphereClient, error) {
 	cfg := do.MustInvoke[Config](i)
 
+	capture, err := cfg.Capture.Policy()
+	if err != nil {
+		return nil, err
+	}
+
 	clientCfg := grpc.Config{
-		Logger:   do.MustInvoke[*zap.Logger](i),
-		Target:   cfg.Target,
-		Deadline: cfg.DeadLine,
+		Logger:      do.MustInvoke[*zap.Logger](i),
+		Target:      cfg.Target,
+		Deadline:    cfg.DeadLine,
+		Capture:     capture,
+		LogSampling: cfg.Capture.Sampling(),
 	}
 
 	return grpc.NewEchoSphereClient(clientCfg)
@@ -0,0 +1,29 @@
+package common
+
+import v1 "github.com/k4l1ma/EchoSphere/api/v1"
+
+// CaptureConfig selects how much of the frames the frame logs and spans keep: off only their type, hash their
+// parties and the hash of their content, truncated the first TruncateAt bytes of the content as well and full the
+// whole frames. Every second the first SampleFirst frame logs with the same message are kept and then one in
+// SampleThereafter, 0 keeps them all.
+type CaptureConfig struct {
+	Payload          string `snout:"payload" default:"hash" validate:"oneof=off hash truncated full"`
+	TruncateAt       int    `snout:"truncate_at" default:"16"`
+	SampleFirst      int    `snout:"sample_first" default:"0"`
+	SampleThereafter int    `snout:"sample_thereafter" default:"0"`
+}
+
+// Policy returns the capture policy of the frame logs and spans.
+func (c CaptureConfig) Policy() (v1.CapturePolicy, error) {
+	capture, err := v1.ParseCapture(c.Payload)
+	if err != nil {
+		return v1.CapturePolicy{}, err
+	}
+
+	return v1.CapturePolicy{Capture: capture, TruncateAt: c.TruncateAt}, nil
+}
+
+// Sampling returns the sampling of the frame logs.
+func (c CaptureConfig) Sampling() v1.LogSampling {
+	return v1.LogSampling{First: c.SampleFirst, Thereafter: c.SampleThereafter}
+}
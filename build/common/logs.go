@@ -0,0 +1,148 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/contrib/bridges/otelzap"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
+	"go.opentelemetry.io/otel/log/global"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"os"
+)
+
+// InitLogs returns logger also emitting its entries through the OpenTelemetry logs pipeline of cfg, along with the
+// flush and shutdown of the pipeline. Like the traces, a pipeline that cannot be created is logged and the logs
+// stay where logger writes them.
+func InitLogs(ctx context.Context, name string, cfg TracingConfig, logger *zap.Logger) (*zap.Logger, func()) {
+	noop := func() {}
+
+	exporter, closeExporter, err := newLogExporter(ctx, cfg)
+	if err != nil {
+		logger.Warn("Logs are not exported", zap.String("exporter", cfg.LogsExporter), zap.Error(err))
+
+		return logger, noop
+	}
+
+	if exporter == nil {
+		return logger, noop
+	}
+
+	// InitTracing already warned about invalid attributes
+	res, err := tracingResource(name, cfg.Attributes)
+	if err != nil {
+		res, _ = tracingResource(name, "") //nolint:errcheck
+	}
+
+	loggerProvider := sdklog.NewLoggerProvider(
+		sdklog.WithResource(res),
+		sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter, sdklog.WithExportTimeout(cfg.Timeout))),
+	)
+
+	global.SetLoggerProvider(loggerProvider)
+
+	bridge := otelzap.NewCore(name, otelzap.WithLoggerProvider(loggerProvider))
+
+	bridged := logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, bridgeCore{LevelEnabler: core, bridge: bridge})
+	}))
+
+	return bridged, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+		defer cancel()
+
+		if err := loggerProvider.Shutdown(ctx); err != nil {
+			logger.Warn("Failed to flush the logs", zap.Error(err))
+		}
+
+		closeExporter()
+	}
+}
+
+// newLogExporter creates the logs exporter of cfg, nil for none, and what closes the file it writes to.
+func newLogExporter(ctx context.Context, cfg TracingConfig) (sdklog.Exporter, func(), error) { //nolint:ireturn
+	noop := func() {}
+
+	switch cfg.LogsExporter {
+	case "", ExporterNone:
+		return nil, noop, nil
+	case ExporterStdout:
+		exporter, err := stdoutlog.New(stdoutlog.WithWriter(os.Stdout))
+
+		return exporter, noop, err
+	case ExporterFile:
+		file, err := os.OpenFile(cfg.LogsPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644) //nolint:gosec
+		if err != nil {
+			return nil, noop, err
+		}
+
+		exporter, err := stdoutlog.New(stdoutlog.WithWriter(file))
+		if err != nil {
+			_ = file.Close() //nolint:errcheck
+
+			return nil, noop, err
+		}
+
+		return exporter, func() { _ = file.Close() }, nil //nolint:errcheck
+	case ExporterOTLPGRPC:
+		opts := []otlploggrpc.Option{otlploggrpc.WithTimeout(cfg.Timeout)}
+		if cfg.LogsEndpoint != "" {
+			opts = append(opts, otlploggrpc.WithEndpoint(cfg.LogsEndpoint))
+		}
+
+		if cfg.Insecure {
+			opts = append(opts, otlploggrpc.WithInsecure())
+		}
+
+		exporter, err := otlploggrpc.New(ctx, opts...)
+
+		return exporter, noop, err
+	case ExporterOTLPHTTP:
+		opts := []otlploghttp.Option{otlploghttp.WithTimeout(cfg.Timeout)}
+		if cfg.LogsEndpoint != "" {
+			opts = append(opts, otlploghttp.WithEndpoint(cfg.LogsEndpoint))
+		}
+
+		if cfg.Insecure {
+			opts = append(opts, otlploghttp.WithInsecure())
+		}
+
+		exporter, err := otlploghttp.New(ctx, opts...)
+
+		return exporter, noop, err
+	default:
+		return nil, noop, fmt.Errorf("unknown logs exporter %q", cfg.LogsExporter)
+	}
+}
+
+// bridgeCore emits the entries enabled by the logger it is teed with through the otelzap bridge, a v1.TraceField
+// being the context of the record. The bridge keeps the context of the last entry on the core writing it, so each
+// entry is written by a clone of the bridge, the loggers being shared by the goroutines.
+type bridgeCore struct {
+	zapcore.LevelEnabler
+
+	bridge zapcore.Core
+}
+
+func (c bridgeCore) With(fields []zapcore.Field) zapcore.Core { //nolint:ireturn
+	return bridgeCore{LevelEnabler: c.LevelEnabler, bridge: c.bridge.With(fields)}
+}
+
+func (c bridgeCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+
+	return checked
+}
+
+func (c bridgeCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.bridge.With(nil).Write(entry, fields)
+}
+
+func (c bridgeCore) Sync() error {
+	return c.bridge.Sync()
+}
@@ -0,0 +1,84 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestInitLogs(t *testing.T) {
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+
+	path := filepath.Join(t.TempDir(), "logs.jsonl")
+	core, logs := observer.New(zap.InfoLevel)
+
+	logger, closeLogs := InitLogs(context.Background(), "echosphere", TracingConfig{
+		LogsExporter: ExporterFile,
+		LogsPath:     path,
+		Timeout:      time.Second,
+	}, zap.New(core))
+
+	message := &v1.Message{From: "originator", Content: "a message"}
+
+	logger.Info("traced", zap.Object("message", message), v1.TraceField(ctx))
+	logger.Info("untraced", v1.TraceField(context.Background()))
+	// below the level of the logger, neither written nor exported
+	logger.Debug("debug", v1.TraceField(ctx))
+	closeLogs()
+
+	require.Len(t, logs.All(), 2)
+	assert.Equal(t, spanContext.TraceID().String(), logs.All()[0].ContextMap()["trace_id"])
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	type record struct {
+		Body       struct{ Value string }
+		TraceID    string
+		SpanID     string
+		Attributes []struct {
+			Key   string
+			Value struct {
+				Type  string
+				Value any
+			}
+		}
+	}
+
+	var records []record
+
+	for decoder := json.NewDecoder(file); decoder.More(); {
+		var r record
+		require.NoError(t, decoder.Decode(&r))
+
+		records = append(records, r)
+	}
+
+	require.Len(t, records, 2)
+
+	assert.Equal(t, "traced", records[0].Body.Value)
+	assert.Equal(t, spanContext.TraceID().String(), records[0].TraceID)
+	assert.Equal(t, spanContext.SpanID().String(), records[0].SpanID)
+	// the objects stay structured
+	require.Len(t, records[0].Attributes, 1)
+	assert.Equal(t, "message", records[0].Attributes[0].Key)
+	assert.Equal(t, "Map", records[0].Attributes[0].Value.Type)
+
+	assert.Equal(t, "untraced", records[1].Body.Value)
+	assert.Equal(t, trace.TraceID{}.String(), records[1].TraceID)
+}
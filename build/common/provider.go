@@ -2,23 +2,24 @@ package common
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/samber/do/v2"
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/prometheus"
-	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/metric"
-	"go.opentelemetry.io/otel/sdk/resource"
-	"go.opentelemetry.io/otel/sdk/trace"
-	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 	"log"
 	"net/http"
 	"net/http/pprof"
 	"os"
+	"runtime"
+	rpprof "runtime/pprof"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -42,45 +43,47 @@ func (h HTTPSideCarServer) Run(ctx context.Context) error {
 	return g.Wait()
 }
 
-func InitTracing(ctx context.Context, name string) func() {
-	exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithInsecure())
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	tracerProvider := trace.NewTracerProvider(
-		trace.WithBatcher(exporter),
-		trace.WithSampler(trace.AlwaysSample()),
-		trace.WithResource(resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceNameKey.String(name),
-		)),
-	)
+// StatsFunc returns a snapshot served as JSON by the sidecar on /debug/stats, the endpoint answers 404 when
+// none is provided.
+type StatsFunc func() any
 
-	otel.SetTracerProvider(tracerProvider)
-	otel.SetTextMapPropagator(
-		propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}),
-	)
+// MultiplexerStateFunc returns the dump of the relayers served as JSON by the sidecar on /debug/multiplexer, the
+// endpoint answers 404 when none is provided.
+type MultiplexerStateFunc func() any
 
-	return func() {
-		_ = tracerProvider.Shutdown(context.Background()) //nolint:errcheck
-	}
-}
+// HeavyHittersFunc returns the owner IDs sending or receiving the most frames served as JSON by the sidecar on
+// /debug/heavyhitters, the endpoint answers 404 when none is provided.
+type HeavyHittersFunc func() any
 
 type GenericConfig interface {
 	GetSideCar() struct {
-		Enabled bool
-		Port    int
+		Enabled        bool
+		Port           int
+		LatencyBuckets string
+		ProfileDir     string
 	}
 }
 
+// DefaultLatencyBuckets are the boundaries, in seconds, of the latency histograms when none are configured.
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
 func ProvideHTTPSideCar[T GenericConfig](i do.Injector) (HTTPSideCarServer, error) {
 	cfg := do.MustInvoke[T](i)
 
 	mux := http.NewServeMux()
 	attachPprof(mux)
-	attachPrometheus(mux)
+	latencyBuckets, err := ParseBuckets(cfg.GetSideCar().LatencyBuckets)
+	if err != nil {
+		return HTTPSideCarServer{}, err
+	}
+
+	attachPrometheus(mux, latencyBuckets)
 	attachHealz(mux, i)
+	attachJSON[StatsFunc](mux, i, "/debug/stats")
+	attachJSON[MultiplexerStateFunc](mux, i, "/debug/multiplexer")
+	attachJSON[HeavyHittersFunc](mux, i, "/debug/heavyhitters")
+	attachLogLevel(mux, i)
+	attachHeapProfile(mux, cfg.GetSideCar().ProfileDir)
 
 	return HTTPSideCarServer{
 		Server: &http.Server{
@@ -91,6 +94,34 @@ func ProvideHTTPSideCar[T GenericConfig](i do.Injector) (HTTPSideCarServer, erro
 	}, nil
 }
 
+// ErrInvalidBuckets is returned for histogram boundaries that are not increasing numbers.
+var ErrInvalidBuckets = errors.New("invalid histogram buckets")
+
+// ParseBuckets parses comma-separated, increasing histogram boundaries, DefaultLatencyBuckets when empty.
+func ParseBuckets(s string) ([]float64, error) {
+	if strings.TrimSpace(s) == "" {
+		return DefaultLatencyBuckets, nil
+	}
+
+	fields := strings.Split(s, ",")
+	buckets := make([]float64, 0, len(fields))
+
+	for _, field := range fields {
+		bucket, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %w", ErrInvalidBuckets, s, err)
+		}
+
+		if len(buckets) > 0 && bucket <= buckets[len(buckets)-1] {
+			return nil, fmt.Errorf("%w: %q: boundaries must increase", ErrInvalidBuckets, s)
+		}
+
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets, nil
+}
+
 // attachPprof creates a pprof endpoints
 func attachPprof(mux *http.ServeMux) {
 	mux.HandleFunc("/debug/pprof/", pprof.Index)
@@ -100,7 +131,8 @@ func attachPprof(mux *http.ServeMux) {
 	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
 }
 
-func attachPrometheus(mux *http.ServeMux) {
+// attachPrometheus creates the metrics endpoint, the histograms named *_latency_seconds use the latency buckets.
+func attachPrometheus(mux *http.ServeMux, latencyBuckets []float64) {
 	os.Setenv("OTEL_SERVICE_NAME", "echosphere.io")
 
 	exporter, err := prometheus.New()
@@ -108,24 +140,154 @@ func attachPrometheus(mux *http.ServeMux) {
 		log.Fatal(err)
 	}
 
-	otel.SetMeterProvider(metric.NewMeterProvider(metric.WithReader(exporter)))
+	otel.SetMeterProvider(metric.NewMeterProvider(
+		metric.WithReader(exporter),
+		metric.WithView(metric.NewView(
+			metric.Instrument{Name: "*_latency_seconds"},
+			metric.Stream{Aggregation: metric.AggregationExplicitBucketHistogram{Boundaries: latencyBuckets}},
+		)),
+	))
 
 	mux.Handle("/metrics", promhttp.Handler())
 }
 
+// Health is the body of the health endpoints, Dependencies are the health checks of the injector, ok or the failure.
+type Health struct {
+	Status       string            `json:"status"`
+	Dependencies map[string]string `json:"dependencies,omitempty"`
+}
+
+// attachHealz creates the health endpoints: /health/live answers 200 as long as the process is up, /health/ready
+// answers 200 when every health check of the injector passes and 503 otherwise, e.g. while the gRPC server starts
+// or drains.
 func attachHealz(mux *http.ServeMux, i do.Injector) {
-	handler := func(w http.ResponseWriter, _ *http.Request) {
-		checks := i.HealthCheck()
+	mux.HandleFunc("/health/live", func(w http.ResponseWriter, _ *http.Request) {
+		writeHealth(w, http.StatusOK, Health{Status: "up"})
+	})
+
+	mux.HandleFunc("/health/ready", func(w http.ResponseWriter, r *http.Request) {
+		health, code := Health{Status: "ready", Dependencies: map[string]string{}}, http.StatusOK
 
-		for dep, err := range checks {
+		for dep, err := range healthChecks(r.Context(), i) {
 			if err != nil {
-				http.Error(w, fmt.Sprintf("dependency: %s:%v", dep, err), http.StatusInternalServerError)
+				health.Dependencies[dep] = err.Error()
+				health.Status, code = "not ready", http.StatusServiceUnavailable
+
+				continue
 			}
+
+			health.Dependencies[dep] = "ok"
 		}
 
-		w.WriteHeader(http.StatusNoContent)
+		writeHealth(w, code, health)
+	})
+}
+
+// healthChecks runs the health checks of the injector, only the services built that have one are reported.
+func healthChecks(ctx context.Context, i do.Injector) map[string]error {
+	checks := i.HealthCheckWithContext(ctx)
+
+	healthCheckers := make(map[string]bool, len(checks))
+
+	for _, scope := range do.ExplainInjector(i).DAG {
+		for _, service := range scope.Services {
+			healthCheckers[service.ServiceName] = service.IsHealthchecker
+		}
+	}
+
+	for dep := range checks {
+		if !healthCheckers[dep] {
+			delete(checks, dep)
+		}
+	}
+
+	return checks
+}
+
+func writeHealth(w http.ResponseWriter, code int, health Health) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	_ = json.NewEncoder(w).Encode(health) //nolint:errcheck
+}
+
+// attachJSON creates the endpoint serving as JSON the snapshot of the T provided to the injector, it is invoked on
+// the first request so it may depend on services built after the sidecar.
+func attachJSON[T ~func() any](mux *http.ServeMux, i do.Injector, path string) {
+	mux.HandleFunc(path, func(w http.ResponseWriter, _ *http.Request) {
+		snapshot, err := do.Invoke[T](i)
+		if err != nil {
+			http.NotFound(w, nil)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := json.NewEncoder(w).Encode(snapshot()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// attachLogLevel creates the endpoint of the level of the logger, GET returns it and PUT changes it, with the JSON
+// {"level":"debug"} or the form level=debug.
+func attachLogLevel(mux *http.ServeMux, i do.Injector) {
+	mux.HandleFunc("/debug/loglevel", func(w http.ResponseWriter, r *http.Request) {
+		level, err := do.Invoke[zap.AtomicLevel](i)
+		if err != nil {
+			http.NotFound(w, nil)
+
+			return
+		}
+
+		level.ServeHTTP(w, r)
+	})
+}
+
+// attachHeapProfile creates the endpoint that writes a heap profile to a new file of dir, the temporary directory
+// when empty, on POST and answers with its path.
+func attachHeapProfile(mux *http.ServeMux, dir string) {
+	if dir == "" {
+		dir = os.TempDir()
+	}
+
+	mux.HandleFunc("/debug/heapprofile", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "a heap profile is written on POST", http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		path, err := writeHeapProfile(dir)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := json.NewEncoder(w).Encode(map[string]string{"path": path}); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// writeHeapProfile writes the heap profile, up to date with a garbage collection, to a new file of dir.
+func writeHeapProfile(dir string) (string, error) {
+	file, err := os.CreateTemp(dir, fmt.Sprintf("heap-%s-*.pprof", time.Now().UTC().Format("20060102T150405")))
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	runtime.GC()
+
+	if err := rpprof.Lookup("heap").WriteTo(file, 0); err != nil {
+		return "", err
 	}
 
-	mux.HandleFunc("/health/live", handler)
-	mux.HandleFunc("/health/ready", handler)
+	return file.Name(), file.Close()
 }
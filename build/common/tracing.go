@@ -0,0 +1,190 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.uber.org/zap"
+	"os"
+	"strings"
+	"time"
+)
+
+// The exporters of the traces.
+const (
+	ExporterNone     = "none"
+	ExporterStdout   = "stdout"
+	ExporterFile     = "file"
+	ExporterOTLPGRPC = "otlp-grpc"
+	ExporterOTLPHTTP = "otlp-http"
+)
+
+// TracingConfig configures where the traces go and which are kept.
+// Exporter is none by default to keep the traces in the process, which still propagates the trace context, stdout
+// or file to write them as JSON lines, to Path for file, or otlp-grpc and otlp-http to send them to the collector at
+// Endpoint, the OTEL_EXPORTER_OTLP_* environment or the OTLP default when empty. Timeout bounds each export and the
+// flush on shutdown, so a missing collector does not hold up the exit.
+// SampleRatio is the fraction of the traces started here that are kept, with ParentBased a trace continued from a
+// remote span keeps the decision of its parent. Attributes are comma-separated key=value resource attributes.
+// LogsExporter also sends the logs through the OpenTelemetry logs pipeline, with the same choices as Exporter, the
+// file being LogsPath and the collector LogsEndpoint, and the same Insecure, Timeout and Attributes as the traces.
+// It is none by default to keep the logs where the logger writes them only.
+type TracingConfig struct {
+	Exporter    string        `snout:"exporter" default:"none" validate:"oneof=none stdout file otlp-grpc otlp-http"`
+	Endpoint    string        `snout:"endpoint"`
+	Insecure    bool          `snout:"insecure" default:"true"`
+	Path        string        `snout:"path" default:"echosphere-traces.jsonl"`
+	Timeout     time.Duration `snout:"timeout" default:"5s"`
+	SampleRatio float64       `snout:"sample_ratio" default:"1"`
+	ParentBased bool          `snout:"parent_based" default:"true"`
+	Attributes  string        `snout:"attributes"`
+
+	LogsExporter string `snout:"logs_exporter" default:"none" validate:"oneof=none stdout file otlp-grpc otlp-http"`
+	LogsEndpoint string `snout:"logs_endpoint"`
+	LogsPath     string `snout:"logs_path" default:"echosphere-logs.jsonl"`
+}
+
+// ErrInvalidAttributes is returned for resource attributes that are not comma-separated key=value pairs.
+var ErrInvalidAttributes = errors.New("invalid resource attributes")
+
+// InitTracing sets the global tracer provider and the W3C propagators, it returns the flush and shutdown of the
+// provider. Tracing never stops the process: an exporter that cannot be created is logged and the traces are not
+// exported, and the failures to export are logged as warnings.
+func InitTracing(ctx context.Context, name string, cfg TracingConfig, logger *zap.Logger) func() {
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		logger.Warn("OpenTelemetry failure", zap.Error(err))
+	}))
+
+	otel.SetTextMapPropagator(
+		propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}),
+	)
+
+	res, err := tracingResource(name, cfg.Attributes)
+	if err != nil {
+		logger.Warn("Ignoring the resource attributes", zap.Error(err))
+
+		res, _ = tracingResource(name, "") //nolint:errcheck
+	}
+
+	opts := []trace.TracerProviderOption{trace.WithSampler(sampler(cfg)), trace.WithResource(res)}
+
+	exporter, closeExporter, err := newExporter(ctx, cfg)
+	if err != nil {
+		logger.Warn("Traces are not exported", zap.String("exporter", cfg.Exporter), zap.Error(err))
+	}
+
+	if exporter != nil {
+		opts = append(opts, trace.WithBatcher(exporter, trace.WithExportTimeout(cfg.Timeout)))
+	}
+
+	tracerProvider := trace.NewTracerProvider(opts...)
+
+	otel.SetTracerProvider(tracerProvider)
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+		defer cancel()
+
+		if err := tracerProvider.Shutdown(ctx); err != nil {
+			logger.Warn("Failed to flush the traces", zap.Error(err))
+		}
+
+		closeExporter()
+	}
+}
+
+// newExporter creates the exporter of cfg, nil for none, and what closes the file it writes to.
+func newExporter(ctx context.Context, cfg TracingConfig) (trace.SpanExporter, func(), error) { //nolint:ireturn
+	noop := func() {}
+
+	switch cfg.Exporter {
+	case "", ExporterNone:
+		return nil, noop, nil
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+
+		return exporter, noop, err
+	case ExporterFile:
+		file, err := os.OpenFile(cfg.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644) //nolint:gosec
+		if err != nil {
+			return nil, noop, err
+		}
+
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			_ = file.Close() //nolint:errcheck
+
+			return nil, noop, err
+		}
+
+		return exporter, func() { _ = file.Close() }, nil //nolint:errcheck
+	case ExporterOTLPGRPC:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithTimeout(cfg.Timeout)}
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(cfg.Endpoint))
+		}
+
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+
+		exporter, err := otlptracegrpc.New(ctx, opts...)
+
+		return exporter, noop, err
+	case ExporterOTLPHTTP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithTimeout(cfg.Timeout)}
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(cfg.Endpoint))
+		}
+
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+
+		exporter, err := otlptracehttp.New(ctx, opts...)
+
+		return exporter, noop, err
+	default:
+		return nil, noop, fmt.Errorf("unknown exporter %q", cfg.Exporter)
+	}
+}
+
+// sampler keeps SampleRatio of the traces, those continued from a remote span follow their parent when ParentBased.
+func sampler(cfg TracingConfig) trace.Sampler { //nolint:ireturn
+	root := trace.TraceIDRatioBased(cfg.SampleRatio)
+
+	if cfg.ParentBased {
+		return trace.ParentBased(root)
+	}
+
+	return root
+}
+
+// tracingResource describes the process by its name and the comma-separated key=value attributes.
+func tracingResource(name, attributes string) (*resource.Resource, error) {
+	attrs := []attribute.KeyValue{semconv.ServiceNameKey.String(name)}
+
+	for _, field := range strings.Split(attributes, ",") {
+		if strings.TrimSpace(field) == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(field, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidAttributes, attributes)
+		}
+
+		attrs = append(attrs, attribute.String(strings.TrimSpace(key), strings.TrimSpace(value)))
+	}
+
+	return resource.NewWithAttributes(semconv.SchemaURL, attrs...), nil
+}
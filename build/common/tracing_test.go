@@ -0,0 +1,76 @@
+package common
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.opentelemetry.io/otel/trace"
+	"testing"
+)
+
+func TestTracingResource(t *testing.T) {
+	service := semconv.ServiceNameKey.String("echosphere")
+
+	for name, tc := range map[string]struct {
+		attributes string
+		want       []attribute.KeyValue
+		err        bool
+	}{
+		"none":         {attributes: "", want: []attribute.KeyValue{service}},
+		"blank":        {attributes: " , ", want: []attribute.KeyValue{service}},
+		"trimmed":      {attributes: " env = prod ,region=eu", want: []attribute.KeyValue{attribute.String("env", "prod"), attribute.String("region", "eu"), service}},
+		"empty value":  {attributes: "env=", want: []attribute.KeyValue{attribute.String("env", ""), service}},
+		"value with =": {attributes: "query=a=b", want: []attribute.KeyValue{attribute.String("query", "a=b"), service}},
+		"no value":     {attributes: "env", err: true},
+		"no key":       {attributes: "=prod", err: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			res, err := tracingResource("echosphere", tc.attributes)
+			if tc.err {
+				require.ErrorIs(t, err, ErrInvalidAttributes)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, res.Attributes())
+		})
+	}
+}
+
+func TestSampler(t *testing.T) {
+	remote := func(flags trace.TraceFlags) context.Context {
+		return trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{1},
+			SpanID:     trace.SpanID{1},
+			TraceFlags: flags,
+			Remote:     true,
+		}))
+	}
+
+	for name, tc := range map[string]struct {
+		cfg  TracingConfig
+		ctx  context.Context
+		want sdktrace.SamplingDecision
+	}{
+		"root kept":                     {cfg: TracingConfig{SampleRatio: 1}, ctx: context.Background(), want: sdktrace.RecordAndSample},
+		"root dropped":                  {cfg: TracingConfig{SampleRatio: 0}, ctx: context.Background(), want: sdktrace.Drop},
+		"sampled parent followed":       {cfg: TracingConfig{SampleRatio: 0, ParentBased: true}, ctx: remote(trace.FlagsSampled), want: sdktrace.RecordAndSample},
+		"unsampled parent followed":     {cfg: TracingConfig{SampleRatio: 1, ParentBased: true}, ctx: remote(0), want: sdktrace.Drop},
+		"sampled parent not followed":   {cfg: TracingConfig{SampleRatio: 0}, ctx: remote(trace.FlagsSampled), want: sdktrace.Drop},
+		"unsampled parent not followed": {cfg: TracingConfig{SampleRatio: 1}, ctx: remote(0), want: sdktrace.RecordAndSample},
+	} {
+		t.Run(name, func(t *testing.T) {
+			result := sampler(tc.cfg).ShouldSample(sdktrace.SamplingParameters{
+				ParentContext: tc.ctx,
+				TraceID:       trace.TraceID{2},
+				Name:          "span",
+			})
+
+			assert.Equal(t, tc.want, result.Decision)
+		})
+	}
+}
@@ -2,9 +2,17 @@ package server
 
 import (
 	"context"
+	"errors"
 	"github.com/k4l1ma/EchoSphere/build/common"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/core"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/admin"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/audit"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/federation"
 	grpc "github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/gRPC"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/heavyhitters"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/metrics"
 	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/multiplexer"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/redis"
 	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
 	"github.com/samber/do/v2"
 	"go.uber.org/zap"
@@ -14,27 +22,120 @@ import (
 
 const Name = "server.echosphere.io"
 
+// RedisBackend is the Router backend sharing the relay pool through Redis.
+const RedisBackend = "redis"
+
 func Run(ctx context.Context, cfg Config) error {
-	cleanUp := common.InitTracing(ctx, Name)
+	// the level is changed at runtime through the sidecar
+	logCfg := zap.NewProductionConfig()
+	logger := zap.Must(logCfg.Build()).Named(Name)
+
+	cleanUp := common.InitTracing(ctx, Name, cfg.Tracing, logger)
 	defer cleanUp()
 
+	logger, closeLogs := common.InitLogs(ctx, Name, cfg.Tracing, logger)
+	defer closeLogs()
+
 	diContainer := do.New()
 
 	do.ProvideValue[Config](diContainer, cfg)
 	do.Provide[net.Listener](diContainer, ProvideListener)
-	do.ProvideValue[*zap.Logger](diContainer, zap.Must(zap.NewProduction()).Named(Name))
+	do.ProvideValue[*zap.Logger](diContainer, logger)
+	do.ProvideValue[zap.AtomicLevel](diContainer, logCfg.Level)
 	do.ProvideValue[*multiplexer.Multiplexer](diContainer, multiplexer.New())
+	do.Provide[*redis.Router](diContainer, ProvideRedisRouter)
+	do.Provide[*federation.Router](diContainer, ProvideFederationRouter)
+	do.Provide[core.RelayRouter](diContainer, ProvideRelayRouter)
+	do.ProvideValue[*usecase.Bus](diContainer, usecase.NewBus())
 	do.Provide[*usecase.UC](diContainer, ProvideUseCaseHandler)
 	do.Provide[common.HTTPSideCarServer](diContainer, common.ProvideHTTPSideCar[Config])
 	do.Provide[*grpc.Server](diContainer, ProvideGRPCServer)
-
-	gRPCServer := do.MustInvoke[*grpc.Server](diContainer)
-	httpSideCar := do.MustInvoke[common.HTTPSideCarServer](diContainer)
+	do.Provide[*federation.Server](diContainer, ProvideFederationServer)
+	do.Provide[*admin.Server](diContainer, ProvideAdminServer)
+	do.Provide[*audit.Sink](diContainer, ProvideAuditSink)
+	do.Provide[common.StatsFunc](diContainer, ProvideStats)
+	do.Provide[common.MultiplexerStateFunc](diContainer, ProvideMultiplexerState)
+	do.Provide[*metrics.Recorder](diContainer, ProvideMetricsRecorder)
+	do.Provide[*heavyhitters.Tracker](diContainer, ProvideHeavyHitters)
+	do.Provide[common.HeavyHittersFunc](diContainer, ProvideHeavyHittersSnapshot)
 
 	g, ctx := errgroup.WithContext(ctx)
 
+	bus := do.MustInvoke[*usecase.Bus](diContainer)
+
+	// the subscribers are added before any stream is accepted so they see every event
+	recorder, err := do.Invoke[*metrics.Recorder](diContainer)
+	if err != nil {
+		return err
+	}
+
+	defer bus.Subscribe(usecase.EventFilter{Types: []usecase.EventType{usecase.EventAckForwarded}}, recorder.Handle)()
+
+	if cfg.HeavyHitters.Enabled {
+		tracker := do.MustInvoke[*heavyhitters.Tracker](diContainer)
+
+		defer bus.Subscribe(
+			usecase.EventFilter{Types: []usecase.EventType{usecase.EventRelayed, usecase.EventAckForwarded}},
+			tracker.Handle,
+		)()
+	}
+
+	if cfg.Audit.Enabled {
+		sink, err := do.Invoke[*audit.Sink](diContainer)
+		if err != nil {
+			return err
+		}
+
+		unsubscribe := bus.Subscribe(usecase.EventFilter{}, sink.Handle)
+
+		g.Go(func() error {
+			err := sink.Run(ctx)
+
+			// no event reaches the sink once unsubscribed, the file can be closed
+			unsubscribe()
+
+			return errors.Join(err, sink.Close())
+		})
+	}
+
+	gRPCServer := do.MustInvoke[*grpc.Server](diContainer)
+
 	g.Go(func() error { return gRPCServer.Run(ctx) })
-	g.Go(func() error { return httpSideCar.Run(ctx) })
+
+	// a disabled sidecar is not even built, nor its Prometheus exporter
+	if cfg.SideCar.Enabled {
+		httpSideCar := do.MustInvoke[common.HTTPSideCarServer](diContainer)
+
+		g.Go(func() error { return httpSideCar.Run(ctx) })
+	}
+
+	if cfg.Federation.Enabled {
+		federationServer := do.MustInvoke[*federation.Server](diContainer)
+
+		g.Go(func() error { return federationServer.Run(ctx) })
+
+		if cfg.Router.Backend != RedisBackend {
+			federationRouter := do.MustInvoke[*federation.Router](diContainer)
+
+			defer func() {
+				if err := federationRouter.Close(); err != nil {
+					logger.Error("Error closing the federation peers", zap.Error(err))
+				}
+			}()
+		}
+	}
+
+	if cfg.Admin.Enabled {
+		adminServer := do.MustInvoke[*admin.Server](diContainer)
+
+		g.Go(func() error { return adminServer.Run(ctx) })
+	}
+
+	if cfg.Router.Backend == RedisBackend {
+		redisRouter := do.MustInvoke[*redis.Router](diContainer)
+
+		g.Go(func() error { return redisRouter.Run(ctx) })
+	}
 
 	return g.Wait()
 }
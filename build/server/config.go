@@ -1,24 +1,129 @@
 package server
 
+import (
+	"github.com/k4l1ma/EchoSphere/build/common"
+	"time"
+)
+
 type Config struct {
-	Server  SrvCfg     `snout:"server"`
-	SideCar SideCarCfg `snout:"sidecar"`
+	Server     SrvCfg               `snout:"server"`
+	SideCar    SideCarCfg           `snout:"sidecar"`
+	Federation FederationCfg        `snout:"federation"`
+	Router     RouterCfg            `snout:"router"`
+	Admin      AdminCfg             `snout:"admin"`
+	Audit      AuditCfg             `snout:"audit"`
+	Capture    common.CaptureConfig `snout:"capture"`
+	// HeavyHitters finds the owner IDs sending or receiving the most frames.
+	HeavyHitters HeavyHittersCfg `snout:"heavy_hitters"`
+	// Tracing configures the exporter and the sampling of the traces.
+	Tracing common.TracingConfig `snout:"tracing"`
 }
 
 type SrvCfg struct {
 	Port int `snout:"port" default:"8080"`
+	// AckTimeout is how long the server waits for the ack of a relayed message before nacking its originator,
+	// 0 disables it. The default is well above the 0-10s ack delays of the README load test, so an ack only late
+	// by a slow client or a busy server does not get its message nacked first.
+	AckTimeout time.Duration `snout:"ack_timeout" default:"30s"`
+	RateLimit  RateLimitCfg  `snout:"rate_limit"`
+	Admission  AdmissionCfg  `snout:"admission"`
+}
+
+// AdmissionCfg caps concurrent Transmit streams and sheds new ones under pressure, 0 disables a limit.
+type AdmissionCfg struct {
+	MaxStreams           int64  `snout:"max_streams" default:"0"`
+	MaxStreamsPerAddress int64  `snout:"max_streams_per_address" default:"0"`
+	MaxHeapBytes         uint64 `snout:"max_heap_bytes" default:"0"`
+	MaxQueueDepth        int64  `snout:"max_queue_depth" default:"0"`
+	// MaxRelayers caps the clients registered on this server, the stream of the next one ends with ResourceExhausted.
+	MaxRelayers int64 `snout:"max_relayers" default:"0"`
+}
+
+// RateLimitCfg configures the token buckets of Transmit frames, rates are frames per second and 0 disables a bucket.
+// It is opt-in: a client acks every message relayed to it, so its frame rate follows the traffic of the others, and
+// a limit that fits one deployment drops the acks of another, leaving their originators to time out.
+type RateLimitCfg struct {
+	Enabled         bool          `snout:"enabled" default:"false"`
+	PerOwner        float64       `snout:"per_owner" default:"10"`
+	PerOwnerBurst   int           `snout:"per_owner_burst" default:"20"`
+	PerAddress      float64       `snout:"per_address" default:"0"`
+	PerAddressBurst int           `snout:"per_address_burst" default:"0"`
+	IdleTTL         time.Duration `snout:"idle_ttl" default:"5m"`
 }
+
+// SideCarCfg configures the HTTP sidecar, it is not built unless Enabled. LatencyBuckets are the comma-separated
+// boundaries in seconds of the latency histograms, common.DefaultLatencyBuckets when empty. ProfileDir is where the
+// heap profiles are written, the temporary directory when empty.
 type SideCarCfg struct {
-	Enabled bool `snout:"enabled" default:"true"`
-	Port    int  `snout:"port" default:"9090"`
+	Enabled        bool   `snout:"enabled" default:"true"`
+	Port           int    `snout:"port" default:"9090"`
+	LatencyBuckets string `snout:"latency_buckets"`
+	ProfileDir     string `snout:"profile_dir"`
+}
+
+// FederationCfg configures the peering with other servers, Peers are the federation addresses of the other nodes.
+// LeaseTTL is how long a relayer stays leased to a peer that does not release it.
+type FederationCfg struct {
+	Enabled  bool          `snout:"enabled" default:"false"`
+	Port     int           `snout:"port" default:"7070"`
+	Peers    []string      `snout:"peers"`
+	LeaseTTL time.Duration `snout:"lease_ttl" default:"30s"`
+}
+
+// AdminCfg configures the admin gRPC service, it is unauthenticated so it is disabled unless asked for.
+type AdminCfg struct {
+	Enabled bool `snout:"enabled" default:"false"`
+	Port    int  `snout:"port" default:"8081"`
+}
+
+// AuditCfg configures the append-only audit log of the lifecycle events, rotated once it reaches MaxSizeMB.
+// Redaction is none to keep the message contents, hash to keep only their hash or truncate to keep their first
+// TruncateAt bytes as well.
+type AuditCfg struct {
+	Enabled       bool          `snout:"enabled" default:"false"`
+	Path          string        `snout:"path" default:"echosphere-audit.jsonl"`
+	MaxSizeMB     int           `snout:"max_size_mb" default:"100"`
+	MaxBackups    int           `snout:"max_backups" default:"5"`
+	Redaction     string        `snout:"redaction" default:"hash" validate:"oneof=none hash truncate"`
+	TruncateAt    int           `snout:"truncate_at" default:"16"`
+	FlushInterval time.Duration `snout:"flush_interval" default:"1s"`
+}
+
+// HeavyHittersCfg configures the top-K of the owner IDs by messages sent, acks sent and relays received, counted in
+// count-min sketches of Width counters in Depth rows over windows of Window, 0 counting since the start.
+type HeavyHittersCfg struct {
+	Enabled bool          `snout:"enabled" default:"true"`
+	K       int           `snout:"k" default:"10"`
+	Width   int           `snout:"width" default:"2048"`
+	Depth   int           `snout:"depth" default:"4"`
+	Window  time.Duration `snout:"window" default:"1m"`
+}
+
+// RouterCfg selects where the relay pool lives, memory keeps it in the process (and its federation peers),
+// redis shares it between every server using the same Redis and prefix.
+type RouterCfg struct {
+	Backend string   `snout:"backend" default:"memory" validate:"oneof=memory redis"`
+	NodeID  string   `snout:"node_id"`
+	Redis   RedisCfg `snout:"redis"`
+}
+
+type RedisCfg struct {
+	Addr     string `snout:"addr" default:"localhost:6379"`
+	Password string `snout:"password"`
+	DB       int    `snout:"db" default:"0"`
+	Prefix   string `snout:"prefix" default:"echosphere"`
 }
 
 func (c Config) GetSideCar() struct {
-	Enabled bool
-	Port    int
+	Enabled        bool
+	Port           int
+	LatencyBuckets string
+	ProfileDir     string
 } {
 	return struct {
-		Enabled bool
-		Port    int
+		Enabled        bool
+		Port           int
+		LatencyBuckets string
+		ProfileDir     string
 	}(c.SideCar)
 }
@@ -1,13 +1,28 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"github.com/google/uuid"
+	"github.com/k4l1ma/EchoSphere/build/common"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/core"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/admin"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/audit"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/federation"
 	grpc "github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/gRPC"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/heavyhitters"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/metrics"
 	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/multiplexer"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/redis"
 	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
+	goredis "github.com/redis/go-redis/v9"
 	"github.com/samber/do/v2"
+	"github.com/samber/lo"
+	"go.opentelemetry.io/otel"
 	"go.uber.org/zap"
 	"net"
+	"time"
 )
 
 func ProvideListener(i do.Injector) (net.Listener, error) {
@@ -16,17 +31,235 @@ func ProvideListener(i do.Injector) (net.Listener, error) {
 	return net.Listen("tcp", fmt.Sprintf(":%d", cfg.Server.Port))
 }
 
+func ProvideRelayRouter(i do.Injector) (core.RelayRouter, error) { //nolint:ireturn
+	cfg := do.MustInvoke[Config](i)
+
+	if cfg.Router.Backend == RedisBackend {
+		return do.MustInvoke[*redis.Router](i), nil
+	}
+
+	if cfg.Federation.Enabled {
+		return do.MustInvoke[*federation.Router](i), nil
+	}
+
+	return do.MustInvoke[*multiplexer.Multiplexer](i), nil
+}
+
+func ProvideFederationRouter(i do.Injector) (*federation.Router, error) {
+	cfg := do.MustInvoke[Config](i)
+
+	peers, err := federation.Dial(cfg.Federation.Peers)
+	if err != nil {
+		return nil, err
+	}
+
+	return federation.NewRouter(do.MustInvoke[*multiplexer.Multiplexer](i), peers), nil
+}
+
+func ProvideRedisRouter(i do.Injector) (*redis.Router, error) {
+	cfg := do.MustInvoke[Config](i)
+
+	return redis.NewRouter(redis.Config{
+		Client: goredis.NewClient(&goredis.Options{
+			Addr:     cfg.Router.Redis.Addr,
+			Password: cfg.Router.Redis.Password,
+			DB:       cfg.Router.Redis.DB,
+		}),
+		NodeID: lo.Ternary(cfg.Router.NodeID != "", cfg.Router.NodeID, uuid.NewString()),
+		Prefix: cfg.Router.Redis.Prefix,
+		Logger: do.MustInvoke[*zap.Logger](i),
+	}), nil
+}
+
+func ProvideAuditSink(i do.Injector) (*audit.Sink, error) {
+	cfg := do.MustInvoke[Config](i)
+
+	return audit.NewSink(audit.Config{
+		Path:          cfg.Audit.Path,
+		MaxSize:       int64(cfg.Audit.MaxSizeMB) << 20,
+		MaxBackups:    cfg.Audit.MaxBackups,
+		Redaction:     audit.Redaction(cfg.Audit.Redaction),
+		TruncateAt:    cfg.Audit.TruncateAt,
+		FlushInterval: cfg.Audit.FlushInterval,
+		Logger:        do.MustInvoke[*zap.Logger](i),
+	})
+}
+
+func ProvideMetricsRecorder(do.Injector) (*metrics.Recorder, error) {
+	return metrics.NewRecorder(otel.GetMeterProvider().Meter("echosphere.io/usecase"))
+}
+
 func ProvideUseCaseHandler(i do.Injector) (*usecase.UC, error) {
-	multiplexer := do.MustInvoke[*multiplexer.Multiplexer](i)
+	cfg := do.MustInvoke[Config](i)
 
-	return usecase.New(multiplexer), nil
+	return usecase.New(usecase.Config{
+		Router:         do.MustInvoke[core.RelayRouter](i),
+		AckTimeout:     cfg.Server.AckTimeout,
+		Bus:            do.MustInvoke[*usecase.Bus](i),
+		MaxConnections: cfg.Server.Admission.MaxRelayers,
+	}), nil
 }
 
 func ProvideGRPCServer(i do.Injector) (*grpc.Server, error) {
+	cfg := do.MustInvoke[Config](i)
+
+	var rateLimit *grpc.RateLimitConfig
+
+	if limits := cfg.Server.RateLimit; limits.Enabled {
+		rateLimit = &grpc.RateLimitConfig{
+			PerOwner:        limits.PerOwner,
+			PerOwnerBurst:   limits.PerOwnerBurst,
+			PerAddress:      limits.PerAddress,
+			PerAddressBurst: limits.PerAddressBurst,
+			IdleTTL:         limits.IdleTTL,
+		}
+	}
+
+	capture, err := cfg.Capture.Policy()
+	if err != nil {
+		return nil, err
+	}
+
+	useCases := do.MustInvoke[*usecase.UC](i)
+	admission := cfg.Server.Admission
+
 	return grpc.NewServer(grpc.Config{
-		Listener: do.MustInvoke[net.Listener](i),
-		Router:   do.MustInvoke[*multiplexer.Multiplexer](i),
+		Listener:  do.MustInvoke[net.Listener](i),
+		Router:    do.MustInvoke[core.RelayRouter](i),
+		Logger:    do.MustInvoke[*zap.Logger](i),
+		UseCases:  useCases,
+		RateLimit: rateLimit,
+		Admission: &grpc.AdmissionConfig{
+			MaxStreams:           admission.MaxStreams,
+			MaxStreamsPerAddress: admission.MaxStreamsPerAddress,
+			MaxHeapBytes:         admission.MaxHeapBytes,
+			MaxQueueDepth:        admission.MaxQueueDepth,
+			QueueDepth:           useCases.QueueDepth,
+		},
+		Capture:     capture,
+		LogSampling: cfg.Capture.Sampling(),
+	}), nil
+}
+
+func ProvideFederationServer(i do.Injector) (*federation.Server, error) {
+	cfg := do.MustInvoke[Config](i)
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Federation.Port))
+	if err != nil {
+		return nil, err
+	}
+
+	return federation.NewServer(federation.Config{
+		Listener: listener,
+		Local:    do.MustInvoke[*multiplexer.Multiplexer](i),
 		Logger:   do.MustInvoke[*zap.Logger](i),
+		LeaseTTL: cfg.Federation.LeaseTTL,
+	}), nil
+}
+
+func ProvideAdminServer(i do.Injector) (*admin.Server, error) {
+	cfg := do.MustInvoke[Config](i)
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Admin.Port))
+	if err != nil {
+		return nil, err
+	}
+
+	// the pending messages are as sensitive as the audit log
+	redactor, err := audit.NewRedactor(audit.Redaction(cfg.Audit.Redaction), cfg.Audit.TruncateAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return admin.NewServer(admin.Config{
+		Listener: listener,
+		Router:   do.MustInvoke[core.RelayRouter](i),
 		UseCases: do.MustInvoke[*usecase.UC](i),
+		Drainer:  do.MustInvoke[*grpc.Server](i),
+		Redactor: redactor,
+		Logger:   do.MustInvoke[*zap.Logger](i),
+	}), nil
+}
+
+// ProvideMultiplexerState dumps the relayers of this server, those of its peers are not listed. The relayers in
+// Redis are not dumped, the endpoint answers 404 with the redis backend.
+func ProvideMultiplexerState(i do.Injector) (common.MultiplexerStateFunc, error) {
+	if do.MustInvoke[Config](i).Router.Backend == RedisBackend {
+		return nil, errors.New("the relayers are in Redis")
+	}
+
+	mux := do.MustInvoke[*multiplexer.Multiplexer](i)
+
+	return func() any { return mux.State() }, nil
+}
+
+func ProvideHeavyHitters(i do.Injector) (*heavyhitters.Tracker, error) {
+	cfg := do.MustInvoke[Config](i)
+
+	return heavyhitters.NewTracker(heavyhitters.Config{
+		K:      cfg.HeavyHitters.K,
+		Width:  cfg.HeavyHitters.Width,
+		Depth:  cfg.HeavyHitters.Depth,
+		Window: cfg.HeavyHitters.Window,
 	}), nil
 }
+
+// nodeHeavyHitters is the snapshot served on /debug/heavyhitters, its scope telling it was counted by this node only.
+type nodeHeavyHitters struct {
+	Scope string `json:"scope"`
+	heavyhitters.Snapshot
+}
+
+// ProvideHeavyHittersSnapshot serves the top-K of the tracker, those of the peers are not merged in so the
+// snapshot is of scope node.
+func ProvideHeavyHittersSnapshot(i do.Injector) (common.HeavyHittersFunc, error) {
+	if !do.MustInvoke[Config](i).HeavyHitters.Enabled {
+		return nil, errors.New("heavy hitters are disabled")
+	}
+
+	tracker, err := do.Invoke[*heavyhitters.Tracker](i)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() any { return nodeHeavyHitters{Scope: "node", Snapshot: tracker.Snapshot()} }, nil
+}
+
+// statsTimeout bounds the request of the pool stats to the relay router, e.g. to Redis.
+const statsTimeout = time.Second
+
+func ProvideStats(i do.Injector) (common.StatsFunc, error) {
+	router := do.MustInvoke[core.RelayRouter](i)
+	useCases := do.MustInvoke[*usecase.UC](i)
+	server := do.MustInvoke[*grpc.Server](i)
+
+	return func() any {
+		useCaseStats := useCases.Stats()
+		streamStats := server.Stats()
+
+		stats := Stats{
+			Connections:      useCaseStats.Connections,
+			PendingRelays:    useCaseStats.PendingRelays,
+			PendingAcks:      useCaseStats.PendingAcks,
+			QueueDepth:       useCaseStats.QueueDepth,
+			Streams:          streamStats.Streams,
+			StreamGoroutines: streamStats.Goroutines,
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), statsTimeout)
+		defer cancel()
+
+		pool, err := router.PoolStats(ctx)
+		if err != nil {
+			stats.Error = err.Error()
+		}
+
+		stats.ActiveRelayers = pool.Registered
+
+		if stats.Streams > 0 {
+			stats.GoroutinesPerStream = float64(stats.StreamGoroutines) / float64(stats.Streams)
+		}
+
+		return stats
+	}, nil
+}
@@ -0,0 +1,23 @@
+package server
+
+// Stats is the work held by a server, served by the sidecar on /debug/stats.
+// Once every client has closed its connection the server is back to the zero state, see Zero.
+type Stats struct {
+	// ActiveRelayers counts the relayers registered, leased or not, those of the other servers too with Redis.
+	ActiveRelayers int64 `json:"active_relayers"`
+	Connections    int   `json:"connections"`
+	PendingRelays  int64 `json:"pending_relays"`
+	PendingAcks    int64 `json:"pending_acks"`
+	QueueDepth     int64 `json:"queue_depth"`
+	Streams        int64 `json:"streams"`
+	// StreamGoroutines counts the goroutines serving the Transmit streams, not those of the whole process.
+	StreamGoroutines    int64   `json:"stream_goroutines"`
+	GoroutinesPerStream float64 `json:"goroutines_per_stream"`
+	// Error tells why the relayers could not be counted.
+	Error string `json:"error,omitempty"`
+}
+
+// Zero reports whether nothing is left of the clients: no relayer, connection, stream, goroutine or pending work.
+func (s Stats) Zero() bool {
+	return s == Stats{}
+}
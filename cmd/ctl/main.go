@@ -0,0 +1,103 @@
+// Command echosphere-ctl inspects and controls an EchoSphere server through its admin service.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	ctl "github.com/k4l1ma/EchoSphere/internal/EchoSphereCtl"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+)
+
+const usage = `Usage: echosphere-ctl [flags] <command> [args]
+
+Commands:
+  list            list the connected clients and their stream age
+  pending         list the relayed messages waiting for their ack
+  kick <owner>    disconnect a client
+  drain           stop the server from accepting new streams
+  stats           show the server stats
+  tail            print the lifecycle events as they happen, until interrupted,
+                  filtered by -owner and -type
+
+Flags:
+`
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "echosphere-ctl:", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	addr := flag.String("addr", "localhost:8081", "address of the server admin service")
+	output := flag.String("o", string(ctl.FormatTable), "output format, table or json")
+	timeout := flag.Duration("timeout", 10*time.Second, "timeout of each command, tail is not bounded")
+	owners := flag.String("owner", "", "comma-separated owner ids whose events tail prints")
+	types := flag.String("type", "", "comma-separated event types tail prints, e.g. relayed,dropped")
+
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+
+		return errors.New("missing command")
+	}
+
+	c, err := ctl.New(ctl.Config{Target: *addr, Format: ctl.Format(*output), Output: os.Stdout})
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	command, args := flag.Arg(0), flag.Args()[1:]
+
+	if command == "tail" {
+		return c.Tail(ctx, ctl.TailFilter{Owners: splitList(*owners), Types: splitList(*types)})
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	switch command {
+	case "list":
+		return c.List(ctx)
+	case "pending":
+		return c.Pending(ctx)
+	case "kick":
+		if len(args) != 1 {
+			return errors.New("kick takes the owner id of the client")
+		}
+
+		return c.Kick(ctx, args[0])
+	case "drain":
+		return c.Drain(ctx)
+	case "stats":
+		return c.Stats(ctx)
+	default:
+		flag.Usage()
+
+		return fmt.Errorf("unknown command %q", command)
+	}
+}
+
+// splitList splits a comma-separated flag, an empty flag is an empty list.
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	return strings.Split(value, ",")
+}
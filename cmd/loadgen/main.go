@@ -0,0 +1,151 @@
+// Command loadgen runs many EchoSphere clients against a server and reports the throughput, the ack latency and
+// the failures of the run. The run is described by the flags, or by a scenario file whose settings the flags
+// given explicitly override.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	loadgen "github.com/k4l1ma/EchoSphere/internal/EchoSphereLoadGen"
+	"go.uber.org/zap"
+	"os"
+	"os/signal"
+	"time"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "loadgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	addr := flag.String("addr", "localhost:8080", "address of the server")
+	clients := flag.Int("clients", 1000, "number of clients")
+	ramp := flag.String("ramp", string(loadgen.RampRandom), "how the clients start over -ramp-over, instant, linear or random")
+	rampOver := flag.Duration("ramp-over", 60*time.Second, "period the clients start over")
+	ackDelay := flag.String("ack-delay", "uniform:0s-2s", "delay before acking, well below the ack timeout of the server, constant:<d>, uniform:<min>-<max> or exponential:<mean>")
+	duration := flag.Duration("duration", 5*time.Minute, "bound of the run, 0 does not bound it")
+	deadline := flag.Duration("deadline", 30*time.Second, "how long a client waits for its ack before resending")
+	reconnects := flag.Int("reconnects", 3, "how many times a client reconnects after its stream fails")
+	reportPath := flag.String("report", "loadgen-report.json", "file the JSON report is written to, empty to skip it")
+	logPath := flag.String("log", "", "file the client logs are written to, e.g. for echosphere-verify, empty to skip them")
+	scenarioPath := flag.String("scenario", "", "scenario file describing the phases of the run, see the scenarios directory")
+
+	flag.Parse()
+
+	var (
+		cfg loadgen.Config
+		err error
+	)
+
+	if *scenarioPath != "" {
+		cfg, err = scenarioConfig(*scenarioPath, *addr)
+	} else {
+		cfg, err = flagsConfig(*clients, *ramp, *rampOver, *ackDelay)
+		cfg.Target, cfg.Duration, cfg.Deadline, cfg.Reconnects = *addr, *duration, *deadline, *reconnects
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// the flags given explicitly win over the scenario
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "addr":
+			cfg.Target = *addr
+		case "duration":
+			cfg.Duration = *duration
+		case "deadline":
+			cfg.Deadline = *deadline
+		case "reconnects":
+			cfg.Reconnects = *reconnects
+		}
+	})
+
+	logger := zap.NewNop()
+
+	if *logPath != "" {
+		cfg := zap.NewProductionConfig()
+		cfg.OutputPaths = []string{*logPath}
+		// the verifier needs every frame
+		cfg.Sampling = nil
+
+		if logger, err = cfg.Build(); err != nil {
+			return err
+		}
+
+		defer logger.Sync() //nolint:errcheck
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	cfg.Logger = logger
+
+	report, err := loadgen.Run(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	if err := report.WriteText(os.Stdout); err != nil {
+		return err
+	}
+
+	if *reportPath != "" {
+		file, err := os.Create(*reportPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		if err := report.WriteJSON(file); err != nil {
+			return err
+		}
+	}
+
+	// the clients of churn phases are meant to leave before their ack
+	if report.Failed > report.Failures[loadgen.FailureDisconnected] {
+		return errors.New("some clients were not acked")
+	}
+
+	return nil
+}
+
+// scenarioConfig reads the scenario at path, a scenario without a target runs against addr.
+func scenarioConfig(path, addr string) (loadgen.Config, error) {
+	scenario, err := loadgen.LoadScenario(path)
+	if err != nil {
+		return loadgen.Config{}, err
+	}
+
+	if scenario.Target == "" {
+		scenario.Target = addr
+	}
+
+	return scenario.Config()
+}
+
+func flagsConfig(clients int, ramp string, rampOver time.Duration, ackDelay string) (loadgen.Config, error) {
+	rampProfile, err := loadgen.ParseRamp(ramp)
+	if err != nil {
+		return loadgen.Config{}, err
+	}
+
+	delays, err := loadgen.ParseDistribution(ackDelay)
+	if err != nil {
+		return loadgen.Config{}, err
+	}
+
+	return loadgen.Config{Phases: []loadgen.Phase{{
+		Name:     "default",
+		Clients:  clients,
+		Ramp:     rampProfile,
+		RampOver: rampOver,
+		AckDelay: delays,
+	}}}, nil
+}
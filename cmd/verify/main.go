@@ -0,0 +1,144 @@
+// Command echosphere-verify checks the protocol invariants over the audit logs of the servers and the logs of the
+// clients recorded by a run, and exits with 1 if any is broken.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	verifier "github.com/k4l1ma/EchoSphere/internal/EchoSphereVerifier"
+	"io"
+	"os"
+	"text/tabwriter"
+)
+
+const usage = `Usage: echosphere-verify [flags]
+
+Reads the audit logs of the servers (-server) and the logs of the clients (-client), both repeatable,
+and reports the messages never acked, the acks without messages, the duplicate deliveries and the
+connections left registered. A client log of - is read from the standard input.
+
+Flags:
+`
+
+// errBroken tells the invariants are broken, the report says which.
+var errBroken = errors.New("protocol invariants broken")
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "echosphere-verify:", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	var servers, clients []string
+
+	flag.Func("server", "audit log of a server, rotated files are read as well", func(path string) error {
+		servers = append(servers, path)
+
+		return nil
+	})
+	flag.Func("client", "log of one or more clients", func(path string) error {
+		clients = append(clients, path)
+
+		return nil
+	})
+	output := flag.String("o", "table", "output format, table or json")
+
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if len(servers) == 0 && len(clients) == 0 {
+		flag.Usage()
+
+		return errors.New("nothing to verify")
+	}
+
+	v := verifier.New()
+
+	for _, path := range servers {
+		if err := v.ReadServerLog(path); err != nil {
+			return err
+		}
+	}
+
+	for _, path := range clients {
+		if err := readClientLog(v, path); err != nil {
+			return err
+		}
+	}
+
+	report := v.Report()
+
+	var err error
+
+	switch *output {
+	case "table":
+		err = printTable(os.Stdout, report)
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(report)
+	default:
+		return fmt.Errorf("unknown output format %q", *output)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !report.OK() {
+		return errBroken
+	}
+
+	return nil
+}
+
+func readClientLog(v *verifier.Verifier, path string) error {
+	if path == "-" {
+		return v.ReadClientLog(os.Stdin)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return v.ReadClientLog(file)
+}
+
+func printTable(out io.Writer, report verifier.Report) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	sections := []struct {
+		title    string
+		findings []verifier.Finding
+	}{
+		{"unmatched messages", report.UnmatchedMessages},
+		{"acks without messages", report.AcksWithoutMessages},
+		{"duplicate deliveries", report.DuplicateDeliveries},
+	}
+
+	for _, section := range sections {
+		fmt.Fprintf(w, "%s: %d\n", section.title, len(section.findings))
+
+		for _, f := range section.findings {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%d\n", f.Source, f.Originator, f.ContentHash, f.Recipient, f.Count)
+		}
+	}
+
+	fmt.Fprintf(w, "left registered: %d\n", len(report.LeftRegistered))
+
+	for _, ownerID := range report.LeftRegistered {
+		fmt.Fprintf(w, "  %s\n", ownerID)
+	}
+
+	return w.Flush()
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 	"golang.org/x/exp/rand"
 	"google.golang.org/grpc/credentials/insecure"
+	"sync"
 	"time"
 
 	v1 "github.com/k4l1ma/EchoSphere/api/v1"
@@ -19,11 +20,38 @@ var ErrDone = fmt.Errorf("done")
 
 // EchoSphereClient is a client for interacting with the EchoSphereTransmissionService gRPC service.
 type EchoSphereClient struct {
-	cli      v1.EchoSphereTransmissionServiceClient
-	logger   *zap.Logger
-	clientID string
-	message  *v1.EchoSphereTransmissionServiceTransmitRequest
-	deadline time.Duration
+	conn       *grpc.ClientConn
+	cli        v1.EchoSphereTransmissionServiceClient
+	logger     *zap.Logger
+	clientID   string
+	message    *v1.EchoSphereTransmissionServiceTransmitRequest
+	deadline   time.Duration
+	accept     func(*v1.Message) bool
+	ackDelay   func() time.Duration
+	reconnects int
+
+	// sendMu serializes the sends of the delayed acks with the others
+	sendMu sync.Mutex
+	// acking counts the delayed acks not sent yet
+	acking sync.WaitGroup
+
+	statsMu     sync.Mutex
+	stats       Stats
+	instruments *instruments
+}
+
+// Stats tells how the message of the client fared.
+type Stats struct {
+	// SentAt is when the message was first sent.
+	SentAt time.Time
+	// AckedAt is when its ack arrived, zero if it never did.
+	AckedAt time.Time
+	// Resends counts the times the message was sent again, after its deadline or a nack.
+	Resends int
+	// Nacks counts the nacks received for the message.
+	Nacks int
+	// Reconnects counts the streams opened again after one failed before the ack.
+	Reconnects int
 }
 
 // Config represents the configuration for creating a new EchoSphereClient.
@@ -32,20 +60,39 @@ type Config struct {
 	Target   string
 	DialOpts []grpc.DialOption
 	Deadline time.Duration
+	// Accept decides whether a relayed message is acked or refused with a nack, nil accepts every message.
+	Accept func(*v1.Message) bool
+	// AckDelay returns how long to wait before acking a relayed message, nil acks right away.
+	AckDelay func() time.Duration
+	// Reconnects is how many times a stream that fails before the ack is opened again to resend the message.
+	Reconnects int
+	// Capture is how much of the frames the frame logs and spans keep, LogSampling thins out the frame logs.
+	Capture     v1.CapturePolicy
+	LogSampling v1.LogSampling
 }
 
 // NewEchoSphereClient creates a new EchoSphereClient instance.
 func NewEchoSphereClient(cfg Config) (*EchoSphereClient, error) {
+	cliID := generateClientID()
+
+	// every line carries the client id so the outputs of many clients can be told apart
+	cfg.Logger = cfg.Logger.With(zap.String("client_id", cliID))
+
 	cfg.DialOpts = append(
 		cfg.DialOpts,
 		grpc.WithChainStreamInterceptor(
-			middleware.StreamLogger(cfg.Logger),
+			middleware.StreamLogger(cfg.Logger, cfg.Capture, cfg.LogSampling),
 			middleware.StreamMetric(),
-			middleware.StreamTracing(),
+			middleware.StreamTracing(cfg.Capture),
 		),
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 	)
 
+	instruments, err := defaultInstruments()
+	if err != nil {
+		return nil, err
+	}
+
 	conn, err := grpc.NewClient(cfg.Target, cfg.DialOpts...)
 	if err != nil {
 		return nil, err
@@ -53,15 +100,19 @@ func NewEchoSphereClient(cfg Config) (*EchoSphereClient, error) {
 
 	client := v1.NewEchoSphereTransmissionServiceClient(conn)
 
-	cliID := generateClientID()
 	message := newMessage(cliID)
 
 	return &EchoSphereClient{
-		cli:      client,
-		logger:   cfg.Logger,
-		clientID: cliID,
-		deadline: cfg.Deadline,
-		message:  message,
+		conn:        conn,
+		cli:         client,
+		logger:      cfg.Logger,
+		clientID:    cliID,
+		deadline:    cfg.Deadline,
+		message:     message,
+		accept:      cfg.Accept,
+		ackDelay:    cfg.AckDelay,
+		reconnects:  cfg.Reconnects,
+		instruments: instruments,
 	}, nil
 }
 
@@ -77,8 +128,31 @@ func newMessage(clientID string) *v1.EchoSphereTransmissionServiceTransmitReques
 	}
 }
 
+// Close closes the connection of the client to the server.
+func (esc *EchoSphereClient) Close() error {
+	return esc.conn.Close()
+}
+
+// Stats returns how the message of the client fared so far.
+func (esc *EchoSphereClient) Stats() Stats {
+	esc.statsMu.Lock()
+	defer esc.statsMu.Unlock()
+
+	return esc.stats
+}
+
+func (esc *EchoSphereClient) recordStats(record func(stats *Stats)) {
+	esc.statsMu.Lock()
+	defer esc.statsMu.Unlock()
+
+	record(&esc.stats)
+}
+
 // sendMessage sends a message via the gRPC stream.
 func (esc *EchoSphereClient) sendMessage(stream v1.EchoSphereTransmissionService_TransmitClient, msg *v1.EchoSphereTransmissionServiceTransmitRequest) error {
+	esc.sendMu.Lock()
+	defer esc.sendMu.Unlock()
+
 	err := stream.SendMsg(msg)
 	if err != nil {
 		return err
@@ -6,6 +6,13 @@ import (
 	esc "github.com/k4l1ma/EchoSphere/internal/EchoSphereClient/io/gRPC"
 	"github.com/k4l1ma/EchoSphere/internal/EchoSphereClient/io/gRPC/internal/mocks"
 	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/mock/gomock"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -22,42 +29,77 @@ import (
 type grpcIntegrationSuite struct {
 	suite.Suite
 
-	SUT     *esc.EchoSphereClient
-	srvCtrl *mocks.MockEchoSphereTransmissionServiceServer
+	SUT      *esc.EchoSphereClient
+	srvCtrl  *mocks.MockEchoSphereTransmissionServiceServer
+	listener *bufconn.Listener
+	metrics  *sdkmetric.ManualReader
+	spans    *tracetest.SpanRecorder
+}
+
+// ended returns the ended span of the client named name.
+func (g *grpcIntegrationSuite) ended(name string) sdktrace.ReadOnlySpan { //nolint:ireturn
+	for _, span := range g.spans.Ended() {
+		if span.Name() == name {
+			return span
+		}
+	}
+
+	return nil
+}
+
+// collect returns the data points of the client metrics by their name.
+func (g *grpcIntegrationSuite) collect() map[string]metricdata.Aggregation {
+	var data metricdata.ResourceMetrics
+	g.Require().NoError(g.metrics.Collect(context.Background(), &data))
+
+	aggregations := make(map[string]metricdata.Aggregation)
+
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			aggregations[m.Name] = m.Data
+		}
+	}
+
+	return aggregations
 }
 
 func (g *grpcIntegrationSuite) SetupSuite() {
 	ctrl := gomock.NewController(g.T())
 	g.srvCtrl = mocks.NewMockEchoSphereTransmissionServiceServer(ctrl)
-	listener := bufconn.Listen(1024 * 1024)
+	g.listener = bufconn.Listen(1024 * 1024)
 
 	resolver.SetDefaultScheme("passthrough")
 
+	g.metrics = sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(g.metrics)))
+
+	g.spans = tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(g.spans)))
+
 	server := grpc.NewServer()
 	v1.RegisterEchoSphereTransmissionServiceServer(server, g.srvCtrl)
 
-	go func() { g.NoError(server.Serve(listener)) }()
+	go func() { g.NoError(server.Serve(g.listener)) }()
 	g.T().Cleanup(func() { server.Stop() })
 
-	// Configure the client
-	clientConfig := esc.Config{
-		Logger: zap.NewNop(),
-		Target: "mock://server.echosphere.io",
-		DialOpts: []grpc.DialOption{
-			grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
-				return listener.Dial()
-			}),
-			grpc.WithTransportCredentials(insecure.NewCredentials()),
-		},
-		Deadline: 1 * time.Second,
+	// Set the System Under Test (SUT)
+	g.SUT = g.newClient(esc.Config{Deadline: 1 * time.Second})
+}
+
+// newClient creates a client of the mocked server.
+func (g *grpcIntegrationSuite) newClient(cfg esc.Config) *esc.EchoSphereClient {
+	cfg.Logger, cfg.Target = zap.NewNop(), "mock://server.echosphere.io"
+	cfg.DialOpts = []grpc.DialOption{
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return g.listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
 	}
 
-	// Create a new EchoSphere client
-	client, err := esc.NewEchoSphereClient(clientConfig)
+	client, err := esc.NewEchoSphereClient(cfg)
 	g.Require().NoError(err)
 
-	// Set the System Under Test (SUT)
-	g.SUT = client
+	return client
 }
 
 func (g *grpcIntegrationSuite) TestRun() {
@@ -93,13 +135,24 @@ func (g *grpcIntegrationSuite) TestRun() {
 
 	messageToAck := recv.GetMessage()
 
-	// send a different message
+	// the message carries the trace of its round trip
+	message := trace.SpanContextFromContext(v1.ExtractTraceContext(context.Background(), messageToAck.GetTraceContext()))
+	g.Require().True(message.IsValid())
+
+	// send a different message, relayed by a span of another trace
+	relay := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+	})
+
 	err = x.Send(
 		&v1.EchoSphereTransmissionServiceTransmitResponse{
 			OutgoingData: &v1.EchoSphereTransmissionServiceTransmitResponse_Message{
 				Message: &v1.Message{
-					From:    "OtherClientID",
-					Content: "Different Message",
+					From:         "OtherClientID",
+					Content:      "Different Message",
+					TraceContext: v1.InjectTraceContext(trace.ContextWithSpanContext(context.Background(), relay)),
 				},
 			},
 		},
@@ -113,6 +166,15 @@ func (g *grpcIntegrationSuite) TestRun() {
 	g.Require().Equal("OtherClientID", recv.GetAck().GetTo())
 	g.Require().Equal("Different Message", recv.GetAck().GetContent())
 
+	// the ack continues the trace of the message it acknowledges
+	ack := trace.SpanContextFromContext(v1.ExtractTraceContext(context.Background(), recv.GetAck().GetTraceContext()))
+	g.Equal(relay.TraceID(), ack.TraceID())
+
+	ackSpan := g.ended("Ack message")
+	g.Require().NotNil(ackSpan)
+	g.Equal(relay.SpanID(), ackSpan.Parent().SpanID())
+	g.Equal(ack.SpanID(), ackSpan.SpanContext().SpanID())
+
 	// finally we ack client message
 	err = x.Send(
 		&v1.EchoSphereTransmissionServiceTransmitResponse{
@@ -130,6 +192,143 @@ func (g *grpcIntegrationSuite) TestRun() {
 	// Expect an EOF
 	_, err = x.Recv()
 	g.Require().Error(err)
+
+	metrics := g.collect()
+
+	latency := metrics["ack_latency_seconds"].(metricdata.Histogram[float64]).DataPoints[0]
+	g.Equal(uint64(1), latency.Count)
+	g.GreaterOrEqual(latency.Sum, time.Second.Seconds())
+
+	resends := metrics["message_resends_total"].(metricdata.Sum[int64]).DataPoints
+	g.Require().Len(resends, 1)
+	g.Equal(int64(1), resends[0].Value)
+	g.Equal(attribute.NewSet(attribute.String("reason", "deadline")), resends[0].Attributes)
+
+	// the round trip ends with the run
+	g.Eventually(func() bool { return g.ended("Transmit message") != nil }, time.Second, 10*time.Millisecond)
+
+	roundTrip := g.ended("Transmit message")
+	g.Equal(message.SpanID(), roundTrip.SpanContext().SpanID())
+	g.Len(roundTrip.Events(), 2, "one resend and the ack")
+}
+
+func (g *grpcIntegrationSuite) TestRun_ResendsOnNack() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var streamChan = make(chan v1.EchoSphereTransmissionService_TransmitServer, 1)
+
+	g.srvCtrl.
+		EXPECT().
+		Transmit(gomock.Any()).
+		Times(1).
+		DoAndReturn(func(stream v1.EchoSphereTransmissionService_TransmitServer) error {
+			streamChan <- stream
+
+			<-ctx.Done()
+
+			return nil
+		})
+
+	go g.SUT.Run(ctx) //nolint:errcheck
+
+	x := <-streamChan
+	recv, err := x.Recv()
+	g.Require().NoError(err)
+
+	message := recv.GetMessage()
+
+	err = x.Send(
+		&v1.EchoSphereTransmissionServiceTransmitResponse{
+			OutgoingData: &v1.EchoSphereTransmissionServiceTransmitResponse_Nack{
+				Nack: &v1.Nack{
+					From:    "OtherClientID",
+					To:      message.GetFrom(),
+					Content: message.GetContent(),
+					Reason:  v1.NackReason_NACK_REASON_TIMEOUT,
+				},
+			},
+		},
+	)
+	g.Require().NoError(err)
+
+	// the resend comes right away instead of after the 1s deadline
+	sent := time.Now()
+
+	recv, err = x.Recv()
+	g.Require().NoError(err)
+	g.Require().Equal(message.GetContent(), recv.GetMessage().GetContent())
+	g.Require().Less(time.Since(sent), 500*time.Millisecond)
+
+	nackResends := 0
+
+	for _, point := range g.collect()["message_resends_total"].(metricdata.Sum[int64]).DataPoints {
+		if reason, _ := point.Attributes.Value("reason"); reason.AsString() == "nack" {
+			nackResends += int(point.Value)
+		}
+	}
+
+	g.Equal(1, nackResends)
+}
+
+func (g *grpcIntegrationSuite) TestRun_DrainsAfterAck() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sut := g.newClient(esc.Config{Deadline: time.Minute, AckDelay: func() time.Duration { return 500 * time.Millisecond }})
+
+	var streamChan = make(chan v1.EchoSphereTransmissionService_TransmitServer, 1)
+
+	g.srvCtrl.
+		EXPECT().
+		Transmit(gomock.Any()).
+		Times(1).
+		DoAndReturn(func(stream v1.EchoSphereTransmissionService_TransmitServer) error {
+			streamChan <- stream
+
+			<-ctx.Done()
+
+			return nil
+		})
+
+	go sut.Run(ctx) //nolint:errcheck
+
+	x := <-streamChan
+	recv, err := x.Recv()
+	g.Require().NoError(err)
+
+	message := recv.GetMessage()
+
+	relay := func(from string) {
+		g.Require().NoError(x.Send(&v1.EchoSphereTransmissionServiceTransmitResponse{
+			OutgoingData: &v1.EchoSphereTransmissionServiceTransmitResponse_Message{
+				Message: &v1.Message{From: from, Content: "Different Message"},
+			},
+		}))
+	}
+
+	// the ack of the first message is delayed, the message of the client is acked meanwhile
+	relay("FirstClientID")
+	g.Require().NoError(x.Send(&v1.EchoSphereTransmissionServiceTransmitResponse{
+		OutgoingData: &v1.EchoSphereTransmissionServiceTransmitResponse_Ack{
+			Ack: &v1.Ack{From: "FirstClientID", To: message.GetFrom(), Content: message.GetContent()},
+		},
+	}))
+
+	// the frames received while the delayed ack is pending are still processed, and acked right away
+	relay("SecondClientID")
+
+	recv, err = x.Recv()
+	g.Require().NoError(err)
+	g.Equal("SecondClientID", recv.GetAck().GetTo())
+
+	recv, err = x.Recv()
+	g.Require().NoError(err)
+	g.Equal("FirstClientID", recv.GetAck().GetTo())
+
+	// the stream is closed once the delayed ack is sent
+	_, err = x.Recv()
+	g.Require().Error(err)
 }
 
 func TestGRPCLayer(t *testing.T) {
@@ -4,13 +4,49 @@ import (
 	"context"
 	"errors"
 	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 	"time"
 )
 
+// reconnectBackoff is how long the client waits before opening a stream again.
+const reconnectBackoff = 100 * time.Millisecond
+
 // Run starts the EchoSphereClient and initiates the communication with the gRPC service.
 // It sends a message, starts receiving and processing responses, and handles retries.
-func (esc *EchoSphereClient) Run(ctx context.Context) error {
+// A stream that fails before the ack is opened again, up to the configured reconnects, to resend the message.
+// The round trip of the message is traced, the ack of the recipient continues the trace of the message.
+func (esc *EchoSphereClient) Run(ctx context.Context) (err error) {
+	ctx, span := esc.startTrace(ctx)
+
+	// the ack ends the round trip, without it the run does
+	defer func() {
+		if esc.Stats().AckedAt.IsZero() {
+			_ = endSpan(span, err) //nolint:errcheck
+		}
+	}()
+
+	for reconnects := 0; ; reconnects++ {
+		err = esc.run(ctx)
+		if !esc.Stats().AckedAt.IsZero() || ctx.Err() != nil || reconnects >= esc.reconnects {
+			return err
+		}
+
+		esc.logger.Warn("Stream failed before the ack, reconnecting", zap.Error(err), v1.TraceField(ctx))
+		esc.recordStats(func(stats *Stats) { stats.Reconnects++ })
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(reconnectBackoff):
+		}
+	}
+}
+
+// run sends the message over a new stream and processes the responses until the message is acked.
+func (esc *EchoSphereClient) run(ctx context.Context) error {
 	stream, err := esc.cli.Transmit(ctx)
 	if err != nil {
 		return err
@@ -20,6 +56,12 @@ func (esc *EchoSphereClient) Run(ctx context.Context) error {
 		return err
 	}
 
+	esc.recordStats(func(stats *Stats) {
+		if stats.SentAt.IsZero() {
+			stats.SentAt = time.Now()
+		}
+	})
+
 	g, ctx := errgroup.WithContext(ctx)
 	resChan := make(chan *v1.EchoSphereTransmissionServiceTransmitResponse, 1)
 
@@ -41,7 +83,7 @@ func (esc *EchoSphereClient) Run(ctx context.Context) error {
 // receiveMessages continuously receives messages from the gRPC stream and sends them to the result channel.
 // It returns an error if the context is canceled or if there's an error receiving messages.
 func (esc *EchoSphereClient) receiveMessages(ctx context.Context, stream v1.EchoSphereTransmissionService_TransmitClient, resChan chan *v1.EchoSphereTransmissionServiceTransmitResponse) error {
-	defer func() { esc.logger.Info("Closing ReceiveMessages") }()
+	defer func() { esc.logger.Info("Closing ReceiveMessages", v1.TraceField(ctx)) }()
 
 	for {
 		select {
@@ -50,9 +92,19 @@ func (esc *EchoSphereClient) receiveMessages(ctx context.Context, stream v1.Echo
 		default:
 			recv, err := stream.Recv()
 			if err != nil {
+				// a stream ended by the cancellation of the client reports the cancellation
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+
 				return err
 			}
-			resChan <- recv
+
+			select {
+			case resChan <- recv:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
 		}
 	}
 }
@@ -60,33 +112,99 @@ func (esc *EchoSphereClient) receiveMessages(ctx context.Context, stream v1.Echo
 // sendMessages continuously sends messages via the gRPC stream, handles retries, and processes received messages.
 // It returns an error if the context is canceled or if there's an error processing received
 func (esc *EchoSphereClient) sendMessages(ctx context.Context, stream v1.EchoSphereTransmissionService_TransmitClient, resChan chan *v1.EchoSphereTransmissionServiceTransmitResponse) error {
-	defer func() { esc.logger.Info("Closing SendMessage") }()
+	defer func() { esc.logger.Info("Closing SendMessage", v1.TraceField(ctx)) }()
 
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-time.After(esc.deadline):
+			esc.recordStats(func(stats *Stats) { stats.Resends++ })
+			esc.instruments.resent(resendDeadline)
+			trace.SpanFromContext(ctx).AddEvent("resent", trace.WithAttributes(attribute.String("reason", resendDeadline)))
+
 			err := esc.sendMessage(stream, esc.message)
 			if err != nil {
 				return err
 			}
 		case recv := <-resChan:
-			if err := esc.processReceivedMessage(stream, recv); err != nil {
+			err := esc.processReceivedMessage(ctx, stream, recv)
+			if errors.Is(err, ErrDone) {
+				return esc.drain(ctx, stream, resChan)
+			}
+
+			if err != nil {
 				return err
 			}
 		}
 	}
 }
 
-// processReceivedMessage handles the received message, manages ack, and sends ack for received messages.
-// It returns ErrDone if the received ack matches the message sent, or if there's an error sending an ack.
-func (esc *EchoSphereClient) processReceivedMessage(stream v1.EchoSphereTransmissionService_TransmitClient, recv *v1.EchoSphereTransmissionServiceTransmitResponse) error {
-	if ack := recv.GetAck(); ack != nil {
-		if ack.GetTo() == esc.clientID && ack.GetContent() == esc.message.GetMessage().GetContent() {
+// drain keeps processing the received frames once the message is acked, until the delayed acks the others are
+// still waiting for are sent. It returns ErrDone then.
+func (esc *EchoSphereClient) drain(ctx context.Context, stream v1.EchoSphereTransmissionService_TransmitClient, resChan chan *v1.EchoSphereTransmissionServiceTransmitResponse) error {
+	// no delayed ack is added once the message is acked, see processReceivedMessage
+	sent := make(chan struct{})
+
+	go func() {
+		esc.acking.Wait()
+		close(sent)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-sent:
 			_ = stream.CloseSend() //nolint:errcheck
 
 			return ErrDone
+		case recv := <-resChan:
+			if err := esc.processReceivedMessage(ctx, stream, recv); err != nil && !errors.Is(err, ErrDone) {
+				return err
+			}
+		}
+	}
+}
+
+// processReceivedMessage handles the received message, manages ack, and sends ack or nack for received messages.
+// A nack of the client's own message resends it. It returns ErrDone if the received ack matches the message sent, or if there's an error sending an ack.
+func (esc *EchoSphereClient) processReceivedMessage(ctx context.Context, stream v1.EchoSphereTransmissionService_TransmitClient, recv *v1.EchoSphereTransmissionServiceTransmitResponse) error {
+	if frameErr := recv.GetError(); frameErr != nil {
+		esc.logger.Warn("Server rejected a frame", zap.Object("error", frameErr), v1.TraceField(ctx))
+
+		return nil
+	}
+
+	if ack := recv.GetAck(); ack != nil {
+		// the ack of a resend may arrive after the first one, once the client is draining
+		if ack.GetTo() == esc.clientID && ack.GetContent() == esc.message.GetMessage().GetContent() &&
+			esc.Stats().AckedAt.IsZero() {
+			var latency time.Duration
+
+			esc.recordStats(func(stats *Stats) {
+				stats.AckedAt = time.Now()
+				latency = stats.AckedAt.Sub(stats.SentAt)
+			})
+			esc.instruments.acked(latency)
+
+			roundTrip := trace.SpanFromContext(ctx)
+			roundTrip.AddEvent("acked", trace.WithAttributes(attribute.String("echosphere.by", ack.GetFrom())))
+			roundTrip.End()
+
+			return ErrDone
+		}
+	}
+
+	// a nack of our message means nobody will ack it, resend it instead of waiting for the deadline
+	if nack := recv.GetNack(); nack != nil {
+		if nack.GetTo() == esc.clientID && nack.GetContent() == esc.message.GetMessage().GetContent() {
+			esc.logger.Info("Message was not acknowledged, resending", zap.Object("nack", nack), v1.TraceField(ctx))
+			esc.recordStats(func(stats *Stats) { stats.Nacks++; stats.Resends++ })
+			esc.instruments.resent(resendNack)
+			trace.SpanFromContext(ctx).AddEvent("resent", trace.WithAttributes(attribute.String("reason", resendNack)))
+
+			return esc.sendMessage(stream, esc.message)
 		}
 	}
 
@@ -96,18 +214,58 @@ func (esc *EchoSphereClient) processReceivedMessage(stream v1.EchoSphereTransmis
 			return nil
 		}
 
-		err := esc.sendMessage(
-			stream,
-			&v1.EchoSphereTransmissionServiceTransmitRequest{IncomingData: &v1.EchoSphereTransmissionServiceTransmitRequest_Ack{Ack: &v1.Ack{
-				From:    esc.clientID,
-				To:      recvMessage.GetFrom(),
-				Content: recvMessage.GetContent(),
-			}}},
-		)
-		if err != nil {
-			return err
+		ctx, span := startAckSpan(ctx, recvMessage)
+
+		if esc.accept != nil && !esc.accept(recvMessage) {
+			span.SetAttributes(attribute.Bool("echosphere.rejected", true))
+
+			return endSpan(span, esc.sendMessage(
+				stream,
+				&v1.EchoSphereTransmissionServiceTransmitRequest{IncomingData: &v1.EchoSphereTransmissionServiceTransmitRequest_Nack{Nack: &v1.Nack{
+					From:    esc.clientID,
+					To:      recvMessage.GetFrom(),
+					Content: recvMessage.GetContent(),
+					Reason:  v1.NackReason_NACK_REASON_REJECTED,
+				}}},
+			))
 		}
+
+		ack := &v1.EchoSphereTransmissionServiceTransmitRequest{IncomingData: &v1.EchoSphereTransmissionServiceTransmitRequest_Ack{Ack: &v1.Ack{
+			From:         esc.clientID,
+			To:           recvMessage.GetFrom(),
+			Content:      recvMessage.GetContent(),
+			TraceContext: v1.InjectTraceContext(ctx),
+		}}}
+
+		// a client whose message is acked is leaving, it acks right away
+		if esc.ackDelay == nil || !esc.Stats().AckedAt.IsZero() {
+			return endSpan(span, esc.sendMessage(stream, ack))
+		}
+
+		esc.sendDelayedAck(ctx, stream, ack, esc.ackDelay(), span)
 	}
 
 	return nil
 }
+
+// sendDelayedAck sends the ack after delay without holding up the processing of the other frames,
+// unless the stream ends first. The span of the ack ends once it is sent.
+func (esc *EchoSphereClient) sendDelayedAck(ctx context.Context, stream v1.EchoSphereTransmissionService_TransmitClient, ack *v1.EchoSphereTransmissionServiceTransmitRequest, delay time.Duration, span trace.Span) {
+	esc.acking.Add(1)
+
+	go func() {
+		defer esc.acking.Done()
+
+		select {
+		case <-ctx.Done():
+			_ = endSpan(span, ctx.Err()) //nolint:errcheck
+
+			return
+		case <-time.After(delay):
+		}
+
+		if err := endSpan(span, esc.sendMessage(stream, ack)); err != nil {
+			esc.logger.Warn("Failed to send delayed ack", zap.Object("ack", ack), zap.Error(err), v1.TraceField(ctx))
+		}
+	}()
+}
@@ -2,14 +2,17 @@ package middleware
 
 import (
 	"context"
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
 	"go.uber.org/zap"
-	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc"
 	"time"
 )
 
-// StreamLogger returns a grpc.StreamServerInterceptor that logs stream events.
-func StreamLogger(logger *zap.Logger) grpc.StreamClientInterceptor {
+// StreamLogger returns a grpc.StreamServerInterceptor that logs stream events, keeping of the frames what the
+// capture policy allows.
+func StreamLogger(logger *zap.Logger, capture v1.CapturePolicy, sampling v1.LogSampling) grpc.StreamClientInterceptor {
+	logger = sampling.Sampled(logger)
+
 	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
 		stream, err := streamer(ctx, desc, cc, method, opts...)
 		if err != nil {
@@ -19,6 +22,7 @@ func StreamLogger(logger *zap.Logger) grpc.StreamClientInterceptor {
 		return &loggerServerStream{
 			ClientStream: stream,
 			logger:       logger,
+			capture:      capture,
 		}, nil
 	}
 }
@@ -26,7 +30,8 @@ func StreamLogger(logger *zap.Logger) grpc.StreamClientInterceptor {
 // loggerServerStream wraps a grpc.ServerStream and logs received and sent messages.
 type loggerServerStream struct {
 	grpc.ClientStream
-	logger *zap.Logger
+	logger  *zap.Logger
+	capture v1.CapturePolicy
 }
 
 // RecvMsg logs the received message and the time taken to handle it.
@@ -59,15 +64,11 @@ func (s *loggerServerStream) SendMsg(m any) error {
 
 // logMessage logs a message with additional fields.
 func (s *loggerServerStream) logMessage(msg string, m any, additionalFields ...zap.Field) {
-	additionalFields = append(additionalFields, s.getMessageField(m))
+	additionalFields = append(additionalFields, s.getMessageField(m), v1.FrameTraceField(m))
 	s.logger.Info(msg, additionalFields...)
 }
 
 // getMessageField returns a zap.Field for logging the message.
 func (s *loggerServerStream) getMessageField(m any) zap.Field {
-	if marshaler, ok := m.(zapcore.ObjectMarshaler); ok {
-		return zap.Object("message", marshaler)
-	}
-
-	return zap.Any("message", m)
+	return zap.Object("message", s.capture.Object(m))
 }
@@ -2,12 +2,18 @@ package middleware
 
 import (
 	"context"
+	"errors"
+	"io"
 	"log"
 	"time"
 
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // MetricsRecorder records metrics for gRPC streaming.
@@ -48,11 +54,12 @@ func createFloat64Histogram(meter metric.Meter, name string) metric.Float64Histo
 	return histogram
 }
 
-// StreamMetric returns a gRPC client stream interceptor that records metrics.
+// StreamMetric returns a gRPC client stream interceptor that records metrics,
+// the instruments are shared by every stream of the interceptor.
 func StreamMetric() grpc.StreamClientInterceptor {
-	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
-		meter := otel.GetMeterProvider().Meter("grpc_client_metrics")
+	metrics := newMetricsRecorder(otel.GetMeterProvider().Meter("grpc_client_metrics"))
 
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
 		clientStream, err := streamer(ctx, desc, cc, method, opts...)
 		if err != nil {
 			return nil, err
@@ -60,7 +67,7 @@ func StreamMetric() grpc.StreamClientInterceptor {
 
 		return &metricClientStream{
 			ClientStream: clientStream,
-			metrics:      newMetricsRecorder(meter),
+			metrics:      metrics,
 			method:       method,
 		}, nil
 	}
@@ -77,9 +84,9 @@ func (s *metricClientStream) RecvMsg(m interface{}) error {
 
 	err := s.ClientStream.RecvMsg(m)
 
-	defer s.metrics.recordMetrics(context.Background(), err, time.Since(start).Seconds()) //nolint:govet
+	s.metrics.recordMetrics(context.Background(), "received", s.method, m, err, time.Since(start).Seconds())
 
-	return nil
+	return err
 }
 
 func (s *metricClientStream) SendMsg(m interface{}) error {
@@ -87,20 +94,33 @@ func (s *metricClientStream) SendMsg(m interface{}) error {
 
 	err := s.ClientStream.SendMsg(m)
 
-	defer s.metrics.recordMetrics(context.Background(), err, time.Since(start).Seconds()) //nolint:govet
+	s.metrics.recordMetrics(context.Background(), "sent", s.method, m, err, time.Since(start).Seconds())
 
 	return err
 }
 
-// recordMetrics records the metrics for a gRPC streaming call.
-func (mr *MetricsRecorder) recordMetrics(ctx context.Context, err error, executionTime float64) {
-	mr.requestsCounter.Add(ctx, 1)
+// recordMetrics records the metrics for a frame sent or received in the given direction.
+// The end of the stream, io.EOF, is not a failure and a frame that was not received is of type none.
+func (mr *MetricsRecorder) recordMetrics(ctx context.Context, direction, method string, frame any, err error, executionTime float64) {
+	code := status.Code(err)
+	if errors.Is(err, io.EOF) {
+		code = codes.OK
+	}
 
-	if err != nil {
-		mr.failureCounter.Add(ctx, 1)
+	attrs := metric.WithAttributes(
+		attribute.String("direction", direction),
+		attribute.String("frame", v1.FrameType(frame)),
+		attribute.String("rpc.method", method),
+		attribute.String("rpc.grpc.status_code", code.String()),
+	)
+
+	mr.requestsCounter.Add(ctx, 1, attrs)
+
+	if code != codes.OK {
+		mr.failureCounter.Add(ctx, 1, attrs)
 	} else {
-		mr.successCounter.Add(ctx, 1)
+		mr.successCounter.Add(ctx, 1, attrs)
 	}
 
-	mr.executionTimeHistogram.Record(ctx, executionTime)
+	mr.executionTimeHistogram.Record(ctx, executionTime, attrs)
 }
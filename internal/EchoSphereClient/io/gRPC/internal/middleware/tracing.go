@@ -2,19 +2,16 @@ package middleware
 
 import (
 	"context"
-	"log"
-
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
-	"google.golang.org/protobuf/encoding/protojson"
-	"google.golang.org/protobuf/proto"
 )
 
-// StreamTracing returns a gRPC client stream interceptor that traces streaming RPC calls.
-func StreamTracing() grpc.StreamClientInterceptor {
+// StreamTracing returns a gRPC client stream interceptor that traces streaming RPC calls, keeping of the frames
+// what the capture policy allows.
+func StreamTracing(capture v1.CapturePolicy) grpc.StreamClientInterceptor {
 	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
 		tracer := otel.GetTracerProvider().Tracer("client.echosphere.io/grpc")
 
@@ -27,14 +24,16 @@ func StreamTracing() grpc.StreamClientInterceptor {
 			ClientStream: clientStream,
 			tracer:       tracer,
 			method:       method,
+			capture:      capture,
 		}, nil
 	}
 }
 
 type tracingClientStream struct {
 	grpc.ClientStream
-	tracer trace.Tracer
-	method string
+	tracer  trace.Tracer
+	method  string
+	capture v1.CapturePolicy
 }
 
 func (s *tracingClientStream) RecvMsg(m interface{}) error {
@@ -46,24 +45,22 @@ func (s *tracingClientStream) RecvMsg(m interface{}) error {
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
-	}
 
-	// Marshal the message to bytes for tracing
-	bytes, err := protojson.Marshal(m.(proto.Message))
-	if err != nil {
-		log.Printf("Error marshaling message: %v", err)
 		return err
 	}
 
-	span.SetAttributes(attribute.String("Message", string(bytes)))
+	span.SetAttributes(s.capture.Attributes(m)...)
 
-	return err
+	return nil
 }
 
 func (s *tracingClientStream) SendMsg(m interface{}) error {
 	err := s.ClientStream.SendMsg(m)
 
-	_, span := s.tracer.Start(s.Context(), "SendMsg", trace.WithSpanKind(trace.SpanKindClient))
+	_, span := s.tracer.Start(s.Context(), "SendMsg",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(s.capture.Attributes(m)...),
+	)
 	defer span.End()
 
 	if err != nil {
@@ -71,14 +68,5 @@ func (s *tracingClientStream) SendMsg(m interface{}) error {
 		span.SetStatus(codes.Error, err.Error())
 	}
 
-	// Marshal the message to bytes for tracing
-	bytes, err := protojson.Marshal(m.(proto.Message))
-	if err != nil {
-		log.Printf("Error marshaling message: %v", err)
-		return err
-	}
-
-	span.SetAttributes(attribute.String("Message", string(bytes)))
-
 	return err
 }
@@ -0,0 +1,54 @@
+package grpc
+
+import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"time"
+)
+
+// The reasons a message is resent.
+const (
+	resendDeadline = "deadline"
+	resendNack     = "nack"
+)
+
+// instruments record how long the message of the client takes to be acked and how often it is resent.
+type instruments struct {
+	ackLatency metric.Float64Histogram
+	resends    metric.Int64Counter
+}
+
+func newInstruments(meter metric.Meter) (*instruments, error) {
+	ackLatency, err := meter.Float64Histogram(
+		"ack_latency_seconds",
+		metric.WithDescription("Time from the message first sent to its ack received."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resends, err := meter.Int64Counter(
+		"message_resends_total",
+		metric.WithDescription("Messages sent again, after their deadline or a nack."),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &instruments{ackLatency: ackLatency, resends: resends}, nil
+}
+
+func defaultInstruments() (*instruments, error) {
+	return newInstruments(otel.GetMeterProvider().Meter("echosphere.io/client"))
+}
+
+func (i *instruments) acked(latency time.Duration) {
+	i.ackLatency.Record(context.Background(), latency.Seconds())
+}
+
+func (i *instruments) resent(reason string) {
+	i.resends.Add(context.Background(), 1, metric.WithAttributes(attribute.String("reason", reason)))
+}
@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"context"
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "client.echosphere.io/message"
+
+// startTrace starts the span of the round trip of the message, from its first send to its ack,
+// and puts its trace context in the message so the server and the recipient continue the trace.
+func (esc *EchoSphereClient) startTrace(ctx context.Context) (context.Context, trace.Span) { //nolint:ireturn
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "Transmit message",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(attribute.String("echosphere.from", esc.clientID)),
+	)
+
+	esc.message.GetMessage().TraceContext = v1.InjectTraceContext(ctx)
+
+	return ctx, span
+}
+
+// startAckSpan starts the span that acknowledges a relayed message as a child of the span that relayed it,
+// a message without a trace context starts a trace of its own.
+func startAckSpan(ctx context.Context, message *v1.Message) (context.Context, trace.Span) { //nolint:ireturn
+	opts := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(attribute.String("echosphere.from", message.GetFrom())),
+	}
+
+	parent := v1.ExtractTraceContext(ctx, message.GetTraceContext())
+	if !trace.SpanContextFromContext(parent).IsRemote() {
+		opts = append(opts, trace.WithNewRoot())
+	}
+
+	return otel.Tracer(tracerName).Start(parent, "Ack message", opts...)
+}
+
+// endSpan ends the span, recording err on it, and returns err.
+func endSpan(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+
+	return err
+}
@@ -42,6 +42,28 @@ func (s *ClientAcceptanceSuite) SetupSuite() {
 	go func() { s.NoError(s.server.Serve(listener)) }()
 }
 
+// runClient runs a client of the mocked server until ctx is canceled, the returned channel is closed once it returns.
+func (s *ClientAcceptanceSuite) runClient(ctx context.Context, deadline time.Duration) <-chan struct{} {
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		err := client.Run(ctx, client.Config{
+			Target:   "localhost:8080",
+			DeadLine: deadline,
+			SideCar: client.SideCar{
+				Enabled: true,
+				Port:    9091,
+			},
+		})
+
+		s.ErrorIs(err, context.Canceled)
+	}()
+
+	return done
+}
+
 // TestClientSendAMessage:
 //
 //	Scenario: Client connects to the server and sends message X
@@ -51,7 +73,6 @@ func (s *ClientAcceptanceSuite) SetupSuite() {
 //	  Then the client should listen for responses from the server
 func (s *ClientAcceptanceSuite) TestClientSendAMessage() {
 	ctx, cancelFunc := context.WithCancel(context.Background())
-	defer cancelFunc()
 
 	var streamChan = make(chan v1.EchoSphereTransmissionService_TransmitServer, 1)
 
@@ -63,17 +84,12 @@ func (s *ClientAcceptanceSuite) TestClientSendAMessage() {
 		return nil
 	})
 
-	go func() {
-		err := client.Run(ctx, client.Config{
-			Target:   "localhost:8080",
-			DeadLine: 30 * time.Second,
-			SideCar: client.SideCar{
-				Enabled: true,
-				Port:    9091,
-			},
-		})
+	done := s.runClient(ctx, 30*time.Second)
 
-		s.ErrorIs(err, context.Canceled)
+	// the client returns before the next test starts its own
+	defer func() {
+		cancelFunc()
+		<-done
 	}()
 
 	stream := <-streamChan
@@ -112,21 +128,12 @@ func (s *ClientAcceptanceSuite) TestOnAckClientCloses() {
 		return nil
 	})
 
-	closeChan := make(chan struct{}, 1)
-
-	go func() {
-		err := client.Run(ctx, client.Config{
-			Target:   "localhost:8080",
-			DeadLine: 30 * time.Second,
-			SideCar: client.SideCar{
-				Enabled: true,
-				Port:    9091,
-			},
-		})
-
-		s.ErrorIs(err, context.Canceled)
+	done := s.runClient(ctx, 30*time.Second)
 
-		close(closeChan)
+	// the client returns before the next test starts its own
+	defer func() {
+		cancelFunc()
+		<-done
 	}()
 
 	stream := <-streamChan
@@ -154,7 +161,7 @@ func (s *ClientAcceptanceSuite) TestOnAckClientCloses() {
 
 	s.Eventually(func() bool {
 		select {
-		case <-closeChan:
+		case <-done:
 			return true
 		default:
 			return false
@@ -170,7 +177,6 @@ func (s *ClientAcceptanceSuite) TestOnAckClientCloses() {
 //	  Then the client should reply with "ok Y"
 func (s *ClientAcceptanceSuite) TestClientAckMessage() {
 	ctx, cancelFunc := context.WithCancel(context.Background())
-	defer cancelFunc()
 
 	var streamChan = make(chan v1.EchoSphereTransmissionService_TransmitServer, 1)
 
@@ -182,17 +188,12 @@ func (s *ClientAcceptanceSuite) TestClientAckMessage() {
 		return nil
 	})
 
-	go func() {
-		err := client.Run(ctx, client.Config{
-			Target:   "localhost:8080",
-			DeadLine: 30 * time.Second,
-			SideCar: client.SideCar{
-				Enabled: true,
-				Port:    9091,
-			},
-		})
+	done := s.runClient(ctx, 30*time.Second)
 
-		s.ErrorIs(err, context.Canceled)
+	// the client returns before the next test starts its own
+	defer func() {
+		cancelFunc()
+		<-done
 	}()
 
 	stream := <-streamChan
@@ -233,7 +234,6 @@ func (s *ClientAcceptanceSuite) TestClientAckMessage() {
 //	  And this process should repeat until "ok X" is received
 func (s *ClientAcceptanceSuite) TestClientResendMessage() {
 	ctx, cancelFunc := context.WithCancel(context.Background())
-	defer cancelFunc()
 
 	var streamChan = make(chan v1.EchoSphereTransmissionService_TransmitServer, 1)
 
@@ -245,17 +245,12 @@ func (s *ClientAcceptanceSuite) TestClientResendMessage() {
 		return nil
 	})
 
-	go func() {
-		err := client.Run(ctx, client.Config{
-			Target:   "localhost:8080",
-			DeadLine: 1 * time.Second,
-			SideCar: client.SideCar{
-				Enabled: true,
-				Port:    9091,
-			},
-		})
+	done := s.runClient(ctx, 1*time.Second)
 
-		s.ErrorIs(err, context.Canceled)
+	// the client returns before the next test starts its own
+	defer func() {
+		cancelFunc()
+		<-done
 	}()
 
 	stream := <-streamChan
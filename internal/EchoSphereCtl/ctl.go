@@ -0,0 +1,195 @@
+// Package ctl implements echosphere-ctl, the command-line tool operators use to inspect and control a server
+// through its admin service.
+package ctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"io"
+	"time"
+)
+
+// Format selects how the results are printed.
+type Format string
+
+const (
+	// FormatTable prints aligned columns for humans.
+	FormatTable Format = "table"
+	// FormatJSON prints JSON for scripts, tailed events are printed one per line.
+	FormatJSON Format = "json"
+)
+
+var (
+	// ErrUnknownFormat is returned for an output format other than table or json.
+	ErrUnknownFormat = errors.New("unknown output format")
+	// ErrUnknownEventType is returned for a tail filter on an event type the admin service does not know.
+	ErrUnknownEventType = errors.New("unknown event type")
+)
+
+// TailFilter selects the events printed by Tail, an empty field matches every event.
+type TailFilter struct {
+	// Owners matches the events whose owner or peer is one of them.
+	Owners []string
+	// Types are event type names as printed by Tail, e.g. relayed or dropped.
+	Types []string
+}
+
+// Ctl runs the commands against the admin service of a server.
+type Ctl struct {
+	client  v1.EchoSphereAdminServiceClient
+	printer printer
+}
+
+// Config represents the configuration for creating a new Ctl.
+type Config struct {
+	Target   string
+	Format   Format
+	Output   io.Writer
+	DialOpts []grpc.DialOption
+}
+
+// New creates a new Ctl connected to the admin service at cfg.Target.
+func New(cfg Config) (*Ctl, error) {
+	if cfg.Format != FormatTable && cfg.Format != FormatJSON {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, cfg.Format)
+	}
+
+	conn, err := grpc.NewClient(
+		cfg.Target,
+		append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, cfg.DialOpts...)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Ctl{
+		client:  v1.NewEchoSphereAdminServiceClient(conn),
+		printer: printer{out: cfg.Output, format: cfg.Format},
+	}, nil
+}
+
+// List prints the clients connected to the server.
+func (c *Ctl) List(ctx context.Context) error {
+	res, err := c.client.ListConnections(ctx, &v1.EchoSphereAdminServiceListConnectionsRequest{})
+	if err != nil {
+		return err
+	}
+
+	rows := make([]connectionRow, 0, len(res.GetConnections()))
+
+	for _, connection := range res.GetConnections() {
+		rows = append(rows, connectionRow{
+			OwnerID:     connection.GetOwnerId(),
+			ConnectedAt: connection.GetConnectedAt().AsTime(),
+			StreamAge:   connection.GetStreamAge().AsDuration().Round(time.Millisecond).String(),
+		})
+	}
+
+	return c.printer.print(rows)
+}
+
+// Pending prints the relayed messages waiting for their ack.
+func (c *Ctl) Pending(ctx context.Context) error {
+	res, err := c.client.ListPending(ctx, &v1.EchoSphereAdminServiceListPendingRequest{})
+	if err != nil {
+		return err
+	}
+
+	rows := make([]pendingRow, 0, len(res.GetPending()))
+
+	for _, pending := range res.GetPending() {
+		rows = append(rows, pendingRow{
+			Originator:  pending.GetOriginator(),
+			Recipient:   pending.GetRecipient(),
+			ContentHash: pending.GetContentHash(),
+			Content:     pending.GetContent(),
+			Truncated:   pending.GetTruncated(),
+			RelayedAt:   pending.GetRelayedAt().AsTime(),
+		})
+	}
+
+	return c.printer.print(rows)
+}
+
+// Kick disconnects the client ownerID.
+func (c *Ctl) Kick(ctx context.Context, ownerID string) error {
+	if _, err := c.client.Kick(ctx, &v1.EchoSphereAdminServiceKickRequest{OwnerId: ownerID}); err != nil {
+		return err
+	}
+
+	return c.printer.print([]resultRow{{Result: "kicked " + ownerID}})
+}
+
+// Drain stops the server from accepting new streams.
+func (c *Ctl) Drain(ctx context.Context) error {
+	res, err := c.client.Drain(ctx, &v1.EchoSphereAdminServiceDrainRequest{})
+	if err != nil {
+		return err
+	}
+
+	return c.printer.print([]drainRow{{Draining: true, Connections: res.GetConnections()}})
+}
+
+// Stats prints a snapshot of the server.
+func (c *Ctl) Stats(ctx context.Context) error {
+	res, err := c.client.Stats(ctx, &v1.EchoSphereAdminServiceStatsRequest{})
+	if err != nil {
+		return err
+	}
+
+	return c.printer.print([]statsRow{{
+		Connections:       res.GetConnections(),
+		AvailableRelayers: res.GetAvailableRelayers(),
+		PendingAcks:       res.GetPendingAcks(),
+		QueueDepth:        res.GetQueueDepth(),
+		Draining:          res.GetDraining(),
+	}})
+}
+
+// Tail prints the lifecycle events of the server matching filter as they happen, until the context is done.
+func (c *Ctl) Tail(ctx context.Context, filter TailFilter) error {
+	req := &v1.EchoSphereAdminServiceWatchRequest{OwnerIds: filter.Owners}
+
+	for _, name := range filter.Types {
+		eventType, err := parseEventType(name)
+		if err != nil {
+			return err
+		}
+
+		req.Types = append(req.Types, eventType)
+	}
+
+	watch, err := c.client.Watch(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		res, err := watch.Recv()
+		if errors.Is(err, io.EOF) || ctx.Err() != nil {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		event := res.GetEvent()
+
+		err = c.printer.stream(eventRow{
+			At:          event.GetAt().AsTime(),
+			Type:        eventTypeName(event.GetType()),
+			OwnerID:     event.GetOwnerId(),
+			PeerID:      event.GetPeerId(),
+			ContentHash: event.GetContentHash(),
+			Reason:      event.GetReason(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package ctl_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	ctl "github.com/k4l1ma/EchoSphere/internal/EchoSphereCtl"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/admin"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/multiplexer"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type nopSender struct{}
+
+func (nopSender) SendMsg(context.Context, any) error { return nil }
+
+func (nopSender) Disconnect() {}
+
+type nopDrainer struct {
+	draining atomic.Bool
+}
+
+func (n *nopDrainer) Drain() { n.draining.Store(true) }
+
+func (n *nopDrainer) Draining() bool { return n.draining.Load() }
+
+// syncBuffer lets the test read what Tail writes from its own goroutine.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.buf.Write(p)
+}
+
+func (s *syncBuffer) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.buf.String()
+}
+
+type ctlSuite struct {
+	suite.Suite
+	cancel   context.CancelFunc
+	errGroup *errgroup.Group
+
+	listener *bufconn.Listener
+	useCases *usecase.UC
+}
+
+func (c *ctlSuite) SetupTest() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.errGroup = &errgroup.Group{}
+
+	mux := multiplexer.New()
+	c.useCases = usecase.New(usecase.Config{Router: mux})
+	c.listener = bufconn.Listen(1024 * 1024)
+
+	server := admin.NewServer(admin.Config{
+		Listener: c.listener,
+		Router:   mux,
+		UseCases: c.useCases,
+		Drainer:  &nopDrainer{},
+		Logger:   zap.NewNop(),
+	})
+
+	c.errGroup.Go(func() error { return server.Run(ctx) })
+	c.Require().Eventually(func() bool { return server.HealthCheck() == nil }, time.Second, time.Millisecond)
+}
+
+func (c *ctlSuite) TearDownTest() {
+	c.cancel()
+	c.Require().NoError(c.errGroup.Wait())
+}
+
+func (c *ctlSuite) newCtl(format ctl.Format, out interface{ Write([]byte) (int, error) }) *ctl.Ctl {
+	sut, err := ctl.New(ctl.Config{
+		Target: "passthrough:///admin.echosphere.io",
+		Format: format,
+		Output: out,
+		DialOpts: []grpc.DialOption{
+			grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return c.listener.Dial() }),
+		},
+	})
+	c.Require().NoError(err)
+
+	return sut
+}
+
+func (c *ctlSuite) connect(ownerID string) {
+	c.Require().NoError(c.useCases.RegisterHandler(context.Background(), usecase.RegisterCMD{OwnerID: ownerID, StreamSender: nopSender{}}))
+}
+
+func (c *ctlSuite) TestList_Table() {
+	c.connect("owner-a")
+
+	out := &bytes.Buffer{}
+	c.Require().NoError(c.newCtl(ctl.FormatTable, out).List(context.Background()))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	c.Require().Len(lines, 2)
+	c.Equal([]string{"OWNER_ID", "CONNECTED_AT", "STREAM_AGE"}, strings.Fields(lines[0]))
+	c.Equal("owner-a", strings.Fields(lines[1])[0])
+}
+
+func (c *ctlSuite) TestList_JSON() {
+	c.connect("owner-a")
+	c.connect("owner-b")
+
+	out := &bytes.Buffer{}
+	c.Require().NoError(c.newCtl(ctl.FormatJSON, out).List(context.Background()))
+
+	var connections []map[string]any
+	c.Require().NoError(json.Unmarshal(out.Bytes(), &connections))
+	c.Require().Len(connections, 2)
+	c.Equal("owner-a", connections[0]["owner_id"])
+}
+
+func (c *ctlSuite) TestKick() {
+	c.connect("owner-a")
+
+	out := &bytes.Buffer{}
+	c.Require().NoError(c.newCtl(ctl.FormatTable, out).Kick(context.Background(), "owner-a"))
+	c.Empty(c.useCases.Connections())
+
+	c.Require().Error(c.newCtl(ctl.FormatTable, out).Kick(context.Background(), "owner-a"))
+}
+
+func (c *ctlSuite) TestTail_JSON() {
+	ctx, cancel := context.WithCancel(context.Background())
+	out := &syncBuffer{}
+
+	done := make(chan error, 1)
+
+	go func() { done <- c.newCtl(ctl.FormatJSON, out).Tail(ctx, ctl.TailFilter{}) }()
+
+	// the watch may not be subscribed yet, connect clients until one of them shows up
+	c.Require().Eventually(func() bool {
+		c.connect("owner-" + time.Now().Format(time.RFC3339Nano))
+
+		return strings.Contains(out.String(), `"type":"registered"`)
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	c.Require().NoError(<-done)
+
+	var event map[string]any
+	c.Require().NoError(json.Unmarshal([]byte(strings.Split(out.String(), "\n")[0]), &event))
+	c.Equal("registered", event["type"])
+}
+
+func (c *ctlSuite) TestTail_UnknownType() {
+	err := c.newCtl(ctl.FormatJSON, &syncBuffer{}).Tail(context.Background(), ctl.TailFilter{Types: []string{"exploded"}})
+	c.Require().ErrorIs(err, ctl.ErrUnknownEventType)
+}
+
+func (c *ctlSuite) TestNew_UnknownFormat() {
+	_, err := ctl.New(ctl.Config{Target: "localhost:8081", Format: "yaml"})
+	c.Require().ErrorIs(err, ctl.ErrUnknownFormat)
+}
+
+func TestCtl(t *testing.T) {
+	suite.Run(t, new(ctlSuite))
+}
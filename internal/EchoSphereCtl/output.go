@@ -0,0 +1,145 @@
+package ctl
+
+import (
+	"encoding/json"
+	"fmt"
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+type connectionRow struct {
+	OwnerID     string    `json:"owner_id"`
+	ConnectedAt time.Time `json:"connected_at"`
+	StreamAge   string    `json:"stream_age"`
+}
+
+type pendingRow struct {
+	Originator  string    `json:"originator"`
+	Recipient   string    `json:"recipient"`
+	ContentHash string    `json:"content_hash"`
+	Content     string    `json:"content"`
+	Truncated   bool      `json:"truncated"`
+	RelayedAt   time.Time `json:"relayed_at"`
+}
+
+type resultRow struct {
+	Result string `json:"result"`
+}
+
+type drainRow struct {
+	Draining    bool  `json:"draining"`
+	Connections int64 `json:"connections"`
+}
+
+type statsRow struct {
+	Connections       int64 `json:"connections"`
+	AvailableRelayers int64 `json:"available_relayers"`
+	PendingAcks       int64 `json:"pending_acks"`
+	QueueDepth        int64 `json:"queue_depth"`
+	Draining          bool  `json:"draining"`
+}
+
+type eventRow struct {
+	At          time.Time `json:"at"`
+	Type        string    `json:"type"`
+	OwnerID     string    `json:"owner_id"`
+	PeerID      string    `json:"peer_id"`
+	ContentHash string    `json:"content_hash"`
+	Reason      string    `json:"reason"`
+}
+
+// printer prints rows as a table, whose columns are the json names of the row fields, or as JSON.
+type printer struct {
+	out    io.Writer
+	format Format
+
+	// headerDone is set once a streamed table printed its header
+	headerDone bool
+}
+
+// print prints all the rows at once, as a JSON array in json format.
+func (p *printer) print(rows any) error {
+	if p.format == FormatJSON {
+		encoder := json.NewEncoder(p.out)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(rows)
+	}
+
+	w := tabwriter.NewWriter(p.out, 0, 0, 2, ' ', 0)
+
+	values := reflect.ValueOf(rows)
+
+	fmt.Fprintln(w, strings.Join(columns(values.Type().Elem()), "\t"))
+
+	for i := range values.Len() {
+		fmt.Fprintln(w, strings.Join(cells(values.Index(i)), "\t"))
+	}
+
+	return w.Flush()
+}
+
+// stream prints one row as soon as it is known, as a JSON line in json format.
+func (p *printer) stream(row any) error {
+	if p.format == FormatJSON {
+		return json.NewEncoder(p.out).Encode(row)
+	}
+
+	// a streamed table cannot be aligned on rows still to come, columns are separated by tabs
+	value := reflect.ValueOf(row)
+
+	if !p.headerDone {
+		p.headerDone = true
+
+		if _, err := fmt.Fprintln(p.out, strings.Join(columns(value.Type()), "\t")); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintln(p.out, strings.Join(cells(value), "\t"))
+
+	return err
+}
+
+func columns(row reflect.Type) []string {
+	names := make([]string, row.NumField())
+
+	for i := range names {
+		names[i] = strings.ToUpper(row.Field(i).Tag.Get("json"))
+	}
+
+	return names
+}
+
+func cells(row reflect.Value) []string {
+	values := make([]string, row.NumField())
+
+	for i := range values {
+		switch field := row.Field(i).Interface().(type) {
+		case time.Time:
+			values[i] = field.Format(time.RFC3339)
+		default:
+			values[i] = fmt.Sprint(field)
+		}
+	}
+
+	return values
+}
+
+func eventTypeName(eventType v1.EventType) string {
+	return strings.ToLower(strings.TrimPrefix(eventType.String(), "EVENT_TYPE_"))
+}
+
+// parseEventType is the inverse of eventTypeName.
+func parseEventType(name string) (v1.EventType, error) {
+	value, ok := v1.EventType_value["EVENT_TYPE_"+strings.ToUpper(name)]
+	if !ok || value == int32(v1.EventType_EVENT_TYPE_UNSPECIFIED) {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownEventType, name)
+	}
+
+	return v1.EventType(value), nil
+}
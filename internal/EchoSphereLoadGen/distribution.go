@@ -0,0 +1,114 @@
+package loadgen
+
+import (
+	"errors"
+	"fmt"
+	"golang.org/x/exp/rand"
+	"strings"
+	"time"
+)
+
+// ErrInvalidDistribution is returned for a distribution that cannot be parsed.
+var ErrInvalidDistribution = errors.New("invalid distribution")
+
+// Distribution draws durations, e.g. how long the clients wait before acking.
+type Distribution struct {
+	kind     string
+	min, max time.Duration
+	mean     time.Duration
+}
+
+// Constant always draws d.
+func Constant(d time.Duration) Distribution {
+	return Distribution{kind: "constant", min: d, max: d}
+}
+
+// Uniform draws between lower and upper.
+func Uniform(lower, upper time.Duration) Distribution {
+	return Distribution{kind: "uniform", min: lower, max: upper}
+}
+
+// Exponential draws around mean, most draws are short and a few are long.
+func Exponential(mean time.Duration) Distribution {
+	return Distribution{kind: "exponential", mean: mean}
+}
+
+// ParseDistribution parses constant:<d>, uniform:<min>-<max> or exponential:<mean>, a bare duration is constant.
+func ParseDistribution(value string) (Distribution, error) {
+	kind, args, found := strings.Cut(value, ":")
+	if !found {
+		kind, args = "constant", value
+	}
+
+	switch kind {
+	case "constant":
+		d, err := time.ParseDuration(args)
+		if err != nil {
+			return Distribution{}, fmt.Errorf("%w %q: %w", ErrInvalidDistribution, value, err)
+		}
+
+		return Constant(d), nil
+	case "uniform":
+		lowerArg, upperArg, found := strings.Cut(args, "-")
+		if !found {
+			return Distribution{}, fmt.Errorf("%w %q: uniform takes <min>-<max>", ErrInvalidDistribution, value)
+		}
+
+		lower, err := time.ParseDuration(lowerArg)
+		if err != nil {
+			return Distribution{}, fmt.Errorf("%w %q: %w", ErrInvalidDistribution, value, err)
+		}
+
+		upper, err := time.ParseDuration(upperArg)
+		if err != nil {
+			return Distribution{}, fmt.Errorf("%w %q: %w", ErrInvalidDistribution, value, err)
+		}
+
+		if upper < lower {
+			return Distribution{}, fmt.Errorf("%w %q: max is below min", ErrInvalidDistribution, value)
+		}
+
+		return Uniform(lower, upper), nil
+	case "exponential", "exp":
+		mean, err := time.ParseDuration(args)
+		if err != nil {
+			return Distribution{}, fmt.Errorf("%w %q: %w", ErrInvalidDistribution, value, err)
+		}
+
+		return Exponential(mean), nil
+	default:
+		return Distribution{}, fmt.Errorf("%w %q: unknown kind %q", ErrInvalidDistribution, value, kind)
+	}
+}
+
+// Draw returns a duration of the distribution, the zero Distribution always draws 0.
+func (d Distribution) Draw() time.Duration {
+	switch d.kind {
+	case "uniform":
+		if d.max == d.min {
+			return d.min
+		}
+
+		return d.min + time.Duration(rand.Int63n(int64(d.max-d.min)))
+	case "exponential":
+		return time.Duration(rand.ExpFloat64() * float64(d.mean))
+	default:
+		return d.min
+	}
+}
+
+// IsZero tells whether d is the zero Distribution, which always draws 0.
+func (d Distribution) IsZero() bool {
+	return d == Distribution{}
+}
+
+func (d Distribution) String() string {
+	switch d.kind {
+	case "uniform":
+		return fmt.Sprintf("uniform:%s-%s", d.min, d.max)
+	case "exponential":
+		return fmt.Sprintf("exponential:%s", d.mean)
+	default:
+		return fmt.Sprintf("constant:%s", d.min)
+	}
+}
@@ -0,0 +1,193 @@
+// Package loadgen runs many EchoSphere clients against a server, each sending its message and acking the ones
+// relayed to it, and reports how the run went.
+//
+// A run is made of phases, cohorts of clients sharing a traffic shape, that may overlap: a ramp-up, a steady
+// state, clients that disconnect before acking, slow ackers or bursts.
+package loadgen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	esc "github.com/k4l1ma/EchoSphere/internal/EchoSphereClient/io/gRPC"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The failure categories of the clients whose message was not acked, the others are named after the gRPC status
+// code the stream ended with.
+const (
+	FailureDial         = "dial"
+	FailureTimeout      = "timeout"
+	FailureInterrupted  = "interrupted"
+	FailureNotStarted   = "not_started"
+	FailureDisconnected = "disconnected"
+)
+
+// ErrNoClients is returned for a run without clients.
+var ErrNoClients = errors.New("no clients to run")
+
+// Config represents the configuration of a load run.
+type Config struct {
+	Target string
+	Phases []Phase
+	// Duration bounds the run, the clients not acked by then fail with FailureTimeout, 0 does not bound it.
+	Duration time.Duration
+	// Deadline is how long a client waits for its ack before resending its message.
+	Deadline time.Duration
+	// Reconnects is how many times a client opens its stream again after it fails before the ack.
+	Reconnects int
+	DialOpts   []grpc.DialOption
+	// Logger receives the logs of the clients.
+	Logger *zap.Logger
+}
+
+// Phase is a cohort of clients sharing a traffic shape.
+type Phase struct {
+	Name    string
+	Clients int
+	// Start is when the phase starts, from the start of the run.
+	Start time.Duration
+	// Ramp spreads the start of the clients over RampOver.
+	Ramp     Ramp
+	RampOver time.Duration
+	// AckDelay is how long the clients wait before acking a relayed message.
+	AckDelay Distribution
+	// DisconnectAfter closes the stream of each client after a draw, whether it was acked or not,
+	// the zero Distribution keeps it until the ack.
+	DisconnectAfter Distribution
+}
+
+// result is how one client fared.
+type result struct {
+	phase   int
+	stats   esc.Stats
+	failure string
+}
+
+// Run runs the clients of every phase until each of them is acked or failed, or the run duration is over.
+// A run interrupted by the context still reports the clients that finished.
+func Run(ctx context.Context, cfg Config) (Report, error) {
+	clients := 0
+
+	for i, phase := range cfg.Phases {
+		if phase.Clients < 0 {
+			return Report{}, fmt.Errorf("phase %d has %d clients", i, phase.Clients)
+		}
+
+		clients += phase.Clients
+	}
+
+	if clients == 0 {
+		return Report{}, ErrNoClients
+	}
+
+	if cfg.Logger == nil {
+		cfg.Logger = zap.NewNop()
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	if cfg.Duration > 0 {
+		runCtx, cancel = context.WithTimeout(ctx, cfg.Duration)
+	}
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		results = make([]result, 0, clients)
+		wg      sync.WaitGroup
+	)
+
+	start := time.Now()
+
+	for p, phase := range cfg.Phases {
+		for i := range phase.Clients {
+			delay := phase.Start + phase.Ramp.startDelay(i, phase.Clients, phase.RampOver)
+
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				res := runClient(ctx, runCtx, cfg, phase, delay)
+				res.phase = p
+
+				mu.Lock()
+				defer mu.Unlock()
+
+				results = append(results, res)
+			}()
+		}
+	}
+
+	wg.Wait()
+
+	return newReport(cfg, time.Since(start), results), nil
+}
+
+func runClient(ctx, runCtx context.Context, cfg Config, phase Phase, delay time.Duration) result {
+	select {
+	case <-runCtx.Done():
+		return result{failure: FailureNotStarted}
+	case <-time.After(delay):
+	}
+
+	client, err := esc.NewEchoSphereClient(esc.Config{
+		Logger:     cfg.Logger,
+		Target:     cfg.Target,
+		DialOpts:   cfg.DialOpts,
+		Deadline:   cfg.Deadline,
+		AckDelay:   phase.AckDelay.Draw,
+		Reconnects: cfg.Reconnects,
+	})
+	if err != nil {
+		return result{failure: FailureDial}
+	}
+	defer client.Close()
+
+	clientCtx, disconnect := runCtx, context.CancelFunc(func() {})
+	if !phase.DisconnectAfter.IsZero() {
+		clientCtx, disconnect = context.WithTimeout(runCtx, phase.DisconnectAfter.Draw())
+	}
+	defer disconnect()
+
+	err = client.Run(clientCtx)
+
+	stats := client.Stats()
+
+	switch {
+	case !stats.AckedAt.IsZero():
+		return result{stats: stats}
+	case ctx.Err() != nil:
+		return result{stats: stats, failure: FailureInterrupted}
+	case runCtx.Err() != nil:
+		return result{stats: stats, failure: FailureTimeout}
+	case clientCtx.Err() != nil:
+		return result{stats: stats, failure: FailureDisconnected}
+	default:
+		return result{stats: stats, failure: failureOf(err)}
+	}
+}
+
+// failureOf names the failure after the gRPC status code of err, e.g. unavailable or resource_exhausted.
+func failureOf(err error) string {
+	return strings.ToLower(toSnakeCase(status.Code(err).String()))
+}
+
+func toSnakeCase(name string) string {
+	var b strings.Builder
+
+	for i, r := range name {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			b.WriteByte('_')
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,247 @@
+package loadgen_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	loadgen "github.com/k4l1ma/EchoSphere/internal/EchoSphereLoadGen"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/audit"
+	essGRPC "github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/gRPC"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/multiplexer"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
+	verifier "github.com/k4l1ma/EchoSphere/internal/EchoSphereVerifier"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type loadGenSuite struct {
+	suite.Suite
+	cancel context.CancelFunc
+	done   chan error
+
+	dialOpts []grpc.DialOption
+	useCases *usecase.UC
+	// auditPath is the audit log of the server
+	auditPath string
+	sink      *audit.Sink
+}
+
+func (l *loadGenSuite) SetupTest() {
+	ctx, cancel := context.WithCancel(context.Background())
+	l.cancel, l.done = cancel, make(chan error, 1)
+
+	listener := bufconn.Listen(1024 * 1024)
+	mux := multiplexer.New()
+	bus := usecase.NewBus()
+
+	var err error
+
+	l.auditPath = filepath.Join(l.T().TempDir(), "audit.jsonl")
+	l.sink, err = audit.NewSink(audit.Config{Path: l.auditPath, Logger: zap.NewNop()})
+	l.Require().NoError(err)
+	bus.Subscribe(usecase.EventFilter{}, l.sink.Handle)
+
+	l.useCases = usecase.New(usecase.Config{Router: mux, Bus: bus})
+
+	server := essGRPC.NewServer(essGRPC.Config{
+		Listener: listener,
+		Router:   mux,
+		Logger:   zap.NewNop(),
+		UseCases: l.useCases,
+	})
+
+	go func() { l.done <- server.Run(ctx) }()
+
+	l.Require().Eventually(func() bool { return server.HealthCheck() == nil }, time.Second, time.Millisecond)
+
+	l.dialOpts = []grpc.DialOption{
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+	}
+}
+
+func (l *loadGenSuite) TearDownTest() {
+	l.cancel()
+	l.Require().NoError(<-l.done)
+	l.Require().NoError(l.sink.Close())
+}
+
+func (l *loadGenSuite) config(clients int) loadgen.Config {
+	return loadgen.Config{
+		Target: "passthrough:///loadgen.echosphere.io",
+		Phases: []loadgen.Phase{{
+			Name:    "default",
+			Clients: clients,
+			Ramp:    loadgen.RampInstant,
+			// the clients linger while their acks are delayed, so the resends find someone to be relayed to
+			AckDelay: loadgen.Constant(100 * time.Millisecond),
+		}},
+		Duration: 10 * time.Second,
+		Deadline: 50 * time.Millisecond,
+		DialOpts: l.dialOpts,
+	}
+}
+
+func (l *loadGenSuite) TestRun() {
+	cfg := l.config(10)
+	cfg.Duration = 2 * time.Second
+
+	report, err := loadgen.Run(context.Background(), cfg)
+	l.Require().NoError(err)
+
+	// the last client may be relayed to one that is closing, then it is left alone with nobody to be relayed to
+	l.GreaterOrEqual(report.Acked, 9, "%+v", report)
+	l.Equal(10, report.Acked+report.Failures[loadgen.FailureTimeout], "%+v", report)
+	l.Positive(report.Throughput)
+	l.GreaterOrEqual(report.AckLatency.P50, float64(100))
+	l.LessOrEqual(report.AckLatency.P50, report.AckLatency.P99)
+	l.LessOrEqual(report.AckLatency.P99, report.AckLatency.Max)
+
+	out := &bytes.Buffer{}
+	l.Require().NoError(report.WriteJSON(out))
+
+	var decoded map[string]any
+	l.Require().NoError(json.Unmarshal(out.Bytes(), &decoded))
+	l.EqualValues(report.Acked, decoded["acked"])
+
+	out.Reset()
+	l.Require().NoError(report.WriteText(out))
+	l.Contains(out.String(), "ack latency")
+}
+
+func (l *loadGenSuite) TestRun_Verified() {
+	cfg := l.config(6)
+	cfg.Duration = 2 * time.Second
+	// without resends every delivery is a first one
+	cfg.Deadline = time.Minute
+
+	// the clients log their frames as echosphere-verify reads them, with the default capture policy
+	clientLog := &bytes.Buffer{}
+	cfg.Logger = zap.New(zapcore.NewCore(
+		zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		zapcore.Lock(zapcore.AddSync(clientLog)),
+		zap.InfoLevel,
+	))
+
+	report, err := loadgen.Run(context.Background(), cfg)
+	l.Require().NoError(err)
+	l.Require().Positive(report.Acked)
+
+	// a lone client is never relayed, only its own log tells its message was never acked
+	cfg.Phases[0].Clients, cfg.Duration = 1, 200*time.Millisecond
+
+	lone, err := loadgen.Run(context.Background(), cfg)
+	l.Require().NoError(err)
+	l.Require().Zero(lone.Acked)
+
+	// the server unregisters the clients once their streams are over
+	l.Require().Eventually(func() bool { return l.useCases.Stats().Connections == 0 }, time.Second, time.Millisecond)
+	l.Require().NoError(l.sink.Flush())
+
+	v := verifier.New()
+	l.Require().NoError(v.ReadServerLog(l.auditPath))
+	l.Require().NoError(v.ReadClientLog(clientLog))
+
+	verified := v.Report()
+	l.Len(verified.UnmatchedMessages, report.Clients-report.Acked+lone.Clients, "%+v", verified)
+	l.Empty(verified.AcksWithoutMessages)
+	l.Empty(verified.DuplicateDeliveries)
+	l.Empty(verified.LeftRegistered)
+}
+
+func (l *loadGenSuite) TestRun_Timeout() {
+	cfg := l.config(1)
+	cfg.Duration = 200 * time.Millisecond
+
+	// a lone client has nobody to be relayed to
+	report, err := loadgen.Run(context.Background(), cfg)
+	l.Require().NoError(err)
+
+	l.Zero(report.Acked)
+	l.Equal(map[string]int{loadgen.FailureTimeout: 1}, report.Failures)
+	l.Positive(report.Resends)
+}
+
+func (l *loadGenSuite) TestRun_Unavailable() {
+	stopped := bufconn.Listen(1024)
+	l.Require().NoError(stopped.Close())
+
+	cfg := l.config(2)
+	cfg.Reconnects = 2
+	cfg.DialOpts = []grpc.DialOption{
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return stopped.Dial() }),
+	}
+
+	report, err := loadgen.Run(context.Background(), cfg)
+	l.Require().NoError(err)
+
+	l.Equal(map[string]int{"unavailable": 2}, report.Failures)
+	l.Equal(4, report.Reconnects)
+}
+
+func (l *loadGenSuite) TestRun_Phases() {
+	cfg := l.config(6)
+	cfg.Duration = 2 * time.Second
+	cfg.Phases = append(cfg.Phases, loadgen.Phase{
+		Name:    "churn",
+		Clients: 3,
+		Ramp:    loadgen.RampInstant,
+		// they leave long before acking
+		AckDelay:        loadgen.Constant(time.Second),
+		DisconnectAfter: loadgen.Constant(20 * time.Millisecond),
+	})
+
+	report, err := loadgen.Run(context.Background(), cfg)
+	l.Require().NoError(err)
+
+	l.Equal(9, report.Clients)
+	l.Require().Len(report.Phases, 2)
+	l.GreaterOrEqual(report.Phases[0].Acked, 5, "%+v", report.Phases[0])
+	l.Equal(map[string]int{loadgen.FailureDisconnected: 3}, report.Phases[1].Failures)
+	l.Equal(report.Phases[0].Acked+report.Phases[1].Acked, report.Acked)
+}
+
+func (l *loadGenSuite) TestRun_NoClients() {
+	_, err := loadgen.Run(context.Background(), l.config(0))
+	l.Require().ErrorIs(err, loadgen.ErrNoClients)
+}
+
+func (l *loadGenSuite) TestParseDistribution() {
+	tests := map[string]struct {
+		value    string
+		min, max time.Duration
+	}{
+		"constant":    {value: "constant:1s", min: time.Second, max: time.Second},
+		"bare":        {value: "250ms", min: 250 * time.Millisecond, max: 250 * time.Millisecond},
+		"uniform":     {value: "uniform:1s-2s", min: time.Second, max: 2 * time.Second},
+		"exponential": {value: "exponential:1ms", min: 0, max: time.Hour},
+	}
+
+	for name, test := range tests {
+		l.Run(name, func() {
+			distribution, err := loadgen.ParseDistribution(test.value)
+			l.Require().NoError(err)
+
+			for range 100 {
+				d := distribution.Draw()
+				l.GreaterOrEqual(d, test.min)
+				l.LessOrEqual(d, test.max)
+			}
+		})
+	}
+
+	for _, value := range []string{"uniform:2s-1s", "uniform:1s", "normal:1s", "constant:soon"} {
+		_, err := loadgen.ParseDistribution(value)
+		l.Require().ErrorIs(err, loadgen.ErrInvalidDistribution, value)
+	}
+}
+
+func TestLoadGen(t *testing.T) {
+	suite.Run(t, new(loadGenSuite))
+}
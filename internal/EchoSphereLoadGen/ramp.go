@@ -0,0 +1,45 @@
+package loadgen
+
+import (
+	"fmt"
+	"golang.org/x/exp/rand"
+	"time"
+)
+
+// Ramp spreads the start of the clients over a period.
+type Ramp string
+
+const (
+	// RampInstant starts every client at once.
+	RampInstant Ramp = "instant"
+	// RampLinear starts the clients at a steady pace.
+	RampLinear Ramp = "linear"
+	// RampRandom starts each client at a random time, as the original load test does.
+	RampRandom Ramp = "random"
+)
+
+// ParseRamp parses the name of a Ramp.
+func ParseRamp(name string) (Ramp, error) {
+	switch ramp := Ramp(name); ramp {
+	case RampInstant, RampLinear, RampRandom:
+		return ramp, nil
+	default:
+		return "", fmt.Errorf("unknown ramp %q", name)
+	}
+}
+
+// startDelay returns when the i-th of n clients starts.
+func (r Ramp) startDelay(i, n int, over time.Duration) time.Duration {
+	if over <= 0 || n <= 1 {
+		return 0
+	}
+
+	switch r {
+	case RampLinear:
+		return over * time.Duration(i) / time.Duration(n-1)
+	case RampRandom:
+		return time.Duration(rand.Int63n(int64(over)))
+	default:
+		return 0
+	}
+}
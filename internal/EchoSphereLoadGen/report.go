@@ -0,0 +1,193 @@
+package loadgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"text/tabwriter"
+	"time"
+)
+
+// Report tells how a load run went, as a whole and phase by phase.
+type Report struct {
+	Target     string  `json:"target"`
+	Clients    int     `json:"clients"`
+	ElapsedSec float64 `json:"elapsed_seconds"`
+	Summary
+	Phases []PhaseReport `json:"phases"`
+}
+
+// PhaseReport tells how the clients of a phase went.
+type PhaseReport struct {
+	Name            string `json:"name"`
+	Clients         int    `json:"clients"`
+	Ramp            Ramp   `json:"ramp"`
+	AckDelay        string `json:"ack_delay"`
+	DisconnectAfter string `json:"disconnect_after,omitempty"`
+	Summary
+}
+
+// Summary tells how a set of clients went.
+type Summary struct {
+	Acked  int `json:"acked"`
+	Failed int `json:"failed"`
+	// Throughput is the number of messages acked per second of the run.
+	Throughput float64 `json:"throughput_per_second"`
+	// AckLatency summarizes the time from the first send of a message to its ack.
+	AckLatency Latency `json:"ack_latency"`
+	// Failures counts the clients not acked by category.
+	Failures   map[string]int `json:"failures"`
+	Reconnects int            `json:"reconnects"`
+	Resends    int            `json:"resends"`
+	Nacks      int            `json:"nacks"`
+}
+
+// Latency summarizes a set of latencies, in milliseconds.
+type Latency struct {
+	P50  float64 `json:"p50_ms"`
+	P90  float64 `json:"p90_ms"`
+	P99  float64 `json:"p99_ms"`
+	Max  float64 `json:"max_ms"`
+	Mean float64 `json:"mean_ms"`
+}
+
+func newReport(cfg Config, elapsed time.Duration, results []result) Report {
+	report := Report{
+		Target:     cfg.Target,
+		ElapsedSec: elapsed.Seconds(),
+		Summary:    summarize(elapsed, results),
+		Phases:     make([]PhaseReport, 0, len(cfg.Phases)),
+	}
+
+	for p, phase := range cfg.Phases {
+		report.Clients += phase.Clients
+
+		phaseReport := PhaseReport{
+			Name:     phase.Name,
+			Clients:  phase.Clients,
+			Ramp:     phase.Ramp,
+			AckDelay: phase.AckDelay.String(),
+			Summary:  summarize(elapsed, slices.DeleteFunc(slices.Clone(results), func(res result) bool { return res.phase != p })),
+		}
+
+		if !phase.DisconnectAfter.IsZero() {
+			phaseReport.DisconnectAfter = phase.DisconnectAfter.String()
+		}
+
+		report.Phases = append(report.Phases, phaseReport)
+	}
+
+	return report
+}
+
+func summarize(elapsed time.Duration, results []result) Summary {
+	summary := Summary{Failures: make(map[string]int)}
+	latencies := make([]time.Duration, 0, len(results))
+
+	for _, res := range results {
+		summary.Reconnects += res.stats.Reconnects
+		summary.Resends += res.stats.Resends
+		summary.Nacks += res.stats.Nacks
+
+		if res.failure != "" {
+			summary.Failed++
+			summary.Failures[res.failure]++
+
+			continue
+		}
+
+		summary.Acked++
+		latencies = append(latencies, res.stats.AckedAt.Sub(res.stats.SentAt))
+	}
+
+	if elapsed > 0 {
+		summary.Throughput = float64(summary.Acked) / elapsed.Seconds()
+	}
+
+	summary.AckLatency = summarizeLatencies(latencies)
+
+	return summary
+}
+
+func summarizeLatencies(latencies []time.Duration) Latency {
+	if len(latencies) == 0 {
+		return Latency{}
+	}
+
+	slices.Sort(latencies)
+
+	var total time.Duration
+
+	for _, latency := range latencies {
+		total += latency
+	}
+
+	return Latency{
+		P50:  milliseconds(percentile(latencies, 50)),
+		P90:  milliseconds(percentile(latencies, 90)),
+		P99:  milliseconds(percentile(latencies, 99)),
+		Max:  milliseconds(latencies[len(latencies)-1]),
+		Mean: milliseconds(total / time.Duration(len(latencies))),
+	}
+}
+
+// percentile returns the nearest-rank percentile p of the sorted latencies.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+
+	return sorted[max(rank, 1)-1]
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(r)
+}
+
+// WriteText writes the report for humans, the whole run first and then each phase when there are several.
+func (r Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "target\t%s\n", r.Target)
+	fmt.Fprintf(tw, "clients\t%d\n", r.Clients)
+	fmt.Fprintf(tw, "elapsed\t%.1fs\n", r.ElapsedSec)
+	writeSummary(tw, "", r.Summary)
+
+	if len(r.Phases) > 1 {
+		for _, phase := range r.Phases {
+			fmt.Fprintf(tw, "\nphase %s\t%d clients (ramp %s, ack delay %s)\n", phase.Name, phase.Clients, phase.Ramp, phase.AckDelay)
+			writeSummary(tw, "  ", phase.Summary)
+		}
+	}
+
+	return tw.Flush()
+}
+
+func writeSummary(w io.Writer, indent string, s Summary) {
+	fmt.Fprintf(w, "%sacked\t%d\n", indent, s.Acked)
+	fmt.Fprintf(w, "%sfailed\t%d\n", indent, s.Failed)
+	fmt.Fprintf(w, "%sthroughput\t%.1f acks/s\n", indent, s.Throughput)
+	fmt.Fprintf(w, "%sack latency\tp50 %.1fms  p90 %.1fms  p99 %.1fms  max %.1fms  mean %.1fms\n",
+		indent, s.AckLatency.P50, s.AckLatency.P90, s.AckLatency.P99, s.AckLatency.Max, s.AckLatency.Mean)
+	fmt.Fprintf(w, "%sreconnects\t%d\n", indent, s.Reconnects)
+	fmt.Fprintf(w, "%sresends\t%d\n", indent, s.Resends)
+	fmt.Fprintf(w, "%snacks\t%d\n", indent, s.Nacks)
+
+	categories := make([]string, 0, len(s.Failures))
+	for category := range s.Failures {
+		categories = append(categories, category)
+	}
+
+	slices.Sort(categories)
+
+	for _, category := range categories {
+		fmt.Fprintf(w, "%sfailures %s\t%d\n", indent, category, s.Failures[category])
+	}
+}
@@ -0,0 +1,141 @@
+package loadgen
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"time"
+)
+
+// defaultDeadline is the resend deadline of the clients of a scenario that does not set one.
+const defaultDeadline = 30 * time.Second
+
+// Scenario is a load run described in a YAML file, so the traffic shapes can be version controlled and replayed
+// against any server.
+type Scenario struct {
+	Name   string `yaml:"name"`
+	Target string `yaml:"target"`
+	// Duration bounds the run, 0 does not bound it.
+	Duration time.Duration `yaml:"duration"`
+	// Deadline is how long a client waits for its ack before resending its message, 30s if 0.
+	Deadline   time.Duration   `yaml:"deadline"`
+	Reconnects int             `yaml:"reconnects"`
+	Phases     []ScenarioPhase `yaml:"phases"`
+}
+
+// ScenarioPhase is a Phase as written in a scenario file, the distributions are written as ParseDistribution
+// parses them.
+type ScenarioPhase struct {
+	Name    string `yaml:"name"`
+	Clients int    `yaml:"clients"`
+	// Start is when the phase starts from the start of the run, by default once the previous phase
+	// has started all its clients.
+	Start *time.Duration `yaml:"start"`
+	// Ramp is instant, linear or random, linear by default.
+	Ramp            string        `yaml:"ramp"`
+	Over            time.Duration `yaml:"over"`
+	AckDelay        string        `yaml:"ack_delay"`
+	DisconnectAfter string        `yaml:"disconnect_after"`
+}
+
+// LoadScenario reads the scenario file at path.
+func LoadScenario(path string) (Scenario, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Scenario{}, err
+	}
+	defer file.Close()
+
+	scenario, err := ReadScenario(file)
+	if err != nil {
+		return Scenario{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return scenario, nil
+}
+
+// ReadScenario reads a scenario, rejecting the fields it does not know.
+func ReadScenario(r io.Reader) (Scenario, error) {
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+
+	var scenario Scenario
+
+	if err := decoder.Decode(&scenario); err != nil {
+		return Scenario{}, fmt.Errorf("failed to read scenario: %w", err)
+	}
+
+	if len(scenario.Phases) == 0 {
+		return Scenario{}, errors.New("failed to read scenario: no phases")
+	}
+
+	return scenario, nil
+}
+
+// Config turns the scenario into the Config of a run.
+func (s Scenario) Config() (Config, error) {
+	cfg := Config{
+		Target:     s.Target,
+		Duration:   s.Duration,
+		Deadline:   s.Deadline,
+		Reconnects: s.Reconnects,
+		Phases:     make([]Phase, 0, len(s.Phases)),
+	}
+
+	if cfg.Deadline == 0 {
+		cfg.Deadline = defaultDeadline
+	}
+
+	var next time.Duration
+
+	for i, scenarioPhase := range s.Phases {
+		phase, err := scenarioPhase.phase(next)
+		if err != nil {
+			return Config{}, fmt.Errorf("phase %d %q: %w", i, scenarioPhase.Name, err)
+		}
+
+		cfg.Phases = append(cfg.Phases, phase)
+		next = phase.Start + phase.RampOver
+	}
+
+	return cfg, nil
+}
+
+func (p ScenarioPhase) phase(start time.Duration) (Phase, error) {
+	phase := Phase{Name: p.Name, Clients: p.Clients, Start: start, Ramp: RampLinear, RampOver: p.Over}
+
+	if p.Start != nil {
+		phase.Start = *p.Start
+	}
+
+	if p.Ramp != "" {
+		ramp, err := ParseRamp(p.Ramp)
+		if err != nil {
+			return Phase{}, err
+		}
+
+		phase.Ramp = ramp
+	}
+
+	if p.AckDelay != "" {
+		ackDelay, err := ParseDistribution(p.AckDelay)
+		if err != nil {
+			return Phase{}, err
+		}
+
+		phase.AckDelay = ackDelay
+	}
+
+	if p.DisconnectAfter != "" {
+		disconnectAfter, err := ParseDistribution(p.DisconnectAfter)
+		if err != nil {
+			return Phase{}, err
+		}
+
+		phase.DisconnectAfter = disconnectAfter
+	}
+
+	return phase, nil
+}
@@ -0,0 +1,80 @@
+package loadgen_test
+
+import (
+	loadgen "github.com/k4l1ma/EchoSphere/internal/EchoSphereLoadGen"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func (l *loadGenSuite) TestReadScenario() {
+	scenario, err := loadgen.ReadScenario(strings.NewReader(`
+name: test
+deadline: 5s
+phases:
+  - name: ramp-up
+    clients: 10
+    over: 30s
+    ack_delay: uniform:0s-1s
+  - name: churn
+    clients: 5
+    ramp: random
+    over: 10s
+    disconnect_after: 2s
+  - name: burst
+    clients: 20
+    ramp: instant
+    start: 5s
+`))
+	l.Require().NoError(err)
+
+	cfg, err := scenario.Config()
+	l.Require().NoError(err)
+
+	l.Equal(5*time.Second, cfg.Deadline)
+	l.Require().Len(cfg.Phases, 3)
+
+	l.Equal(loadgen.RampLinear, cfg.Phases[0].Ramp)
+	l.Zero(cfg.Phases[0].Start)
+	l.Equal("uniform:0s-1s", cfg.Phases[0].AckDelay.String())
+
+	// a phase starts once the previous one has started all its clients, unless told otherwise
+	l.Equal(30*time.Second, cfg.Phases[1].Start)
+	l.Equal(loadgen.Constant(2*time.Second), cfg.Phases[1].DisconnectAfter)
+	l.Equal(5*time.Second, cfg.Phases[2].Start)
+}
+
+func (l *loadGenSuite) TestReadScenario_Invalid() {
+	tests := map[string]string{
+		"no phases":      "name: empty\n",
+		"unknown field":  "phases:\n  - clients: 1\n    colour: red\n",
+		"bad ramp":       "phases:\n  - clients: 1\n    ramp: sideways\n",
+		"bad ack delay":  "phases:\n  - clients: 1\n    ack_delay: soon\n",
+		"bad disconnect": "phases:\n  - clients: 1\n    disconnect_after: uniform:1s\n",
+	}
+
+	for name, file := range tests {
+		l.Run(name, func() {
+			scenario, err := loadgen.ReadScenario(strings.NewReader(file))
+			if err == nil {
+				_, err = scenario.Config()
+			}
+
+			l.Require().Error(err)
+		})
+	}
+}
+
+func (l *loadGenSuite) TestLoadScenario_Examples() {
+	files, err := filepath.Glob("../../scenarios/*.yaml")
+	l.Require().NoError(err)
+	l.Require().NotEmpty(files)
+
+	for _, file := range files {
+		scenario, err := loadgen.LoadScenario(file)
+		l.Require().NoError(err, file)
+
+		_, err = scenario.Config()
+		l.Require().NoError(err, file)
+	}
+}
@@ -3,3 +3,7 @@ package core
 import "errors"
 
 var ErrFailedToGetRelayer = errors.New("failed to get relayer")
+
+var ErrFailedToRelay = errors.New("failed to relay")
+
+var ErrTooManyRelayers = errors.New("too many relayers")
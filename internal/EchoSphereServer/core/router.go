@@ -11,4 +11,41 @@ type RelayRouter interface {
 	AcquireRelayer(ctx context.Context, ownerID string) (Messager, error)
 	AcquireRandomRelayer(ctx context.Context, excludeRelayer string) (OwnerID string, Relayer Messager, err error)
 	ReleaseRelayer(ctx context.Context, ownerID string, relayer Messager)
+	// Unregister withdraws the relayer of ownerID from the pool, a leased relayer is not put back on release.
+	Unregister(ctx context.Context, ownerID string) error
+	// PoolStats reports on the relay pool the router acquires from.
+	PoolStats(ctx context.Context) (PoolStats, error)
+}
+
+// PoolStats is a snapshot of a relay pool, the counters are totals since the RelayRouter was created.
+type PoolStats struct {
+	// Registered counts the relayers of the pool, acquired or not, Available those that can be acquired.
+	Registered    int64
+	Available     int64
+	Registrations uint64
+	Acquisitions  uint64
+	Releases      uint64
+}
+
+// Disconnecter is a Messager whose connection can be closed by the server.
+type Disconnecter interface {
+	Disconnect()
+}
+
+// AsDisconnecter finds the Disconnecter behind relayer, unwrapping the Messagers that wrap it.
+func AsDisconnecter(relayer Messager) (Disconnecter, bool) {
+	for relayer != nil {
+		if disconnecter, ok := relayer.(Disconnecter); ok {
+			return disconnecter, true
+		}
+
+		wrapper, ok := relayer.(interface{ Unwrap() Messager })
+		if !ok {
+			return nil, false
+		}
+
+		relayer = wrapper.Unwrap()
+	}
+
+	return nil, false
 }
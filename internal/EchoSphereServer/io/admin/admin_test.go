@@ -0,0 +1,229 @@
+package admin_test
+
+import (
+	"context"
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/admin"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/multiplexer"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeSender struct {
+	disconnected atomic.Bool
+}
+
+func (f *fakeSender) SendMsg(context.Context, any) error { return nil }
+
+func (f *fakeSender) Disconnect() { f.disconnected.Store(true) }
+
+type fakeDrainer struct {
+	draining atomic.Bool
+}
+
+func (f *fakeDrainer) Drain() { f.draining.Store(true) }
+
+func (f *fakeDrainer) Draining() bool { return f.draining.Load() }
+
+type adminSuite struct {
+	suite.Suite
+	cancel   context.CancelFunc
+	errGroup *errgroup.Group
+
+	useCases *usecase.UC
+	drainer  *fakeDrainer
+	client   v1.EchoSphereAdminServiceClient
+}
+
+func (a *adminSuite) SetupTest() {
+	ctx, cancel := context.WithCancel(context.Background())
+	a.cancel = cancel
+	a.errGroup = &errgroup.Group{}
+
+	mux := multiplexer.New()
+	a.useCases = usecase.New(usecase.Config{Router: mux})
+	a.drainer = &fakeDrainer{}
+
+	listener := bufconn.Listen(1024 * 1024)
+
+	server := admin.NewServer(admin.Config{
+		Listener: listener,
+		Router:   mux,
+		UseCases: a.useCases,
+		Drainer:  a.drainer,
+		Logger:   zap.NewNop(),
+	})
+
+	a.errGroup.Go(func() error { return server.Run(ctx) })
+	a.Require().Eventually(func() bool { return server.HealthCheck() == nil }, time.Second, time.Millisecond)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///admin.echosphere.io",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	a.Require().NoError(err)
+	a.T().Cleanup(func() { conn.Close() })
+
+	a.client = v1.NewEchoSphereAdminServiceClient(conn)
+}
+
+func (a *adminSuite) TearDownTest() {
+	a.cancel()
+	a.Require().NoError(a.errGroup.Wait())
+}
+
+func (a *adminSuite) connect(ownerID string) *fakeSender {
+	sender := &fakeSender{}
+
+	a.Require().NoError(a.useCases.RegisterHandler(context.Background(), usecase.RegisterCMD{OwnerID: ownerID, StreamSender: sender}))
+
+	return sender
+}
+
+func (a *adminSuite) TestListConnections() {
+	a.connect("owner-a")
+	a.connect("owner-b")
+
+	res, err := a.client.ListConnections(context.Background(), &v1.EchoSphereAdminServiceListConnectionsRequest{})
+	a.Require().NoError(err)
+	a.Require().Len(res.GetConnections(), 2)
+	a.Equal("owner-a", res.GetConnections()[0].GetOwnerId())
+	a.Positive(res.GetConnections()[0].GetStreamAge().AsDuration())
+}
+
+func (a *adminSuite) TestKick() {
+	ctx := context.Background()
+	sender := a.connect("owner-a")
+
+	_, err := a.client.Kick(ctx, &v1.EchoSphereAdminServiceKickRequest{OwnerId: "owner-a"})
+	a.Require().NoError(err)
+	a.True(sender.disconnected.Load())
+
+	res, err := a.client.ListConnections(ctx, &v1.EchoSphereAdminServiceListConnectionsRequest{})
+	a.Require().NoError(err)
+	a.Empty(res.GetConnections())
+
+	_, err = a.client.Kick(ctx, &v1.EchoSphereAdminServiceKickRequest{OwnerId: "owner-a"})
+	a.Equal(codes.NotFound, status.Code(err))
+}
+
+func (a *adminSuite) TestDrain() {
+	a.connect("owner-a")
+
+	res, err := a.client.Drain(context.Background(), &v1.EchoSphereAdminServiceDrainRequest{})
+	a.Require().NoError(err)
+	a.Equal(int64(1), res.GetConnections())
+	a.True(a.drainer.Draining())
+}
+
+func (a *adminSuite) TestStats() {
+	ctx := context.Background()
+
+	a.connect("owner-a")
+	a.connect("owner-b")
+	a.Require().NoError(a.useCases.RelayHandler(ctx, usecase.RelayCMD{From: "owner-a", Content: "hello"}))
+
+	stats, err := a.client.Stats(ctx, &v1.EchoSphereAdminServiceStatsRequest{})
+	a.Require().NoError(err)
+	a.Equal(int64(2), stats.GetConnections())
+	a.Equal(int64(2), stats.GetAvailableRelayers())
+	a.Equal(uint64(2), stats.GetAcquisitions())
+	a.Equal(uint64(2), stats.GetReleases())
+	a.Equal(int64(1), stats.GetPendingAcks())
+	a.False(stats.GetDraining())
+
+	pending, err := a.client.CountPending(ctx, &v1.EchoSphereAdminServiceCountPendingRequest{})
+	a.Require().NoError(err)
+	a.Equal(int64(1), pending.GetPendingAcks())
+}
+
+func (a *adminSuite) TestListPending() {
+	ctx := context.Background()
+
+	a.connect("owner-a")
+	a.connect("owner-b")
+	a.Require().NoError(a.useCases.RelayHandler(ctx, usecase.RelayCMD{From: "owner-a", Content: "hello"}))
+
+	res, err := a.client.ListPending(ctx, &v1.EchoSphereAdminServiceListPendingRequest{})
+	a.Require().NoError(err)
+	a.Require().Len(res.GetPending(), 1)
+	a.Equal("owner-a", res.GetPending()[0].GetOriginator())
+	a.Equal("owner-b", res.GetPending()[0].GetRecipient())
+	// only the hash of the content is listed by default
+	a.Empty(res.GetPending()[0].GetContent())
+	a.Equal(usecase.ContentHash("hello"), res.GetPending()[0].GetContentHash())
+}
+
+func (a *adminSuite) TestWatch() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	watch, err := a.client.Watch(ctx, &v1.EchoSphereAdminServiceWatchRequest{})
+	a.Require().NoError(err)
+
+	// the headers arrive once the server is subscribed
+	_, err = watch.Header()
+	a.Require().NoError(err)
+
+	a.connect("owner-a")
+
+	res, err := watch.Recv()
+	a.Require().NoError(err)
+	a.Equal(v1.EventType_EVENT_TYPE_REGISTERED, res.GetEvent().GetType())
+	a.Equal("owner-a", res.GetEvent().GetOwnerId())
+}
+
+func (a *adminSuite) TestWatch_Filtered() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	watch, err := a.client.Watch(ctx, &v1.EchoSphereAdminServiceWatchRequest{
+		OwnerIds: []string{"owner-b"},
+		Types:    []v1.EventType{v1.EventType_EVENT_TYPE_REGISTERED, v1.EventType_EVENT_TYPE_DROPPED},
+	})
+	a.Require().NoError(err)
+
+	_, err = watch.Header()
+	a.Require().NoError(err)
+
+	a.connect("owner-a")
+	a.connect("owner-b")
+	a.Require().Error(a.useCases.AckHandler(ctx, usecase.AckCMD{From: "owner-b", To: "owner-c", Content: "hello"}))
+
+	res, err := watch.Recv()
+	a.Require().NoError(err)
+	a.Equal(v1.EventType_EVENT_TYPE_REGISTERED, res.GetEvent().GetType())
+	a.Equal("owner-b", res.GetEvent().GetOwnerId())
+
+	res, err = watch.Recv()
+	a.Require().NoError(err)
+	a.Equal(v1.EventType_EVENT_TYPE_DROPPED, res.GetEvent().GetType())
+	a.Equal("owner-c", res.GetEvent().GetPeerId())
+	a.NotEmpty(res.GetEvent().GetContentHash())
+}
+
+func (a *adminSuite) TestWatch_UnknownType() {
+	watch, err := a.client.Watch(context.Background(), &v1.EchoSphereAdminServiceWatchRequest{
+		Types: []v1.EventType{v1.EventType(42)},
+	})
+	a.Require().NoError(err)
+
+	_, err = watch.Recv()
+	a.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func TestAdmin(t *testing.T) {
+	suite.Run(t, new(adminSuite))
+}
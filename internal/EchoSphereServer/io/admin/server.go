@@ -0,0 +1,95 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/core"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/audit"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"net"
+	"sync"
+)
+
+// Server serves the admin Service.
+type Server struct {
+	_ struct{}
+
+	listener   net.Listener
+	gRPCServer *grpc.Server
+
+	logger     *zap.Logger
+	serving    bool
+	servingMux sync.Mutex
+}
+
+// Config represents the configuration for creating a new admin Server.
+type Config struct {
+	Listener net.Listener
+	Router   core.RelayRouter
+	UseCases *usecase.UC
+	Drainer  Drainer
+	// Redactor selects how much of the pending message contents is listed, the zero Redactor lists only their hash.
+	Redactor audit.Redactor
+	Logger   *zap.Logger
+}
+
+// NewServer creates a new admin Server with the provided listener.
+func NewServer(cfg Config) *Server {
+	s := grpc.NewServer()
+
+	v1.RegisterEchoSphereAdminServiceServer(s, NewService(cfg.Router, cfg.UseCases, cfg.Drainer, cfg.Redactor))
+
+	reflection.Register(s)
+
+	return &Server{
+		listener:   cfg.Listener,
+		gRPCServer: s,
+		logger:     cfg.Logger,
+	}
+}
+
+// Run starts the admin Server and handles graceful shutdown.
+func (s *Server) Run(ctx context.Context) error {
+	g, ctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		s.servingMux.Lock()
+		s.serving = true
+		s.servingMux.Unlock()
+
+		s.logger.Info("Serving admin", zap.String("addr", s.listener.Addr().String()))
+
+		return s.gRPCServer.Serve(s.listener)
+	})
+
+	g.Go(func() error {
+		<-ctx.Done()
+
+		s.gRPCServer.Stop()
+
+		s.servingMux.Lock()
+		defer s.servingMux.Unlock()
+
+		s.serving = false
+
+		return nil
+	})
+
+	return g.Wait()
+}
+
+func (s *Server) HealthCheck() error {
+	s.servingMux.Lock()
+	defer s.servingMux.Unlock()
+
+	if !s.serving {
+		return fmt.Errorf("not serving admin")
+	}
+
+	return nil
+}
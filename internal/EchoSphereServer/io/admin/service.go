@@ -0,0 +1,215 @@
+// Package admin provides the gRPC service operators use to inspect and control a server.
+package admin
+
+import (
+	"context"
+	"errors"
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/core"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/audit"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+// watchBuffer is how many events a Watch stream can fall behind before it misses some.
+const watchBuffer = 256
+
+var eventTypes = map[usecase.EventType]v1.EventType{
+	usecase.EventRegistered:    v1.EventType_EVENT_TYPE_REGISTERED,
+	usecase.EventUnregistered:  v1.EventType_EVENT_TYPE_UNREGISTERED,
+	usecase.EventKicked:        v1.EventType_EVENT_TYPE_KICKED,
+	usecase.EventRelayed:       v1.EventType_EVENT_TYPE_RELAYED,
+	usecase.EventAckForwarded:  v1.EventType_EVENT_TYPE_ACK_FORWARDED,
+	usecase.EventNackForwarded: v1.EventType_EVENT_TYPE_NACK_FORWARDED,
+	usecase.EventDropped:       v1.EventType_EVENT_TYPE_DROPPED,
+}
+
+// Drainer stops a server from accepting new streams.
+type Drainer interface {
+	Drain()
+	Draining() bool
+}
+
+// Service implements the EchoSphereAdminService over the connections of this server.
+type Service struct {
+	v1.UnimplementedEchoSphereAdminServiceServer
+
+	router   core.RelayRouter
+	useCases *usecase.UC
+	drainer  Drainer
+	redactor audit.Redactor
+}
+
+// NewService creates a new Service, the pending message contents are listed as redactor keeps them.
+func NewService(router core.RelayRouter, useCases *usecase.UC, drainer Drainer, redactor audit.Redactor) *Service {
+	return &Service{router: router, useCases: useCases, drainer: drainer, redactor: redactor}
+}
+
+// ListConnections lists the clients connected to this server, oldest first.
+func (s *Service) ListConnections(
+	context.Context,
+	*v1.EchoSphereAdminServiceListConnectionsRequest,
+) (*v1.EchoSphereAdminServiceListConnectionsResponse, error) {
+	now := time.Now()
+	connections := s.useCases.Connections()
+
+	res := &v1.EchoSphereAdminServiceListConnectionsResponse{Connections: make([]*v1.Connection, 0, len(connections))}
+
+	for _, connection := range connections {
+		res.Connections = append(res.Connections, &v1.Connection{
+			OwnerId:     connection.OwnerID,
+			ConnectedAt: timestamppb.New(connection.ConnectedAt),
+			StreamAge:   durationpb.New(now.Sub(connection.ConnectedAt)),
+		})
+	}
+
+	return res, nil
+}
+
+// CountPending counts the relayed messages still waiting for their ack.
+func (s *Service) CountPending(
+	context.Context,
+	*v1.EchoSphereAdminServiceCountPendingRequest,
+) (*v1.EchoSphereAdminServiceCountPendingResponse, error) {
+	return &v1.EchoSphereAdminServiceCountPendingResponse{PendingAcks: s.useCases.PendingAcks()}, nil
+}
+
+// ListPending lists the relayed messages still waiting for their ack, oldest first, their contents redacted like in the
+// audit log.
+func (s *Service) ListPending(
+	context.Context,
+	*v1.EchoSphereAdminServiceListPendingRequest,
+) (*v1.EchoSphereAdminServiceListPendingResponse, error) {
+	pending := s.useCases.Pending()
+
+	res := &v1.EchoSphereAdminServiceListPendingResponse{Pending: make([]*v1.PendingMessage, 0, len(pending))}
+
+	for _, p := range pending {
+		content, truncated := s.redactor.Content(p.Content)
+
+		res.Pending = append(res.Pending, &v1.PendingMessage{
+			Originator:  p.Originator,
+			Recipient:   p.Recipient,
+			Content:     content,
+			RelayedAt:   timestamppb.New(p.RelayedAt),
+			ContentHash: usecase.ContentHash(p.Content),
+			Truncated:   truncated,
+		})
+	}
+
+	return res, nil
+}
+
+// Kick disconnects a client, its pending messages are nacked to their originators.
+func (s *Service) Kick(
+	ctx context.Context,
+	req *v1.EchoSphereAdminServiceKickRequest,
+) (*v1.EchoSphereAdminServiceKickResponse, error) {
+	if req.GetOwnerId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "owner id is required")
+	}
+
+	err := s.useCases.KickHandler(ctx, usecase.KickCMD{OwnerID: req.GetOwnerId()})
+
+	switch {
+	case errors.Is(err, core.ErrFailedToGetRelayer):
+		return nil, status.Error(codes.NotFound, err.Error())
+	// the client is gone either way, the nacks of its pending messages failed
+	case errors.Is(err, core.ErrFailedToRelay):
+		return &v1.EchoSphereAdminServiceKickResponse{}, nil
+	case err != nil:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &v1.EchoSphereAdminServiceKickResponse{}, nil
+}
+
+// Drain stops the server from accepting new streams and returns how many connections are left.
+func (s *Service) Drain(
+	context.Context,
+	*v1.EchoSphereAdminServiceDrainRequest,
+) (*v1.EchoSphereAdminServiceDrainResponse, error) {
+	s.drainer.Drain()
+
+	return &v1.EchoSphereAdminServiceDrainResponse{Connections: int64(len(s.useCases.Connections()))}, nil
+}
+
+// Watch streams the lifecycle events matching the request until the caller goes away,
+// events are dropped if it falls behind.
+func (s *Service) Watch(
+	req *v1.EchoSphereAdminServiceWatchRequest,
+	stream v1.EchoSphereAdminService_WatchServer,
+) error {
+	filter := usecase.EventFilter{OwnerIDs: req.GetOwnerIds()}
+
+	for _, t := range req.GetTypes() {
+		for eventType, v1Type := range eventTypes {
+			if v1Type == t {
+				filter.Types = append(filter.Types, eventType)
+			}
+		}
+	}
+
+	// only unknown types were asked for, nothing can match
+	if len(req.GetTypes()) > 0 && len(filter.Types) == 0 {
+		return status.Error(codes.InvalidArgument, "no known event type requested")
+	}
+
+	events, unsubscribe := s.useCases.Watch(watchBuffer, filter)
+	defer unsubscribe()
+
+	// the headers tell the caller it is subscribed
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			err := stream.Send(&v1.EchoSphereAdminServiceWatchResponse{Event: &v1.Event{
+				Type:        eventTypes[event.Type],
+				OwnerId:     event.OwnerID,
+				PeerId:      event.PeerID,
+				ContentHash: event.ContentHash,
+				Reason:      event.Reason,
+				At:          timestamppb.New(event.At),
+			}})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Stats returns a snapshot of the relay pool and the use cases.
+func (s *Service) Stats(
+	ctx context.Context,
+	_ *v1.EchoSphereAdminServiceStatsRequest,
+) (*v1.EchoSphereAdminServiceStatsResponse, error) {
+	stats, err := s.router.PoolStats(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	return &v1.EchoSphereAdminServiceStatsResponse{
+		Connections:       int64(len(s.useCases.Connections())),
+		AvailableRelayers: stats.Available,
+		Registrations:     stats.Registrations,
+		Acquisitions:      stats.Acquisitions,
+		Releases:          stats.Releases,
+		PendingAcks:       s.useCases.PendingAcks(),
+		QueueDepth:        s.useCases.QueueDepth(),
+		Draining:          s.drainer.Draining(),
+	}, nil
+}
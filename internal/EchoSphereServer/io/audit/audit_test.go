@@ -0,0 +1,195 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/audit"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type auditSuite struct {
+	suite.Suite
+
+	path string
+}
+
+func (a *auditSuite) SetupTest() {
+	a.path = filepath.Join(a.T().TempDir(), "audit.jsonl")
+}
+
+func (a *auditSuite) newSink(cfg audit.Config) *audit.Sink {
+	cfg.Path, cfg.Logger = a.path, zap.NewNop()
+
+	sink, err := audit.NewSink(cfg)
+	a.Require().NoError(err)
+
+	return sink
+}
+
+func (a *auditSuite) replay() []audit.Record {
+	var records []audit.Record
+
+	a.Require().NoError(audit.Replay(a.path, func(record audit.Record) error {
+		records = append(records, record)
+
+		return nil
+	}))
+
+	return records
+}
+
+func (a *auditSuite) TestReplay() {
+	bus := usecase.NewBus()
+	sink := a.newSink(audit.Config{Redaction: audit.RedactHash})
+	defer bus.Subscribe(usecase.EventFilter{}, sink.Handle)()
+
+	ctx := context.Background()
+	bus.Publish(ctx, usecase.Event{Type: usecase.EventRegistered, OwnerID: "a"})
+	bus.Publish(ctx, usecase.Event{Type: usecase.EventRelayed, OwnerID: "a", PeerID: "b", Content: "secret"})
+	bus.Publish(ctx, usecase.Event{Type: usecase.EventDropped, OwnerID: "b", PeerID: "c", Content: "secret", Reason: "gone"})
+	a.Require().NoError(sink.Close())
+
+	records := a.replay()
+	a.Require().Len(records, 3)
+	a.Equal("registered", records[0].Type)
+	a.Equal("relayed", records[1].Type)
+	a.Equal("b", records[1].PeerID)
+	a.Empty(records[1].Content)
+	a.NotEmpty(records[1].ContentHash)
+	a.Equal("dropped", records[2].Type)
+	a.Equal("gone", records[2].Reason)
+
+	raw, err := os.ReadFile(a.path)
+	a.Require().NoError(err)
+	a.NotContains(string(raw), "secret")
+}
+
+func (a *auditSuite) TestRedaction() {
+	tests := map[audit.Redaction]struct {
+		content   string
+		truncated bool
+	}{
+		audit.RedactNone:     {content: "0123456789"},
+		audit.RedactHash:     {content: ""},
+		audit.RedactTruncate: {content: "0123", truncated: true},
+	}
+
+	for redaction, want := range tests {
+		a.Run(string(redaction), func() {
+			a.SetupTest()
+
+			sink := a.newSink(audit.Config{Redaction: redaction, TruncateAt: 4})
+			sink.Handle(context.Background(), usecase.Event{Type: usecase.EventRelayed, OwnerID: "a", Content: "0123456789"})
+			a.Require().NoError(sink.Close())
+
+			records := a.replay()
+			a.Require().Len(records, 1)
+			a.Equal(want.content, records[0].Content)
+			a.Equal(want.truncated, records[0].Truncated)
+		})
+	}
+}
+
+func (a *auditSuite) TestNewSink_UnknownRedaction() {
+	_, err := audit.NewSink(audit.Config{Path: a.path, Redaction: "shred"})
+	a.Require().Error(err)
+}
+
+func (a *auditSuite) TestRotation() {
+	sink := a.newSink(audit.Config{MaxSize: 200, MaxBackups: 2})
+
+	for i := range 20 {
+		sink.Handle(context.Background(), usecase.Event{Type: usecase.EventRegistered, OwnerID: strings.Repeat("x", i%3+1)})
+	}
+
+	a.Require().NoError(sink.Close())
+
+	for _, name := range []string{a.path, a.path + ".1", a.path + ".2"} {
+		info, err := os.Stat(name)
+		a.Require().NoError(err)
+		a.LessOrEqual(info.Size(), int64(200))
+	}
+
+	_, err := os.Stat(a.path + ".3")
+	a.True(os.IsNotExist(err))
+
+	// the oldest lines were rotated out, the replay keeps the order of the ones left
+	records := a.replay()
+	a.Less(len(records), 20)
+	a.Equal(strings.Repeat("x", 19%3+1), records[len(records)-1].OwnerID)
+}
+
+func (a *auditSuite) TestRotation_WholeRecords() {
+	// the records are longer than the buffer of the writer, which flushes them in chunks
+	sink := a.newSink(audit.Config{MaxSize: 10_000, MaxBackups: 3, Redaction: audit.RedactNone})
+
+	for i := range 12 {
+		sink.Handle(context.Background(), usecase.Event{
+			Type:    usecase.EventRelayed,
+			OwnerID: strconv.Itoa(i),
+			Content: strings.Repeat("x", 3000),
+		})
+	}
+
+	a.Require().NoError(sink.Close())
+
+	a.FileExists(a.path + ".3")
+
+	records := a.replay()
+	a.Require().Len(records, 12)
+
+	for i, record := range records {
+		a.Equal(strconv.Itoa(i), record.OwnerID)
+		a.Len(record.Content, 3000)
+	}
+}
+
+func (a *auditSuite) TestRun_Flushes() {
+	ctx, cancel := context.WithCancel(context.Background())
+	sink := a.newSink(audit.Config{FlushInterval: time.Millisecond})
+
+	done := make(chan error, 1)
+
+	go func() { done <- sink.Run(ctx) }()
+
+	sink.Handle(ctx, usecase.Event{Type: usecase.EventRegistered, OwnerID: "a"})
+
+	a.Require().Eventually(func() bool {
+		raw, err := os.ReadFile(a.path)
+
+		return err == nil && strings.Contains(string(raw), `"owner_id":"a"`)
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	a.Require().NoError(<-done)
+}
+
+func (a *auditSuite) TestReplay_StopsOnHandlerError() {
+	sink := a.newSink(audit.Config{})
+	sink.Handle(context.Background(), usecase.Event{Type: usecase.EventRegistered, OwnerID: "a"})
+	sink.Handle(context.Background(), usecase.Event{Type: usecase.EventRegistered, OwnerID: "b"})
+	a.Require().NoError(sink.Close())
+
+	stop := errors.New("stop")
+	calls := 0
+
+	err := audit.Replay(a.path, func(audit.Record) error {
+		calls++
+
+		return stop
+	})
+	a.Require().ErrorIs(err, stop)
+	a.Equal(1, calls)
+}
+
+func TestAudit(t *testing.T) {
+	suite.Run(t, new(auditSuite))
+}
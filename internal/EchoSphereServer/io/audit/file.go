@@ -0,0 +1,94 @@
+package audit
+
+import (
+	"fmt"
+	"os"
+)
+
+// rotatingFile appends to path and, when rotated, moves it to path.1, shifting the older backups up to
+// path.<maxBackups> and deleting the oldest. The writer rotates it between records, see full.
+type rotatingFile struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	file *os.File
+	size int64
+}
+
+func openRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	f := &rotatingFile{path: path, maxSize: maxSize, maxBackups: maxBackups}
+
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// full reports whether a record of n bytes would grow the file past maxSize once the buffered bytes are written.
+// A record bigger than a whole file still gets written, to a file of its own.
+func (f *rotatingFile) full(buffered, n int64) bool {
+	used := f.size + buffered
+
+	return f.maxSize > 0 && used > 0 && used+n > f.maxSize
+}
+
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+
+	return n, err
+}
+
+func (f *rotatingFile) Close() error {
+	return f.file.Close()
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+
+		return err
+	}
+
+	f.file, f.size = file, info.Size()
+
+	return nil
+}
+
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+
+	if f.maxBackups > 0 {
+		if err := os.Remove(backupPath(f.path, f.maxBackups)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		for n := f.maxBackups - 1; n > 0; n-- {
+			if err := os.Rename(backupPath(f.path, n), backupPath(f.path, n+1)); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+
+		if err := os.Rename(f.path, backupPath(f.path, 1)); err != nil {
+			return err
+		}
+	} else if err := os.Remove(f.path); err != nil {
+		return err
+	}
+
+	return f.open()
+}
+
+func backupPath(path string, n int) string {
+	return fmt.Sprintf("%s.%d", path, n)
+}
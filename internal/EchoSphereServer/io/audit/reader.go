@@ -0,0 +1,95 @@
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Reader reads the records of an audit log.
+type Reader struct {
+	decoder *json.Decoder
+}
+
+// NewReader creates a new Reader over r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{decoder: json.NewDecoder(r)}
+}
+
+// Next returns the next record, or io.EOF once there are no more.
+func (r *Reader) Next() (Record, error) {
+	var record Record
+
+	if err := r.decoder.Decode(&record); err != nil {
+		if errors.Is(err, io.EOF) {
+			return Record{}, io.EOF
+		}
+
+		return Record{}, fmt.Errorf("failed to read audit record: %w", err)
+	}
+
+	return record, nil
+}
+
+// Replay calls handle with every record of the audit log at path, the rotated files first, oldest first,
+// until handle returns an error.
+func Replay(path string, handle func(Record) error) error {
+	files, err := logFiles(path)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range files {
+		if err := replayFile(name, handle); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// logFiles returns the files of the audit log at path, oldest first.
+func logFiles(path string) ([]string, error) {
+	var backups []string
+
+	for n := 1; ; n++ {
+		if _, err := os.Stat(backupPath(path, n)); err != nil {
+			if os.IsNotExist(err) {
+				break
+			}
+
+			return nil, err
+		}
+
+		backups = append([]string{backupPath(path, n)}, backups...)
+	}
+
+	return append(backups, path), nil
+}
+
+func replayFile(name string, handle func(Record) error) error {
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := NewReader(file)
+
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		if err := handle(record); err != nil {
+			return err
+		}
+	}
+}
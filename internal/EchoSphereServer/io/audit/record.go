@@ -0,0 +1,85 @@
+// Package audit keeps an append-only record of the lifecycle events of the server, one JSON line per event,
+// in a file rotated by size, and replays it for offline analysis.
+package audit
+
+import (
+	"fmt"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
+	"time"
+)
+
+// Redaction selects how much of the message contents reaches the audit log.
+type Redaction string
+
+const (
+	// RedactNone keeps the whole content.
+	RedactNone Redaction = "none"
+	// RedactHash keeps only the content hash.
+	RedactHash Redaction = "hash"
+	// RedactTruncate keeps the content hash and the first bytes of the content.
+	RedactTruncate Redaction = "truncate"
+)
+
+// Record is one line of the audit log.
+type Record struct {
+	At          time.Time `json:"at"`
+	Type        string    `json:"type"`
+	OwnerID     string    `json:"owner_id"`
+	PeerID      string    `json:"peer_id,omitempty"`
+	Content     string    `json:"content,omitempty"`
+	ContentHash string    `json:"content_hash,omitempty"`
+	Reason      string    `json:"reason,omitempty"`
+	// Truncated tells the Content was cut by RedactTruncate.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Redactor applies a Redaction to the message contents, the zero Redactor keeps only their hash.
+type Redactor struct {
+	redaction  Redaction
+	truncateAt int
+}
+
+// NewRedactor creates a Redactor, TruncateAt is the number of content bytes kept by RedactTruncate.
+func NewRedactor(redaction Redaction, truncateAt int) (Redactor, error) {
+	switch redaction {
+	case RedactNone, RedactHash, RedactTruncate:
+	case "":
+		redaction = RedactHash
+	default:
+		return Redactor{}, fmt.Errorf("unknown redaction %q", redaction)
+	}
+
+	return Redactor{redaction: redaction, truncateAt: truncateAt}, nil
+}
+
+// Content returns what the Redaction keeps of content and whether it was cut.
+func (r Redactor) Content(content string) (string, bool) {
+	switch r.redaction {
+	case RedactNone:
+		return content, false
+	case RedactTruncate:
+		if len(content) > r.truncateAt {
+			return content[:r.truncateAt], true
+		}
+
+		return content, false
+	case RedactHash:
+	}
+
+	return "", false
+}
+
+func (r Redactor) record(event usecase.Event) Record {
+	record := Record{
+		At:          event.At.UTC(),
+		Type:        event.Type.String(),
+		OwnerID:     event.OwnerID,
+		PeerID:      event.PeerID,
+		ContentHash: event.ContentHash,
+		Reason:      event.Reason,
+	}
+
+	record.Content, record.Truncated = r.Content(event.Content)
+
+	return record
+}
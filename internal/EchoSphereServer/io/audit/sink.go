@@ -0,0 +1,138 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+)
+
+// Sink writes the events it handles to the audit log.
+//
+// It is meant to be a synchronous subscriber of the usecase.Bus, so no event is lost to a full buffer and the lines
+// keep the order of the events. The lines are buffered in memory and flushed by Run.
+type Sink struct {
+	redactor      Redactor
+	flushInterval time.Duration
+	logger        *zap.Logger
+
+	mu     sync.Mutex
+	file   *rotatingFile
+	writer *bufio.Writer
+}
+
+// Config represents the configuration for creating a new Sink.
+type Config struct {
+	Path string
+	// MaxSize is the size in bytes a file is rotated at, 0 never rotates it.
+	MaxSize int64
+	// MaxBackups is the number of rotated files kept next to Path.
+	MaxBackups int
+	Redaction  Redaction
+	// TruncateAt is the number of content bytes kept by RedactTruncate.
+	TruncateAt int
+	// FlushInterval is how often the buffered lines are written to the file, every second if 0.
+	FlushInterval time.Duration
+	Logger        *zap.Logger
+}
+
+// NewSink creates a new Sink appending to the file at cfg.Path.
+func NewSink(cfg Config) (*Sink, error) {
+	redactor, err := NewRedactor(cfg.Redaction, cfg.TruncateAt)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := openRotatingFile(cfg.Path, cfg.MaxSize, cfg.MaxBackups)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = time.Second
+	}
+
+	return &Sink{
+		redactor:      redactor,
+		flushInterval: cfg.FlushInterval,
+		logger:        cfg.Logger,
+		file:          file,
+		writer:        bufio.NewWriter(file),
+	}, nil
+}
+
+// Handle writes one line for the event, it has the signature of a usecase.EventHandler.
+func (s *Sink) Handle(_ context.Context, event usecase.Event) {
+	line, err := json.Marshal(s.redactor.record(event))
+	if err != nil {
+		s.logger.Error("Failed to marshal audit record", zap.Error(err))
+
+		return
+	}
+
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the buffer is written in chunks, the file is rotated before a record so none is split between two files
+	if s.file.full(int64(s.writer.Buffered()), int64(len(line))) {
+		if err := s.rotate(); err != nil {
+			s.logger.Error("Failed to rotate audit log", zap.Error(err))
+		}
+	}
+
+	if _, err := s.writer.Write(line); err != nil {
+		s.logger.Error("Failed to write audit record", zap.Error(err))
+	}
+}
+
+// Run flushes the buffered lines every flush interval until the context is done. The Sink must be unsubscribed
+// before it is closed, the events handled afterwards are lost.
+func (s *Sink) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.Flush(); err != nil {
+				s.logger.Error("Failed to flush audit log", zap.Error(err))
+			}
+		case <-ctx.Done():
+			return s.Flush()
+		}
+	}
+}
+
+// Flush writes the buffered lines to the file.
+func (s *Sink) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.writer.Flush()
+}
+
+func (s *Sink) rotate() error {
+	if err := s.writer.Flush(); err != nil {
+		return err
+	}
+
+	return s.file.rotate()
+}
+
+// Close flushes the buffered lines and closes the file, the lines handled afterwards are lost.
+func (s *Sink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := s.writer.Flush()
+	if closeErr := s.file.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
@@ -0,0 +1,308 @@
+package federation_test
+
+import (
+	"context"
+	"github.com/google/uuid"
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/core"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/federation"
+	essGRPC "github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/gRPC"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/multiplexer"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+type mockRelayer struct {
+	mu       sync.Mutex
+	messages []any
+}
+
+func (m *mockRelayer) SendMsg(_ context.Context, msg any) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = append(m.messages, msg)
+
+	return nil
+}
+
+func (m *mockRelayer) received() []any {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.messages
+}
+
+// node is an in-process server of the federation listening on ephemeral ports.
+type node struct {
+	local        *multiplexer.Multiplexer
+	router       *federation.Router
+	transmitAddr string
+}
+
+type federationSuite struct {
+	suite.Suite
+	cancel   context.CancelFunc
+	errGroup *errgroup.Group
+
+	nodeA, nodeB *node
+}
+
+func (f *federationSuite) SetupTest() {
+	ctx, cancel := context.WithCancel(context.Background())
+	f.cancel = cancel
+	f.errGroup = &errgroup.Group{}
+
+	nodes := f.startNodes(ctx, 2)
+	f.nodeA, f.nodeB = nodes[0], nodes[1]
+}
+
+func (f *federationSuite) TearDownTest() {
+	f.cancel()
+	f.Require().NoError(f.errGroup.Wait())
+
+	f.Require().NoError(f.nodeA.router.Close())
+	f.Require().NoError(f.nodeB.router.Close())
+}
+
+func (f *federationSuite) startNodes(ctx context.Context, n int) []*node {
+	fedListeners := make([]net.Listener, n)
+	addrs := make([]string, n)
+
+	for i := range fedListeners {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		f.Require().NoError(err)
+
+		fedListeners[i], addrs[i] = listener, listener.Addr().String()
+	}
+
+	nodes := make([]*node, n)
+
+	for i := range nodes {
+		local := multiplexer.New()
+
+		peers, err := federation.Dial(append(append([]string{}, addrs[:i]...), addrs[i+1:]...))
+		f.Require().NoError(err)
+
+		router := federation.NewRouter(local, peers)
+
+		transmitListener, err := net.Listen("tcp", "127.0.0.1:0")
+		f.Require().NoError(err)
+
+		fedServer := federation.NewServer(federation.Config{Listener: fedListeners[i], Local: local, Logger: zap.NewNop()})
+		transmitServer := essGRPC.NewServer(essGRPC.Config{
+			Listener: transmitListener,
+			Router:   router,
+			Logger:   zap.NewNop(),
+			UseCases: usecase.New(usecase.Config{Router: router}),
+		})
+
+		f.errGroup.Go(func() error { return fedServer.Run(ctx) })
+		f.errGroup.Go(func() error { return transmitServer.Run(ctx) })
+
+		f.Require().Eventually(func() bool { return fedServer.HealthCheck() == nil }, time.Second, time.Millisecond)
+
+		nodes[i] = &node{local: local, router: router, transmitAddr: transmitListener.Addr().String()}
+	}
+
+	return nodes
+}
+
+func (f *federationSuite) TestAcquireRandomRelayer_FromPeer() {
+	ctx := context.Background()
+
+	f.nodeA.router.Register(ctx, "owner-a", &mockRelayer{})
+
+	remote := &mockRelayer{}
+	f.nodeB.router.Register(ctx, "owner-b", remote)
+
+	ownerID, relayer, err := f.nodeA.router.AcquireRandomRelayer(ctx, "owner-a")
+	f.Require().NoError(err)
+	f.Require().Equal("owner-b", ownerID)
+
+	frame := &v1.EchoSphereTransmissionServiceTransmitResponse{
+		OutgoingData: &v1.EchoSphereTransmissionServiceTransmitResponse_Message{
+			Message: &v1.Message{From: "owner-a", Content: "hello"},
+		},
+	}
+
+	f.Require().NoError(relayer.SendMsg(ctx, frame))
+	f.Require().Len(remote.received(), 1)
+	f.Equal("hello", remote.received()[0].(*v1.EchoSphereTransmissionServiceTransmitResponse).GetMessage().GetContent())
+
+	// while leased, the relayer is not available on its node
+	_, err = f.nodeB.local.AcquireRelayer(ctx, "owner-b")
+	f.Require().Error(err)
+
+	f.nodeA.router.ReleaseRelayer(ctx, ownerID, relayer)
+
+	released, err := f.nodeB.local.AcquireRelayer(ctx, "owner-b")
+	f.Require().NoError(err)
+	f.Equal(remote, released)
+}
+
+func (f *federationSuite) TestReleaseRelayer_Unregistered() {
+	ctx := context.Background()
+
+	f.nodeB.router.Register(ctx, "owner-b", &mockRelayer{})
+
+	relayer, err := f.nodeA.router.AcquireRelayer(ctx, "owner-b")
+	f.Require().NoError(err)
+
+	// the connection closes on its node while a peer holds its relayer
+	f.Require().NoError(f.nodeB.router.Unregister(ctx, "owner-b"))
+
+	f.nodeA.router.ReleaseRelayer(ctx, "owner-b", relayer)
+
+	_, err = f.nodeB.local.AcquireRelayer(ctx, "owner-b")
+	f.Require().ErrorIs(err, core.ErrFailedToGetRelayer)
+}
+
+func (f *federationSuite) TestLease_Expires() {
+	ctx := context.Background()
+
+	local := multiplexer.New()
+	relayer := &mockRelayer{}
+	local.Register(ctx, "owner-b", relayer)
+
+	service := federation.NewService(local, 10*time.Millisecond)
+
+	// the peer leases the relayer and never releases it, its acquire having failed on its side
+	_, err := service.AcquireRelayer(ctx, &v1.EchoSphereFederationServiceAcquireRelayerRequest{OwnerId: "owner-b"})
+	f.Require().NoError(err)
+
+	_, err = local.AcquireRelayer(ctx, "owner-b")
+	f.Require().ErrorIs(err, core.ErrFailedToGetRelayer)
+
+	f.Require().Eventually(func() bool {
+		released, err := local.AcquireRelayer(ctx, "owner-b")
+		if err != nil {
+			return false
+		}
+
+		local.ReleaseRelayer(ctx, "owner-b", released)
+
+		return released == relayer
+	}, time.Second, time.Millisecond)
+
+	// a late release does not return the relayer a second time
+	_, err = service.ReleaseRelayer(ctx, &v1.EchoSphereFederationServiceReleaseRelayerRequest{OwnerId: "owner-b"})
+	f.Require().Equal(codes.FailedPrecondition, status.Code(err))
+}
+
+func (f *federationSuite) TestAcquireRandomRelayer_NoRelayers() {
+	ctx := context.Background()
+
+	f.nodeA.router.Register(ctx, "owner-a", &mockRelayer{})
+
+	_, relayer, err := f.nodeA.router.AcquireRandomRelayer(ctx, "owner-a")
+	f.Require().Error(err)
+	f.IsType(multiplexer.NoopRelayer{}, relayer)
+}
+
+func (f *federationSuite) TestAcquireRelayer_FromOwnerNode() {
+	ctx := context.Background()
+
+	originator := &mockRelayer{}
+	f.nodeB.router.Register(ctx, "owner-b", originator)
+
+	relayer, err := f.nodeA.router.AcquireRelayer(ctx, "owner-b")
+	f.Require().NoError(err)
+
+	ack := &v1.EchoSphereTransmissionServiceTransmitResponse{
+		OutgoingData: &v1.EchoSphereTransmissionServiceTransmitResponse_Ack{
+			Ack: &v1.Ack{From: "owner-a", To: "owner-b", Content: "hello"},
+		},
+	}
+
+	f.Require().NoError(relayer.SendMsg(ctx, ack))
+	f.Require().Len(originator.received(), 1)
+
+	f.nodeA.router.ReleaseRelayer(ctx, "owner-b", relayer)
+}
+
+func (f *federationSuite) TestAcquireRelayer_NotFound() {
+	_, err := f.nodeA.router.AcquireRelayer(context.Background(), "nobody")
+	f.Require().Error(err)
+}
+
+// TestRelayAcrossNodes:
+//
+//	Scenario: Clients attached to different nodes exchange message X and ok X
+//	  Given two federated servers
+//	  And client B is connected to the second server
+//	  When client A sends "message X" to the first server
+//	  Then client B receives "message X"
+//	  And when client B replies "ok X" the first server forwards it to client A
+func (f *federationSuite) TestRelayAcrossNodes() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	streamA := f.transmit(ctx, f.nodeA.transmitAddr)
+	streamB := f.transmit(ctx, f.nodeB.transmitAddr)
+
+	clientA, clientB := uuid.Must(uuid.NewV7()).String(), uuid.Must(uuid.NewV7()).String()
+
+	// client B registers, there is nobody else, so it only gets its own echo
+	f.Require().NoError(streamB.Send(newMessage(clientB, "y")))
+
+	echo, err := streamB.Recv()
+	f.Require().NoError(err)
+	f.Equal(clientB, echo.GetMessage().GetFrom())
+
+	f.Require().NoError(streamA.Send(newMessage(clientA, "x")))
+
+	echo, err = streamA.Recv()
+	f.Require().NoError(err)
+	f.Equal(clientA, echo.GetMessage().GetFrom())
+
+	relayed, err := streamB.Recv()
+	f.Require().NoError(err)
+	f.Equal(clientA, relayed.GetMessage().GetFrom())
+	f.Equal("x", relayed.GetMessage().GetContent())
+
+	f.Require().NoError(streamB.Send(&v1.EchoSphereTransmissionServiceTransmitRequest{
+		IncomingData: &v1.EchoSphereTransmissionServiceTransmitRequest_Ack{
+			Ack: &v1.Ack{From: clientB, To: clientA, Content: "x"},
+		},
+	}))
+
+	ack, err := streamA.Recv()
+	f.Require().NoError(err)
+	f.Equal(clientB, ack.GetAck().GetFrom())
+	f.Equal("x", ack.GetAck().GetContent())
+}
+
+func (f *federationSuite) transmit(ctx context.Context, addr string) v1.EchoSphereTransmissionService_TransmitClient {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	f.Require().NoError(err)
+
+	f.T().Cleanup(func() { _ = conn.Close() })
+
+	stream, err := v1.NewEchoSphereTransmissionServiceClient(conn).Transmit(ctx)
+	f.Require().NoError(err)
+
+	return stream
+}
+
+func newMessage(from, content string) *v1.EchoSphereTransmissionServiceTransmitRequest {
+	return &v1.EchoSphereTransmissionServiceTransmitRequest{
+		IncomingData: &v1.EchoSphereTransmissionServiceTransmitRequest_Message{
+			Message: &v1.Message{From: from, Content: content},
+		},
+	}
+}
+
+func TestFederation(t *testing.T) {
+	suite.Run(t, new(federationSuite))
+}
@@ -0,0 +1,186 @@
+// Package federation lets several EchoSphere servers share one relay pool by peering over an internal gRPC service.
+package federation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/core"
+	"golang.org/x/exp/rand"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// releaseTimeout bounds the release of a remote relayer, the peer expires the lease if the release does not get there.
+const releaseTimeout = 5 * time.Second
+
+// Peer is another node of the federation.
+type Peer struct {
+	Addr   string
+	conn   *grpc.ClientConn
+	client v1.EchoSphereFederationServiceClient
+}
+
+// Dial creates the peers for the given addresses.
+// Connections are established lazily, so unreachable peers do not prevent the node from starting.
+func Dial(addrs []string, opts ...grpc.DialOption) ([]Peer, error) {
+	opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+
+	peers := make([]Peer, 0, len(addrs))
+
+	for _, addr := range addrs {
+		conn, err := grpc.NewClient(addr, opts...)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("dialing peer %s: %w", addr, err), closePeers(peers))
+		}
+
+		peers = append(peers, Peer{Addr: addr, conn: conn, client: v1.NewEchoSphereFederationServiceClient(conn)})
+	}
+
+	return peers, nil
+}
+
+func closePeers(peers []Peer) error {
+	var err error
+
+	for _, peer := range peers {
+		if peer.conn != nil {
+			err = errors.Join(err, peer.conn.Close())
+		}
+	}
+
+	return err
+}
+
+// Router is a core.RelayRouter that routes through the local relay pool and the pools of its peers.
+type Router struct {
+	local core.RelayRouter
+	peers []Peer
+}
+
+// NewRouter creates a new Router over the local relay pool and the given peers.
+func NewRouter(local core.RelayRouter, peers []Peer) *Router {
+	return &Router{local: local, peers: peers}
+}
+
+// Register registers the relayer in the local relay pool, connections always belong to the node they are attached to.
+func (r *Router) Register(ctx context.Context, ownerID string, relayer core.Messager) {
+	r.local.Register(ctx, ownerID, relayer)
+}
+
+// AcquireRelayer acquires the relayer of ownerID from the local pool or, if it is not there, from the peer owning it.
+func (r *Router) AcquireRelayer(ctx context.Context, ownerID string) (core.Messager, error) { //nolint:ireturn
+	relayer, err := r.local.AcquireRelayer(ctx, ownerID)
+	if err == nil || !errors.Is(err, core.ErrFailedToGetRelayer) {
+		return relayer, err
+	}
+
+	errs := []error{err}
+
+	for _, peer := range r.peers {
+		_, pErr := peer.client.AcquireRelayer(ctx, &v1.EchoSphereFederationServiceAcquireRelayerRequest{OwnerId: ownerID})
+		if pErr == nil {
+			return &remoteRelayer{peer: peer, ownerID: ownerID}, nil
+		}
+
+		if status.Code(pErr) != codes.NotFound {
+			errs = append(errs, fmt.Errorf("peer %s: %w", peer.Addr, pErr))
+		}
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+// AcquireRandomRelayer acquires a random relayer, excluding the specified one, from any node of the federation.
+// Nodes are tried in random order, so every node is equally likely to be chosen regardless of its pool size.
+// When no node has a relayer available it returns the local pool's no-op result.
+func (r *Router) AcquireRandomRelayer(ctx context.Context, excludeRelayer string) (string, core.Messager, error) { //nolint:ireturn
+	var (
+		ownerID      string
+		localRelayer core.Messager
+		localErr     error
+	)
+
+	for _, i := range rand.Perm(len(r.peers) + 1) {
+		if i == len(r.peers) {
+			ownerID, localRelayer, localErr = r.local.AcquireRandomRelayer(ctx, excludeRelayer)
+			if localErr == nil || !errors.Is(localErr, core.ErrFailedToGetRelayer) {
+				return ownerID, localRelayer, localErr
+			}
+
+			continue
+		}
+
+		peer := r.peers[i]
+
+		res, err := peer.client.AcquireRandomRelayer(
+			ctx,
+			&v1.EchoSphereFederationServiceAcquireRandomRelayerRequest{ExcludeOwnerId: excludeRelayer},
+		)
+		// a peer that leased the relayer before the call failed expires the lease
+		if err != nil {
+			continue
+		}
+
+		return res.GetOwnerId(), &remoteRelayer{peer: peer, ownerID: res.GetOwnerId()}, nil
+	}
+
+	return ownerID, localRelayer, localErr
+}
+
+// ReleaseRelayer releases the relayer back to the node it was acquired from.
+// Remote releases outlive the cancellation of the caller's context, otherwise a closing stream would leave the relayer
+// leased on the peer until the lease expires, but not releaseTimeout.
+func (r *Router) ReleaseRelayer(ctx context.Context, ownerID string, relayer core.Messager) {
+	remote, ok := relayer.(*remoteRelayer)
+	if !ok {
+		r.local.ReleaseRelayer(ctx, ownerID, relayer)
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), releaseTimeout)
+	defer cancel()
+
+	_, _ = remote.peer.client.ReleaseRelayer( //nolint:errcheck
+		ctx,
+		&v1.EchoSphereFederationServiceReleaseRelayerRequest{OwnerId: ownerID},
+	)
+}
+
+// Unregister withdraws the relayer from the local relay pool, where the connection is registered.
+func (r *Router) Unregister(ctx context.Context, ownerID string) error {
+	return r.local.Unregister(ctx, ownerID)
+}
+
+// PoolStats reports on the local relay pool, the pools of the peers are not included.
+func (r *Router) PoolStats(ctx context.Context) (core.PoolStats, error) {
+	return r.local.PoolStats(ctx)
+}
+
+// Close closes the connections to the peers.
+func (r *Router) Close() error {
+	return closePeers(r.peers)
+}
+
+// remoteRelayer is a relayer leased from a peer, frames are delivered through the peer.
+type remoteRelayer struct {
+	peer    Peer
+	ownerID string
+}
+
+// SendMsg delivers the frame to the leased relayer through the peer.
+func (r *remoteRelayer) SendMsg(ctx context.Context, m any) error {
+	frame, ok := m.(*v1.EchoSphereTransmissionServiceTransmitResponse)
+	if !ok {
+		return fmt.Errorf("unsupported frame %T", m)
+	}
+
+	_, err := r.peer.client.Deliver(ctx, &v1.EchoSphereFederationServiceDeliverRequest{OwnerId: r.ownerID, Frame: frame})
+
+	return err
+}
@@ -0,0 +1,91 @@
+package federation
+
+import (
+	"context"
+	"fmt"
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/core"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"net"
+	"sync"
+	"time"
+)
+
+// Server serves the federation Service to the peers of the node.
+type Server struct {
+	_ struct{}
+
+	listener   net.Listener
+	gRPCServer *grpc.Server
+
+	logger     *zap.Logger
+	serving    bool
+	servingMux sync.Mutex
+}
+
+// Config represents the configuration for creating a new federation Server.
+type Config struct {
+	Listener net.Listener
+	// Local is the relay pool of this node, it must not be a Router to avoid bouncing requests between peers.
+	Local  core.RelayRouter
+	Logger *zap.Logger
+	// LeaseTTL is how long a relayer stays leased to a peer that does not release it, DefaultLeaseTTL when 0.
+	LeaseTTL time.Duration
+}
+
+// NewServer creates a new federation Server with the provided listener.
+func NewServer(cfg Config) *Server {
+	s := grpc.NewServer(grpc.StatsHandler(otelgrpc.NewServerHandler()))
+
+	v1.RegisterEchoSphereFederationServiceServer(s, NewService(cfg.Local, cfg.LeaseTTL))
+
+	return &Server{
+		listener:   cfg.Listener,
+		gRPCServer: s,
+		logger:     cfg.Logger,
+	}
+}
+
+// Run starts the federation Server and handles graceful shutdown.
+func (s *Server) Run(ctx context.Context) error {
+	g, ctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		s.servingMux.Lock()
+		s.serving = true
+		s.servingMux.Unlock()
+
+		s.logger.Info("Serving federation", zap.String("addr", s.listener.Addr().String()))
+
+		return s.gRPCServer.Serve(s.listener)
+	})
+
+	g.Go(func() error {
+		<-ctx.Done()
+
+		s.gRPCServer.Stop()
+
+		s.servingMux.Lock()
+		defer s.servingMux.Unlock()
+
+		s.serving = false
+
+		return nil
+	})
+
+	return g.Wait()
+}
+
+func (s *Server) HealthCheck() error {
+	s.servingMux.Lock()
+	defer s.servingMux.Unlock()
+
+	if !s.serving {
+		return fmt.Errorf("not serving federation")
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package federation
+
+import (
+	"context"
+	"errors"
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/core"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sync"
+	"time"
+)
+
+// DefaultLeaseTTL is how long a relayer stays leased to a peer when no TTL is configured.
+const DefaultLeaseTTL = 30 * time.Second
+
+// Service exposes the local relay pool to peers.
+// Relayers acquired by a peer are leased until the peer releases them, so frames can be delivered meanwhile.
+// A lease the peer never releases, because the acquire failed on its side or the peer went away, expires after
+// the TTL and the relayer returns to the local relay pool.
+type Service struct {
+	v1.UnimplementedEchoSphereFederationServiceServer
+
+	local    core.RelayRouter
+	leaseTTL time.Duration
+
+	mu     sync.Mutex
+	leased map[string]*lease
+}
+
+// lease is a relayer held by a peer, expiry releases it unless the peer did first.
+type lease struct {
+	relayer core.Messager
+	expiry  *time.Timer
+}
+
+// NewService creates a new Service over the local relay pool, leases expire after leaseTTL or DefaultLeaseTTL
+// when it is not positive.
+func NewService(local core.RelayRouter, leaseTTL time.Duration) *Service {
+	if leaseTTL <= 0 {
+		leaseTTL = DefaultLeaseTTL
+	}
+
+	return &Service{
+		local:    local,
+		leaseTTL: leaseTTL,
+		leased:   make(map[string]*lease),
+	}
+}
+
+// AcquireRelayer leases the local relayer of the requested owner to the calling peer.
+func (s *Service) AcquireRelayer(
+	ctx context.Context,
+	req *v1.EchoSphereFederationServiceAcquireRelayerRequest,
+) (*v1.EchoSphereFederationServiceAcquireRelayerResponse, error) {
+	relayer, err := s.local.AcquireRelayer(ctx, req.GetOwnerId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	s.lease(req.GetOwnerId(), relayer)
+
+	return &v1.EchoSphereFederationServiceAcquireRelayerResponse{}, nil
+}
+
+// AcquireRandomRelayer leases a random local relayer, other than the excluded one, to the calling peer.
+func (s *Service) AcquireRandomRelayer(
+	ctx context.Context,
+	req *v1.EchoSphereFederationServiceAcquireRandomRelayerRequest,
+) (*v1.EchoSphereFederationServiceAcquireRandomRelayerResponse, error) {
+	ownerID, relayer, err := s.local.AcquireRandomRelayer(ctx, req.GetExcludeOwnerId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	s.lease(ownerID, relayer)
+
+	return &v1.EchoSphereFederationServiceAcquireRandomRelayerResponse{OwnerId: ownerID}, nil
+}
+
+// ReleaseRelayer returns a leased relayer to the local relay pool.
+func (s *Service) ReleaseRelayer(
+	ctx context.Context,
+	req *v1.EchoSphereFederationServiceReleaseRelayerRequest,
+) (*v1.EchoSphereFederationServiceReleaseRelayerResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l, ok := s.leased[req.GetOwnerId()]
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "relayer %s is not leased", req.GetOwnerId())
+	}
+
+	l.expiry.Stop()
+	delete(s.leased, req.GetOwnerId())
+
+	s.local.ReleaseRelayer(ctx, req.GetOwnerId(), l.relayer)
+
+	return &v1.EchoSphereFederationServiceReleaseRelayerResponse{}, nil
+}
+
+// Deliver sends a frame through a leased relayer.
+func (s *Service) Deliver(
+	ctx context.Context,
+	req *v1.EchoSphereFederationServiceDeliverRequest,
+) (*v1.EchoSphereFederationServiceDeliverResponse, error) {
+	s.mu.Lock()
+	l, ok := s.leased[req.GetOwnerId()]
+	s.mu.Unlock()
+
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "relayer %s is not leased", req.GetOwnerId())
+	}
+
+	if err := l.relayer.SendMsg(ctx, req.GetFrame()); err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	return &v1.EchoSphereFederationServiceDeliverResponse{}, nil
+}
+
+func (s *Service) lease(ownerID string, relayer core.Messager) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l := &lease{relayer: relayer}
+	l.expiry = time.AfterFunc(s.leaseTTL, func() { s.expire(ownerID, l) })
+
+	s.leased[ownerID] = l
+}
+
+// expire releases the lease to the local relay pool if the peer still holds it.
+func (s *Service) expire(ownerID string, l *lease) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.leased[ownerID] != l {
+		return
+	}
+
+	delete(s.leased, ownerID)
+
+	s.local.ReleaseRelayer(context.Background(), ownerID, l.relayer)
+}
+
+// toStatus maps relay pool errors to gRPC statuses understood by the Router.
+func toStatus(err error) error {
+	if errors.Is(err, core.ErrFailedToGetRelayer) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
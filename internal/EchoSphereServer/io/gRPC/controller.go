@@ -9,13 +9,50 @@ import (
 	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/gRPC/internal/middleware"
 	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
 	"github.com/samber/lo"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"io"
+	"sync"
 )
 
 // Transmit handles incoming stream messages and relays them.
+// Frames that cannot be processed are answered with an Error frame, only stream failures end the stream.
 func (s *Server) Transmit(stream v1.EchoSphereTransmissionService_TransmitServer) error {
+	if s.draining.Load() {
+		return status.Error(codes.Unavailable, "server is draining")
+	}
+
+	s.streams.Add(1)
+	defer s.streams.Add(-1)
+
+	s.goroutines.Add(1)
+	defer s.goroutines.Add(-1)
+
+	sender := NewStreamSender(stream)
+
+	// receiving blocks, it runs apart so the server can still end the stream
+	received := make(chan error, 1)
+
+	s.goroutines.Add(1)
+
+	go func() {
+		defer s.goroutines.Add(-1)
+
+		received <- s.receive(stream, sender)
+	}()
+
+	select {
+	case err := <-received:
+		return err
+	case <-sender.disconnected:
+		return status.Error(codes.Aborted, "disconnected by the server")
+	}
+}
+
+func (s *Server) receive(stream v1.EchoSphereTransmissionService_TransmitServer, sender *StreamSender) error {
 	for {
 		select {
 		case <-stream.Context().Done():
@@ -23,12 +60,15 @@ func (s *Server) Transmit(stream v1.EchoSphereTransmissionService_TransmitServer
 		default:
 			req, err := stream.Recv()
 			if err != nil {
-				s.unregister(stream.Context()) //nolint:errcheck
+				// a disconnected client was already unregistered
+				if !sender.isDisconnected() {
+					s.unregister(stream.Context()) //nolint:errcheck
+				}
 
 				return lo.Ternary(!errors.Is(err, io.EOF), err, nil)
 			}
 
-			err = s.handleRequest(stream.Context(), req, stream)
+			err = s.handleRequest(stream.Context(), req, sender)
 			if err != nil {
 				s.unregister(stream.Context()) //nolint:errcheck
 
@@ -38,63 +78,131 @@ func (s *Server) Transmit(stream v1.EchoSphereTransmissionService_TransmitServer
 	}
 }
 
-func (s *Server) handleRequest(ctx context.Context, req *v1.EchoSphereTransmissionServiceTransmitRequest, stream grpc.ServerStream) error {
-	if message := req.GetMessage(); message != nil {
-		if err := s.handleMessage(ctx, stream, message); err != nil {
-			return err
-		}
+func (s *Server) handleRequest(ctx context.Context, req *v1.EchoSphereTransmissionServiceTransmitRequest, sender *StreamSender) error {
+	var err error
+
+	switch {
+	case req.GetMessage() != nil:
+		err = s.handleMessage(ctx, sender, req.GetMessage())
+	case req.GetAck() != nil:
+		err = s.handleAck(ctx, req.GetAck())
+	case req.GetNack() != nil:
+		err = s.handleNack(ctx, req.GetNack())
+	default:
+		err = &frameError{code: v1.ErrorCode_ERROR_CODE_INVALID_FRAME, err: errors.New("frame carries no message, ack or nack")}
 	}
 
-	if ok := req.GetAck(); ok != nil {
-		if err := s.handleAck(ctx, ok); err != nil && !errors.Is(err, core.ErrFailedToGetRelayer) {
-			return err
-		}
+	var fErr *frameError
+	if !errors.As(err, &fErr) {
+		return err
 	}
 
-	return nil
+	s.logger.Warn("Rejected frame", zap.Stringer("code", fErr.code), zap.Error(fErr), v1.TraceField(ctx))
+
+	return sender.SendMsg(ctx, v1.NewErrorResponse(fErr.code, fErr.Error(), req))
 }
 
-func (s *Server) handleMessage(ctx context.Context, stream grpc.ServerStream, message *v1.Message) error {
-	err := s.useCase.RegisterHandler(ctx, usecase.RegisterCMD{
+func (s *Server) handleMessage(ctx context.Context, sender *StreamSender, message *v1.Message) (err error) {
+	if message.GetFrom() == "" {
+		return &frameError{code: v1.ErrorCode_ERROR_CODE_INVALID_FRAME, err: errors.New("message is missing its sender")}
+	}
+
+	ctx, span := startFrameSpan(ctx, "Relay message", message.GetTraceContext(), attribute.String("echosphere.from", message.GetFrom()))
+	defer func() { endFrameSpan(span, err) }()
+
+	err = s.useCase.RegisterHandler(ctx, usecase.RegisterCMD{
 		OwnerID:      message.GetFrom(),
-		StreamSender: StreamSender{stream: stream},
+		StreamSender: sender,
 	})
+	if errors.Is(err, core.ErrTooManyRelayers) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+
 	if err != nil {
 		return err
 	}
 
-	s.logger.Info("Registered client successfully", zap.String("client-id", message.GetFrom()))
+	s.logger.Info("Registered client successfully", zap.String("client-id", message.GetFrom()), v1.TraceField(ctx))
 
 	err = s.useCase.RelayHandler(
 		ctx,
 		usecase.RelayCMD{
-			From:    message.GetFrom(),
-			Content: message.GetContent(),
+			From:         message.GetFrom(),
+			Content:      message.GetContent(),
+			TraceContext: v1.InjectTraceContext(ctx),
 		},
 	)
-	if err != nil {
+
+	// the echo is lost too when the relayer of the sender is leased meanwhile, the stream survives both
+	switch {
+	case errors.Is(err, core.ErrFailedToRelay), errors.Is(err, core.ErrFailedToGetRelayer):
+		return &frameError{code: v1.ErrorCode_ERROR_CODE_RELAY_FAILED, err: err}
+	case err != nil:
 		return fmt.Errorf("error handling message: %w", err)
 	}
 
 	return nil
 }
 
-func (s *Server) handleAck(ctx context.Context, ackMessage *v1.Ack) error {
-	err := s.useCase.AckHandler(
+func (s *Server) handleAck(ctx context.Context, ackMessage *v1.Ack) (err error) {
+	if ackMessage.GetFrom() == "" || ackMessage.GetTo() == "" {
+		return &frameError{code: v1.ErrorCode_ERROR_CODE_INVALID_FRAME, err: errors.New("ack is missing its sender or recipient")}
+	}
+
+	ctx, span := startFrameSpan(ctx, "Forward ack", ackMessage.GetTraceContext(),
+		attribute.String("echosphere.from", ackMessage.GetFrom()), attribute.String("echosphere.to", ackMessage.GetTo()))
+	defer func() { endFrameSpan(span, err) }()
+
+	err = s.useCase.AckHandler(
 		ctx,
 		usecase.AckCMD{
-			From:    ackMessage.GetFrom(),
-			To:      ackMessage.To, //nolint:protogetter
-			Content: ackMessage.GetContent(),
+			From:         ackMessage.GetFrom(),
+			To:           ackMessage.To, //nolint:protogetter
+			Content:      ackMessage.GetContent(),
+			TraceContext: v1.InjectTraceContext(ctx),
 		},
 	)
-	if err != nil {
+
+	switch {
+	case errors.Is(err, core.ErrFailedToGetRelayer):
+		return &frameError{code: v1.ErrorCode_ERROR_CODE_UNKNOWN_ACK_TARGET, err: err}
+	case errors.Is(err, core.ErrFailedToRelay):
+		return &frameError{code: v1.ErrorCode_ERROR_CODE_RELAY_FAILED, err: err}
+	case err != nil:
 		return fmt.Errorf("error handling ack message: %w", err)
 	}
 
 	return nil
 }
 
+func (s *Server) handleNack(ctx context.Context, nack *v1.Nack) error {
+	if nack.GetFrom() == "" || nack.GetTo() == "" {
+		return &frameError{code: v1.ErrorCode_ERROR_CODE_INVALID_FRAME, err: errors.New("nack is missing its sender or recipient")}
+	}
+
+	err := s.useCase.NackHandler(
+		ctx,
+		usecase.NackCMD{
+			From:    nack.GetFrom(),
+			To:      nack.GetTo(),
+			Content: nack.GetContent(),
+			// a client only refuses messages, timeouts and departures are the server's to report
+			Reason: v1.NackReason_NACK_REASON_REJECTED,
+		},
+	)
+
+	switch {
+	case errors.Is(err, core.ErrFailedToGetRelayer):
+		return &frameError{code: v1.ErrorCode_ERROR_CODE_UNKNOWN_ACK_TARGET, err: err}
+	case errors.Is(err, core.ErrFailedToRelay):
+		return &frameError{code: v1.ErrorCode_ERROR_CODE_RELAY_FAILED, err: err}
+	case err != nil:
+		return fmt.Errorf("error handling nack message: %w", err)
+	}
+
+	return nil
+}
+
 func (s *Server) unregister(ctx context.Context) error {
 	ownerID, ok := ctx.Value(middleware.ClientSourceCtxKey).(string)
 	if !ok {
@@ -103,22 +211,59 @@ func (s *Server) unregister(ctx context.Context) error {
 
 	err := s.useCase.UnregisterHandler(ctx, usecase.UnregisterCMD{OwnerID: ownerID})
 	if err != nil {
-		s.logger.Error("Error unregistering:", zap.Error(err))
+		s.logger.Error("Error unregistering:", zap.Error(err), v1.TraceField(ctx))
 
 		return err
 	}
 
-	s.logger.Info("Unregister process completed successfully", zap.String("clientID", ownerID))
+	s.logger.Info("Unregister process completed successfully", zap.String("clientID", ownerID), v1.TraceField(ctx))
 
 	return nil
 }
 
+// frameError is a failure confined to one frame, it is reported to the client and the stream stays open.
+type frameError struct {
+	code v1.ErrorCode
+	err  error
+}
+
+func (f *frameError) Error() string { return f.err.Error() }
+
+func (f *frameError) Unwrap() error { return f.err }
+
 // StreamSender provides a method to send messages via gRPC stream.
+// Frames relayed by other streams and the Error frames of its own stream are serialized.
 type StreamSender struct {
+	mu     sync.Mutex
 	stream grpc.ServerStream
+
+	disconnect   sync.Once
+	disconnected chan struct{}
+}
+
+// NewStreamSender creates a new StreamSender for the stream.
+func NewStreamSender(stream grpc.ServerStream) *StreamSender {
+	return &StreamSender{stream: stream, disconnected: make(chan struct{})}
 }
 
 // SendMsg sends a message via the gRPC stream.
-func (s StreamSender) SendMsg(_ context.Context, m any) error {
+func (s *StreamSender) SendMsg(_ context.Context, m any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	return s.stream.SendMsg(m)
 }
+
+// Disconnect ends the stream, the client sees it aborted.
+func (s *StreamSender) Disconnect() {
+	s.disconnect.Do(func() { close(s.disconnected) })
+}
+
+func (s *StreamSender) isDisconnected() bool {
+	select {
+	case <-s.disconnected:
+		return true
+	default:
+		return false
+	}
+}
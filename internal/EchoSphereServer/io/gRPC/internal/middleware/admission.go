@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"runtime/metrics"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// heapSampleInterval bounds how often the heap size is read when shedding by memory.
+const heapSampleInterval = time.Second
+
+// AdmissionConfig configures which new streams are accepted, a zero limit or threshold disables it.
+type AdmissionConfig struct {
+	MaxStreams           int64
+	MaxStreamsPerAddress int64
+	// MaxHeapBytes sheds new streams while the live heap is above it.
+	MaxHeapBytes uint64
+	// MaxQueueDepth sheds new streams while QueueDepth reports more queued commands.
+	MaxQueueDepth int64
+	QueueDepth    func() int64
+}
+
+// StreamAdmission rejects new streams with ResourceExhausted when a connection limit is reached
+// or while the server is shedding load.
+func StreamAdmission(cfg AdmissionConfig) grpc.StreamServerInterceptor {
+	admission := newAdmission(cfg)
+
+	return func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		address := remoteHost(stream)
+
+		if err := admission.admit(stream.Context(), address); err != nil {
+			return err
+		}
+		defer admission.release(stream.Context(), address)
+
+		return handler(srv, stream)
+	}
+}
+
+type admission struct {
+	cfg AdmissionConfig
+
+	streams   atomic.Int64
+	mu        sync.Mutex
+	perAddr   map[string]int64
+	heapBytes atomic.Uint64
+	heapAt    atomic.Int64
+
+	rejected metric.Int64Counter
+	active   metric.Int64UpDownCounter
+}
+
+func newAdmission(cfg AdmissionConfig) *admission {
+	meter := otel.GetMeterProvider().Meter("echosphere.io/admission")
+
+	active, err := meter.Int64UpDownCounter("admission_active_streams")
+	if err != nil {
+		log.Fatalf("failed to create counter admission_active_streams: %v", err)
+	}
+
+	return &admission{
+		cfg:      cfg,
+		perAddr:  make(map[string]int64),
+		rejected: createInt64Counter(meter, "admission_rejected_total"),
+		active:   active,
+	}
+}
+
+func (a *admission) admit(ctx context.Context, address string) error {
+	if reason, msg := a.shedding(); reason != "" {
+		return a.reject(ctx, reason, msg)
+	}
+
+	if streams := a.streams.Add(1); a.cfg.MaxStreams > 0 && streams > a.cfg.MaxStreams {
+		a.streams.Add(-1)
+
+		return a.reject(ctx, "max_streams", "maximum number of streams reached")
+	}
+
+	a.mu.Lock()
+	if a.cfg.MaxStreamsPerAddress > 0 && a.perAddr[address] >= a.cfg.MaxStreamsPerAddress {
+		a.mu.Unlock()
+		a.streams.Add(-1)
+
+		return a.reject(ctx, "max_streams_per_address", "maximum number of streams reached for address "+address)
+	}
+	a.perAddr[address]++
+	a.mu.Unlock()
+
+	a.active.Add(ctx, 1)
+
+	return nil
+}
+
+func (a *admission) release(ctx context.Context, address string) {
+	a.streams.Add(-1)
+
+	a.mu.Lock()
+	if a.perAddr[address]--; a.perAddr[address] <= 0 {
+		delete(a.perAddr, address)
+	}
+	a.mu.Unlock()
+
+	a.active.Add(ctx, -1)
+}
+
+// shedding returns the reason the server is shedding load, if any, and a description for the client.
+func (a *admission) shedding() (string, string) {
+	if a.cfg.MaxQueueDepth > 0 && a.cfg.QueueDepth != nil && a.cfg.QueueDepth() > a.cfg.MaxQueueDepth {
+		return "queue_depth", "server is shedding load: too many queued commands"
+	}
+
+	if a.cfg.MaxHeapBytes > 0 && a.heap() > a.cfg.MaxHeapBytes {
+		return "memory", "server is shedding load: memory threshold exceeded"
+	}
+
+	return "", ""
+}
+
+// heap returns the live heap size, sampled at most once per heapSampleInterval.
+func (a *admission) heap() uint64 {
+	now := time.Now().UnixNano()
+
+	if last := a.heapAt.Load(); now-last > int64(heapSampleInterval) && a.heapAt.CompareAndSwap(last, now) {
+		sample := []metrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+		metrics.Read(sample)
+
+		a.heapBytes.Store(sample[0].Value.Uint64())
+	}
+
+	return a.heapBytes.Load()
+}
+
+func (a *admission) reject(ctx context.Context, reason, msg string) error {
+	a.rejected.Add(ctx, 1, metric.WithAttributes(attribute.String("reason", reason)))
+
+	return status.Error(codes.ResourceExhausted, msg)
+}
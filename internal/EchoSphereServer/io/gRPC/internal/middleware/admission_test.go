@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"net"
+	"testing"
+	"time"
+)
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (f fakeServerStream) Context() context.Context { return f.ctx }
+
+func newFakeStream(address string) fakeServerStream {
+	addr, _ := net.ResolveTCPAddr("tcp", address) //nolint:errcheck
+
+	return fakeServerStream{ctx: peer.NewContext(context.Background(), &peer.Peer{Addr: addr})}
+}
+
+// holdStream starts a stream through the interceptor and keeps it open until the returned func is called.
+func holdStream(t *testing.T, interceptor grpc.StreamServerInterceptor, address string) func() {
+	t.Helper()
+
+	admitted, done := make(chan error, 1), make(chan struct{})
+
+	go func() {
+		err := interceptor(nil, newFakeStream(address), nil, func(any, grpc.ServerStream) error {
+			admitted <- nil
+			<-done
+
+			return nil
+		})
+		if err != nil {
+			admitted <- err
+		}
+	}()
+
+	require.NoError(t, <-admitted)
+
+	return func() { close(done) }
+}
+
+func openStream(interceptor grpc.StreamServerInterceptor, address string) error {
+	return interceptor(nil, newFakeStream(address), nil, func(any, grpc.ServerStream) error { return nil })
+}
+
+func TestStreamAdmission_MaxStreams(t *testing.T) {
+	interceptor := StreamAdmission(AdmissionConfig{MaxStreams: 1})
+
+	release := holdStream(t, interceptor, "10.0.0.1:1000")
+
+	err := openStream(interceptor, "10.0.0.2:1000")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	release()
+
+	assert.Eventually(t, func() bool { return openStream(interceptor, "10.0.0.2:1000") == nil }, time.Second, time.Millisecond)
+}
+
+func TestStreamAdmission_MaxStreamsPerAddress(t *testing.T) {
+	interceptor := StreamAdmission(AdmissionConfig{MaxStreamsPerAddress: 1})
+
+	release := holdStream(t, interceptor, "10.0.0.1:1000")
+	defer release()
+
+	err := openStream(interceptor, "10.0.0.1:2000")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	require.NoError(t, openStream(interceptor, "10.0.0.2:1000"))
+}
+
+func TestStreamAdmission_ShedsOnQueueDepth(t *testing.T) {
+	depth := int64(0)
+	interceptor := StreamAdmission(AdmissionConfig{MaxQueueDepth: 10, QueueDepth: func() int64 { return depth }})
+
+	require.NoError(t, openStream(interceptor, "10.0.0.1:1000"))
+
+	depth = 11
+
+	err := openStream(interceptor, "10.0.0.1:1000")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestStreamAdmission_ShedsOnMemory(t *testing.T) {
+	interceptor := StreamAdmission(AdmissionConfig{MaxHeapBytes: 1})
+
+	err := openStream(interceptor, "10.0.0.1:1000")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	v1 "github.com/k4l1ma/EchoSphere/api/v1"
 	"google.golang.org/grpc"
+	"sync"
 )
 
 type CtxKey string
@@ -20,10 +21,15 @@ type identifierServerStream struct {
 	_ struct{}
 	grpc.ServerStream
 
+	// mu guards ctx, the stream is sent to by the streams relaying to it while it receives
+	mu  sync.RWMutex
 	ctx context.Context
 }
 
 func (i *identifierServerStream) Context() context.Context {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
 	return i.ctx
 }
 
@@ -48,7 +54,18 @@ func (i *identifierServerStream) RecvMsg(m any) error {
 		From = request.GetAck().GetFrom()
 	}
 
-	i.ctx = context.WithValue(i.Context(), ClientSourceCtxKey, From)
+	if request.GetNack() != nil {
+		From = request.GetNack().GetFrom()
+	}
+
+	// a frame without a sender does not tell who the client is
+	if From == "" {
+		return nil
+	}
+
+	i.mu.Lock()
+	i.ctx = context.WithValue(i.ctx, ClientSourceCtxKey, From)
+	i.mu.Unlock()
 
 	return nil
 }
@@ -4,28 +4,31 @@ import (
 	v1 "github.com/k4l1ma/EchoSphere/api/v1"
 	"github.com/samber/lo"
 	"go.uber.org/zap"
-	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc"
 	"time"
 )
 
-func StreamLogger(logger *zap.Logger) grpc.StreamServerInterceptor {
+// StreamLogger logs the frames of the streams, keeping of them what the capture policy allows.
+func StreamLogger(logger *zap.Logger, capture v1.CapturePolicy, sampling v1.LogSampling) grpc.StreamServerInterceptor {
+	logger = sampling.Sampled(logger)
+
 	return func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
 		// Execute the next handler in the chain
-		return handler(srv, &loggerServerStream{ServerStream: stream, logger: logger})
+		return handler(srv, &loggerServerStream{ServerStream: stream, logger: logger, capture: capture})
 	}
 }
 
 type loggerServerStream struct {
 	_ struct{}
 	grpc.ServerStream
-	logger *zap.Logger
+	logger  *zap.Logger
+	capture v1.CapturePolicy
 }
 
 func (s *loggerServerStream) RecvMsg(m any) error {
 	start := time.Now()
 
-	if !lo.IsEmpty(m.(*v1.EchoSphereTransmissionServiceTransmitRequest).IncomingData) {
+	if request, ok := m.(*v1.EchoSphereTransmissionServiceTransmitRequest); ok && !lo.IsEmpty(request.IncomingData) {
 		s.logMessage("Recived Msg", m)
 	}
 
@@ -49,14 +52,10 @@ func (s *loggerServerStream) SendMsg(m any) error {
 }
 
 func (s *loggerServerStream) logMessage(msg string, m any, additionalFields ...zap.Field) {
-	additionalFields = append(additionalFields, s.getMessageField(m))
+	additionalFields = append(additionalFields, s.getMessageField(m), v1.FrameTraceField(m))
 	s.logger.Info(msg, additionalFields...)
 }
 
 func (s *loggerServerStream) getMessageField(m any) zap.Field {
-	if marshaler, ok := m.(zapcore.ObjectMarshaler); ok {
-		return zap.Object("message", marshaler)
-	}
-
-	return zap.Any("message", m)
+	return zap.Object("message", s.capture.Object(m))
 }
@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"context"
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"testing"
+)
+
+// relayed is the frame of a message relayed to its recipient.
+var relayed = &v1.EchoSphereTransmissionServiceTransmitResponse{
+	OutgoingData: &v1.EchoSphereTransmissionServiceTransmitResponse_Message{
+		Message: &v1.Message{From: "originator", Content: "a secret message"},
+	},
+}
+
+// sendLogs sends the frames through the logging interceptor and returns the frames logged once sent.
+func sendLogs(t *testing.T, capture v1.CapturePolicy, sampling v1.LogSampling, frames int) []map[string]any {
+	t.Helper()
+
+	core, logs := observer.New(zap.InfoLevel)
+	interceptor := StreamLogger(zap.New(core), capture, sampling)
+
+	require.NoError(t, interceptor(nil, &scriptedStream{}, nil, func(_ any, stream grpc.ServerStream) error {
+		for range frames {
+			if err := stream.SendMsg(relayed); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+
+	var sent []map[string]any
+
+	for _, entry := range logs.FilterMessage("Finished handling SendMsg").AllUntimed() {
+		sent = append(sent, entry.ContextMap()["message"].(map[string]any))
+	}
+
+	return sent
+}
+
+func TestStreamLogger_Capture(t *testing.T) {
+	hash := v1.ContentHash("a secret message")
+
+	for capture, want := range map[v1.Capture]map[string]any{
+		v1.CaptureOff:  {"Type": "message"},
+		v1.CaptureHash: {"Type": "message", "From": "originator", "ContentHash": hash},
+		v1.CaptureTruncated: {
+			"Type": "message", "From": "originator", "ContentHash": hash, "Content": "a secret",
+		},
+		// the whole frame as it is logged elsewhere
+		v1.CaptureFull: {"Content": map[string]any{"From": "originator", "Content": "a secret message"}},
+	} {
+		t.Run(string(capture), func(t *testing.T) {
+			sent := sendLogs(t, v1.CapturePolicy{Capture: capture, TruncateAt: 8}, v1.LogSampling{}, 1)
+			require.Len(t, sent, 1)
+			assert.Equal(t, want, sent[0])
+		})
+	}
+}
+
+func TestStreamLogger_Sampling(t *testing.T) {
+	assert.Len(t, sendLogs(t, v1.CapturePolicy{}, v1.LogSampling{}, 10), 10)
+
+	// the first 2 of the second, then 1 in 4 of the 8 left
+	assert.Len(t, sendLogs(t, v1.CapturePolicy{}, v1.LogSampling{First: 2, Thereafter: 4}, 10), 4)
+}
+
+func TestStreamLogger_Trace(t *testing.T) {
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	// the frames are logged with the span they were sent in
+	for name, tc := range map[string]struct {
+		traceContext map[string]string
+		want         map[string]any
+	}{
+		"traced": {
+			traceContext: v1.InjectTraceContext(trace.ContextWithSpanContext(context.Background(), spanContext)),
+			want: map[string]any{
+				"trace_id": spanContext.TraceID().String(),
+				"span_id":  spanContext.SpanID().String(),
+			},
+		},
+		"untraced": {want: map[string]any{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			core, logs := observer.New(zap.InfoLevel)
+			interceptor := StreamLogger(zap.New(core), v1.CapturePolicy{}, v1.LogSampling{})
+
+			frame := &v1.EchoSphereTransmissionServiceTransmitResponse{
+				OutgoingData: &v1.EchoSphereTransmissionServiceTransmitResponse_Message{
+					Message: &v1.Message{From: "originator", Content: "a message", TraceContext: tc.traceContext},
+				},
+			}
+
+			require.NoError(t, interceptor(nil, &scriptedStream{}, nil, func(_ any, stream grpc.ServerStream) error {
+				return stream.SendMsg(frame)
+			}))
+
+			require.NotEmpty(t, logs.All())
+
+			for _, entry := range logs.All() {
+				fields := entry.ContextMap()
+
+				got := map[string]any{}
+				for _, key := range []string{"trace_id", "span_id"} {
+					if value, ok := fields[key]; ok {
+						got[key] = value
+					}
+				}
+
+				assert.Equal(t, tc.want, got, entry.Message)
+			}
+		})
+	}
+}
@@ -2,8 +2,14 @@ package middleware
 
 import (
 	"context"
+	"errors"
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
 	"log"
 	"time"
 
@@ -48,28 +54,56 @@ func createFloat64Histogram(meter metric.Meter, name string) metric.Float64Histo
 	return histogram
 }
 
-// recordMetrics records the metrics for a command execution.
-func (mr *MetricsRecorder) recordMetrics(ctx context.Context, err error, executionTime float64) {
-	mr.requestsCounter.Add(ctx, 1)
+// recordMetrics records the metrics for a frame sent or received in the given direction.
+// The end of the stream, io.EOF, is not a failure and a frame that was not received is of type none.
+func (mr *MetricsRecorder) recordMetrics(ctx context.Context, direction, method string, frame any, err error, executionTime float64) {
+	code := status.Code(err)
+	if errors.Is(err, io.EOF) {
+		code = codes.OK
+	}
 
-	if err != nil {
-		mr.failureCounter.Add(ctx, 1)
+	attrs := metric.WithAttributes(
+		attribute.String("direction", direction),
+		attribute.String("frame", v1.FrameType(frame)),
+		attribute.String("rpc.method", method),
+		attribute.String("rpc.grpc.status_code", code.String()),
+	)
+
+	mr.requestsCounter.Add(ctx, 1, attrs)
+
+	if code != codes.OK {
+		mr.failureCounter.Add(ctx, 1, attrs)
 	} else {
-		mr.successCounter.Add(ctx, 1)
+		mr.successCounter.Add(ctx, 1, attrs)
 	}
 
-	mr.executionTimeHistogram.Record(ctx, executionTime)
+	mr.executionTimeHistogram.Record(ctx, executionTime, attrs)
 }
 
+// StreamMetric records the frames of the streams, the instruments are shared by every stream of the interceptor.
 func StreamMetric() grpc.StreamServerInterceptor {
-	return func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		return handler(srv, &metricServerStream{ServerStream: stream})
+	return streamMetric(otel.GetMeterProvider().Meter("echosphere.io/stream"))
+}
+
+func streamMetric(meter metric.Meter) grpc.StreamServerInterceptor {
+	recorder := newMetricsRecorder(meter)
+
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		var method string
+		if info != nil {
+			method = info.FullMethod
+		}
+
+		return handler(srv, &metricServerStream{ServerStream: stream, recorder: recorder, method: method})
 	}
 }
 
 type metricServerStream struct {
 	_ struct{}
 	grpc.ServerStream
+
+	recorder *MetricsRecorder
+	method   string
 }
 
 func (s *metricServerStream) RecvMsg(m any) error {
@@ -77,12 +111,7 @@ func (s *metricServerStream) RecvMsg(m any) error {
 
 	err := s.ServerStream.RecvMsg(m)
 
-	newMetricsRecorder(otel.GetMeterProvider().Meter("echosphere.io/receiver")).
-		recordMetrics(
-			context.Background(),
-			err,
-			time.Since(startTime).Seconds(),
-		)
+	s.recorder.recordMetrics(context.Background(), "received", s.method, m, err, time.Since(startTime).Seconds())
 
 	return err
 }
@@ -92,12 +121,7 @@ func (s *metricServerStream) SendMsg(m any) error {
 
 	err := s.ServerStream.SendMsg(m)
 
-	newMetricsRecorder(otel.GetMeterProvider().Meter("echosphere.io/sender")).
-		recordMetrics(
-			context.Background(),
-			err,
-			time.Since(startTime).Seconds(),
-		)
+	s.recorder.recordMetrics(context.Background(), "sent", s.method, m, err, time.Since(startTime).Seconds())
 
 	return err
 }
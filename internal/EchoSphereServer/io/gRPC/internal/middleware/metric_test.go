@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"context"
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"testing"
+)
+
+// scriptedStream receives the frames of recv in turn, then io.EOF, and fails its sends with sendErr.
+type scriptedStream struct {
+	grpc.ServerStream
+	recv    []*v1.EchoSphereTransmissionServiceTransmitRequest
+	sendErr error
+}
+
+func (s *scriptedStream) Context() context.Context { return context.Background() }
+
+func (s *scriptedStream) RecvMsg(m any) error {
+	if len(s.recv) == 0 {
+		return io.EOF
+	}
+
+	proto.Merge(m.(proto.Message), s.recv[0])
+	s.recv = s.recv[1:]
+
+	return nil
+}
+
+func (s *scriptedStream) SendMsg(any) error { return s.sendErr }
+
+// requests returns the requests_total data points by their attributes.
+func requests(t *testing.T, reader sdkmetric.Reader) map[attribute.Distinct]int64 {
+	t.Helper()
+
+	var data metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &data))
+
+	points := make(map[attribute.Distinct]int64)
+
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name != "requests_total" {
+				continue
+			}
+
+			for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				points[point.Attributes.Equivalent()] = point.Value
+			}
+		}
+	}
+
+	return points
+}
+
+const transmitMethod = "/api.v1.EchoSphereTransmissionService/Transmit"
+
+func frameAttributes(direction, frame, method string, code codes.Code) attribute.Distinct {
+	set := attribute.NewSet(
+		attribute.String("direction", direction),
+		attribute.String("frame", frame),
+		attribute.String("rpc.method", method),
+		attribute.String("rpc.grpc.status_code", code.String()),
+	)
+
+	return set.Equivalent()
+}
+
+func TestStreamMetric_Attributes(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	interceptor := streamMetric(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test"))
+
+	stream := &scriptedStream{
+		recv: []*v1.EchoSphereTransmissionServiceTransmitRequest{
+			{IncomingData: &v1.EchoSphereTransmissionServiceTransmitRequest_Message{Message: &v1.Message{From: "a"}}},
+			{IncomingData: &v1.EchoSphereTransmissionServiceTransmitRequest_Ack{Ack: &v1.Ack{From: "a", To: "b"}}},
+			{IncomingData: &v1.EchoSphereTransmissionServiceTransmitRequest_Ack{Ack: &v1.Ack{From: "a", To: "c"}}},
+		},
+		sendErr: status.Error(codes.Unavailable, "gone"),
+	}
+
+	info := &grpc.StreamServerInfo{FullMethod: transmitMethod}
+
+	err := interceptor(nil, stream, info, func(_ any, stream grpc.ServerStream) error {
+		for {
+			if err := stream.RecvMsg(&v1.EchoSphereTransmissionServiceTransmitRequest{}); err != nil {
+				break
+			}
+		}
+
+		return stream.SendMsg(&v1.EchoSphereTransmissionServiceTransmitResponse{
+			OutgoingData: &v1.EchoSphereTransmissionServiceTransmitResponse_Nack{Nack: &v1.Nack{}},
+		})
+	})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+
+	assert.Equal(t, map[attribute.Distinct]int64{
+		frameAttributes("received", "message", transmitMethod, codes.OK): 1,
+		frameAttributes("received", "ack", transmitMethod, codes.OK):     2,
+		// the end of the stream is no frame and no failure
+		frameAttributes("received", "none", transmitMethod, codes.OK):      1,
+		frameAttributes("sent", "nack", transmitMethod, codes.Unavailable): 1,
+	}, requests(t, reader))
+}
+
+func TestStreamMetric_InstrumentsShared(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	interceptor := streamMetric(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test"))
+
+	for range 3 {
+		require.NoError(t, interceptor(nil, &scriptedStream{}, nil, func(_ any, stream grpc.ServerStream) error {
+			return stream.SendMsg(&v1.EchoSphereTransmissionServiceTransmitResponse{})
+		}))
+	}
+
+	assert.Equal(t, map[attribute.Distinct]int64{
+		frameAttributes("sent", "none", "", codes.OK): 3,
+	}, requests(t, reader))
+}
@@ -0,0 +1,181 @@
+package middleware
+
+import (
+	"fmt"
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"net"
+	"sync"
+	"time"
+)
+
+// RateLimitConfig configures the token buckets applied to incoming frames, a zero rate disables the bucket.
+type RateLimitConfig struct {
+	PerOwner        float64
+	PerOwnerBurst   int
+	PerAddress      float64
+	PerAddressBurst int
+	// IdleTTL is how long the bucket of an inactive owner or address is kept.
+	IdleTTL time.Duration
+}
+
+// StreamRateLimit limits the frames each owner ID and each remote address can send.
+// Over-limit frames are dropped and answered with a RATE_LIMITED Error frame, the stream stays open.
+func StreamRateLimit(cfg RateLimitConfig) grpc.StreamServerInterceptor {
+	owners := newKeyedLimiter(rate.Limit(cfg.PerOwner), cfg.PerOwnerBurst, cfg.IdleTTL)
+	addresses := newKeyedLimiter(rate.Limit(cfg.PerAddress), cfg.PerAddressBurst, cfg.IdleTTL)
+
+	return func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &rateLimitServerStream{
+			ServerStream: stream,
+			owners:       owners,
+			addresses:    addresses,
+			address:      remoteHost(stream),
+		})
+	}
+}
+
+type rateLimitServerStream struct {
+	_ struct{}
+	grpc.ServerStream
+
+	owners    *keyedLimiter
+	addresses *keyedLimiter
+	address   string
+
+	// sendMu serializes the Error frames with the frames relayed by other streams
+	sendMu sync.Mutex
+}
+
+func (s *rateLimitServerStream) SendMsg(m any) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	return s.ServerStream.SendMsg(m)
+}
+
+func (s *rateLimitServerStream) RecvMsg(m any) error {
+	for {
+		if err := s.ServerStream.RecvMsg(m); err != nil {
+			return err
+		}
+
+		request, ok := m.(*v1.EchoSphereTransmissionServiceTransmitRequest)
+		if !ok {
+			return nil
+		}
+
+		reason := s.exceeded(frameOwner(request))
+		if reason == "" {
+			return nil
+		}
+
+		if err := s.SendMsg(v1.NewErrorResponse(v1.ErrorCode_ERROR_CODE_RATE_LIMITED, reason, request)); err != nil {
+			return err
+		}
+	}
+}
+
+// exceeded returns why the frame is over the limit, or an empty string when it is allowed.
+func (s *rateLimitServerStream) exceeded(ownerID string) string {
+	if !s.addresses.allow(s.address) {
+		return fmt.Sprintf("rate limit exceeded for address %s: %.2f frames/s", s.address, float64(s.addresses.limit))
+	}
+
+	if !s.owners.allow(ownerID) {
+		return fmt.Sprintf("rate limit exceeded for owner %s: %.2f frames/s", ownerID, float64(s.owners.limit))
+	}
+
+	return ""
+}
+
+func frameOwner(request *v1.EchoSphereTransmissionServiceTransmitRequest) string {
+	if message := request.GetMessage(); message != nil {
+		return message.GetFrom()
+	}
+
+	if nack := request.GetNack(); nack != nil {
+		return nack.GetFrom()
+	}
+
+	return request.GetAck().GetFrom()
+}
+
+func remoteHost(stream grpc.ServerStream) string {
+	p, ok := peer.FromContext(stream.Context())
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
+
+// keyedLimiter keeps a token bucket per key, buckets idle for longer than ttl are evicted.
+type keyedLimiter struct {
+	limit rate.Limit
+	burst int
+	ttl   time.Duration
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func newKeyedLimiter(limit rate.Limit, burst int, ttl time.Duration) *keyedLimiter {
+	return &keyedLimiter{
+		limit:     limit,
+		burst:     max(burst, 1),
+		ttl:       ttl,
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+func (k *keyedLimiter) allow(key string) bool {
+	if k.limit <= 0 {
+		return true
+	}
+
+	now := time.Now()
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.sweep(now)
+
+	b, ok := k.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(k.limit, k.burst)}
+		k.buckets[key] = b
+	}
+
+	b.lastSeen = now
+
+	return b.limiter.AllowN(now, 1)
+}
+
+func (k *keyedLimiter) sweep(now time.Time) {
+	if k.ttl <= 0 || now.Sub(k.lastSweep) < k.ttl {
+		return
+	}
+
+	for key, b := range k.buckets {
+		if now.Sub(b.lastSeen) > k.ttl {
+			delete(k.buckets, key)
+		}
+	}
+
+	k.lastSweep = now
+}
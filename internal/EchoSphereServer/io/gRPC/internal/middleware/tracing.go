@@ -1,58 +1,53 @@
 package middleware
 
 import (
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
-	"google.golang.org/protobuf/encoding/protojson"
-	"google.golang.org/protobuf/proto"
 )
 
-func StreamTracing() grpc.StreamServerInterceptor {
+// StreamTracing traces the frames of the streams, keeping of them what the capture policy allows.
+func StreamTracing(capture v1.CapturePolicy) grpc.StreamServerInterceptor {
 	return func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		return handler(srv, &tracingServerStream{ServerStream: stream})
+		return handler(srv, &tracingServerStream{ServerStream: stream, capture: capture})
 	}
 }
 
 type tracingServerStream struct {
 	_ struct{}
 	grpc.ServerStream
+	capture v1.CapturePolicy
 }
 
 func (s *tracingServerStream) RecvMsg(m any) error {
 	tracer := otel.GetTracerProvider().Tracer("server.echosphere.io/grpc/reciver")
 
-	bytes, err := protojson.Marshal(m.(proto.Message))
-	if err != nil {
-		return err
-	}
-
-	_, span := tracer.Start(s.Context(), "RecvMsg", trace.WithAttributes(attribute.String("Message", string(bytes))))
+	_, span := tracer.Start(s.Context(), "RecvMsg")
 	defer span.End()
 
-	err = s.ServerStream.RecvMsg(m)
+	err := s.ServerStream.RecvMsg(m)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
+
+		return err
 	}
 
-	return err
+	// the frame is only known once received
+	span.SetAttributes(s.capture.Attributes(m)...)
+
+	return nil
 }
 
 func (s *tracingServerStream) SendMsg(m any) error {
 	tracer := otel.GetTracerProvider().Tracer("server.echosphere.io/grpc/sender")
 
-	bytes, err := protojson.Marshal(m.(proto.Message))
-	if err != nil {
-		return err
-	}
-
-	_, span := tracer.Start(s.Context(), "SendMsg", trace.WithAttributes(attribute.String("Message", string(bytes))))
+	_, span := tracer.Start(s.Context(), "SendMsg", trace.WithAttributes(s.capture.Attributes(m)...))
 	defer span.End()
 
-	err = s.ServerStream.SendMsg(m)
+	err := s.ServerStream.SendMsg(m)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
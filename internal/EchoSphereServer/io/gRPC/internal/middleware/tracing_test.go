@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"testing"
+)
+
+func TestStreamTracing_Capture(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+
+	received := &v1.EchoSphereTransmissionServiceTransmitRequest{
+		IncomingData: &v1.EchoSphereTransmissionServiceTransmitRequest_Ack{
+			Ack: &v1.Ack{From: "recipient", To: "originator", Content: "a secret message"},
+		},
+	}
+
+	for _, capture := range []v1.Capture{v1.CaptureOff, v1.CaptureHash, v1.CaptureTruncated, v1.CaptureFull} {
+		interceptor := StreamTracing(v1.CapturePolicy{Capture: capture, TruncateAt: 8})
+
+		stream := &scriptedStream{recv: []*v1.EchoSphereTransmissionServiceTransmitRequest{received}}
+
+		require.NoError(t, interceptor(nil, stream, nil, func(_ any, stream grpc.ServerStream) error {
+			return stream.RecvMsg(&v1.EchoSphereTransmissionServiceTransmitRequest{})
+		}))
+	}
+
+	ended := spans.Ended()
+	require.Len(t, ended, 4)
+
+	hash := attribute.String("echosphere.content_hash", v1.ContentHash("a secret message"))
+	parties := []attribute.KeyValue{
+		attribute.String("echosphere.frame", "ack"),
+		attribute.String("echosphere.from", "recipient"),
+		attribute.String("echosphere.to", "originator"),
+	}
+
+	assert.Equal(t, []attribute.KeyValue{attribute.String("echosphere.frame", "ack")}, ended[0].Attributes())
+	assert.Equal(t, append(parties, hash), ended[1].Attributes())
+	assert.Equal(t, append(parties, hash, attribute.String("echosphere.content", "a secret")), ended[2].Attributes())
+
+	// the whole frame is only kept when asked to
+	assert.Contains(t, ended[3].Attributes()[1].Value.AsString(), "a secret message")
+
+	for _, span := range ended[:3] {
+		for _, attr := range span.Attributes() {
+			assert.NotContains(t, attr.Value.Emit(), "a secret message")
+		}
+	}
+}
@@ -54,6 +54,20 @@ func (mr *MockUseCaseMockRecorder) AckHandler(ctx, cmd any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AckHandler", reflect.TypeOf((*MockUseCase)(nil).AckHandler), ctx, cmd)
 }
 
+// NackHandler mocks base method.
+func (m *MockUseCase) NackHandler(ctx context.Context, cmd usecase.NackCMD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NackHandler", ctx, cmd)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NackHandler indicates an expected call of NackHandler.
+func (mr *MockUseCaseMockRecorder) NackHandler(ctx, cmd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NackHandler", reflect.TypeOf((*MockUseCase)(nil).NackHandler), ctx, cmd)
+}
+
 // RegisterHandler mocks base method.
 func (m *MockUseCase) RegisterHandler(ctx context.Context, cmd usecase.RegisterCMD) error {
 	m.ctrl.T.Helper()
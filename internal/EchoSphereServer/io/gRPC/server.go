@@ -7,10 +7,15 @@ import (
 	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/gRPC/internal/middleware"
 	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 	"net"
 	"sync"
+	"sync/atomic"
 
 	v1 "github.com/k4l1ma/EchoSphere/api/v1"
 	"golang.org/x/sync/errgroup"
@@ -25,37 +30,80 @@ type Server struct {
 
 	listener   net.Listener
 	gRPCServer *grpc.Server
+	// health answers grpc.health.v1.Health with the readiness HealthCheck reports
+	health    *health.Server
+	healthMux sync.Mutex
 
 	logger      *zap.Logger
 	multiplexer core.RelayRouter
 	useCase     UseCase
 	serving     bool
 	servingMux  sync.Mutex
+	draining    atomic.Bool
+
+	// streams counts the open Transmit streams and goroutines the goroutines serving them
+	streams    atomic.Int64
+	goroutines atomic.Int64
+}
+
+// StreamStats is a snapshot of the streams of a Server.
+type StreamStats struct {
+	Streams    int64
+	Goroutines int64
 }
 
 type Config struct {
-	Listener net.Listener
-	Router   core.RelayRouter
-	Logger   *zap.Logger
-	UseCases UseCase
+	Listener  net.Listener
+	Router    core.RelayRouter
+	Logger    *zap.Logger
+	UseCases  UseCase
+	RateLimit *RateLimitConfig
+	Admission *AdmissionConfig
+	// Capture is how much of the frames the frame logs and spans keep, LogSampling thins out the frame logs.
+	Capture     v1.CapturePolicy
+	LogSampling v1.LogSampling
 }
 
+// RateLimitConfig configures the per owner ID and per remote address rate limits of Transmit frames.
+type RateLimitConfig = middleware.RateLimitConfig
+
+// AdmissionConfig configures the connection limits and load shedding thresholds of new streams.
+type AdmissionConfig = middleware.AdmissionConfig
+
 type UseCase interface {
 	RegisterHandler(ctx context.Context, cmd usecase.RegisterCMD) error
 	RelayHandler(ctx context.Context, cmd usecase.RelayCMD) error
 	AckHandler(ctx context.Context, cmd usecase.AckCMD) error
+	NackHandler(ctx context.Context, cmd usecase.NackCMD) error
 	UnregisterHandler(ctx context.Context, cmd usecase.UnregisterCMD) error
 }
 
 // NewServer creates a new gRPC server with the provided listener.
 func NewServer(cfg Config) *Server {
+	var interceptors []grpc.StreamServerInterceptor
+
+	if cfg.Admission != nil {
+		interceptors = append(interceptors, middleware.StreamAdmission(*cfg.Admission))
+	}
+
+	interceptors = append(
+		interceptors,
+		middleware.StreamIdentifier(),
+		middleware.StreamLogger(cfg.Logger, cfg.Capture, cfg.LogSampling),
+		middleware.StreamMetric(),
+		middleware.StreamTracing(cfg.Capture),
+	)
+
+	if cfg.RateLimit != nil {
+		interceptors = append(interceptors, middleware.StreamRateLimit(*cfg.RateLimit))
+	}
+
+	for i, interceptor := range interceptors {
+		interceptors[i] = transmitOnly(interceptor)
+	}
+
 	s := grpc.NewServer(
-		grpc.ChainStreamInterceptor(
-			middleware.StreamIdentifier(),
-			middleware.StreamLogger(cfg.Logger),
-			middleware.StreamMetric(),
-			middleware.StreamTracing(),
-		),
+		grpc.ChainStreamInterceptor(interceptors...),
 		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 	)
 
@@ -66,9 +114,14 @@ func NewServer(cfg Config) *Server {
 		useCase:     cfg.UseCases,
 		logger:      cfg.Logger,
 		serving:     false,
+		health:      health.NewServer(),
 	}
 
+	srv.observe()
+	srv.reportHealth()
+
 	v1.RegisterEchoSphereTransmissionServiceServer(s, srv)
+	healthpb.RegisterHealthServer(s, srv.health)
 
 	// Register server reflection service on your gRPC server
 	reflection.Register(s)
@@ -76,12 +129,82 @@ func NewServer(cfg Config) *Server {
 	return srv
 }
 
+// transmitOnly applies the interceptor to the Transmit streams only, the health and reflection streams
+// are neither admitted, rate limited nor observed as frames.
+func transmitOnly(interceptor grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if info == nil || info.FullMethod != v1.EchoSphereTransmissionService_Transmit_FullMethodName {
+			return handler(srv, stream)
+		}
+
+		return interceptor(srv, stream, info, handler)
+	}
+}
+
+// gauge is a gauge of the server, value is read when the gauge is observed.
+type gauge struct {
+	name, description string
+	value             func(ctx context.Context) (int64, error)
+}
+
+// observe registers the gauges of the streams and, when there is a router, of the registered relayers.
+func (s *Server) observe() {
+	meter := otel.GetMeterProvider().Meter("echosphere.io/server")
+
+	gauges := []gauge{
+		{"active_streams", "Transmit streams being served.", loaded(&s.streams)},
+		{"stream_goroutines", "Goroutines serving the Transmit streams.", loaded(&s.goroutines)},
+	}
+
+	if s.multiplexer != nil {
+		gauges = append(gauges, gauge{"registered_relayers", "Relayers registered to the relay router, leased or not.", s.registeredRelayers})
+	}
+
+	for _, gauge := range gauges {
+		_, err := meter.Int64ObservableGauge(
+			gauge.name,
+			metric.WithDescription(gauge.description),
+			metric.WithInt64Callback(func(ctx context.Context, observer metric.Int64Observer) error {
+				// a value that could not be read is not observed
+				value, err := gauge.value(ctx)
+				if err != nil {
+					return err
+				}
+
+				observer.Observe(value)
+
+				return nil
+			}),
+		)
+		if err != nil {
+			s.logger.Error("Failed to create gauge", zap.String("gauge", gauge.name), zap.Error(err))
+		}
+	}
+}
+
+func loaded(value *atomic.Int64) func(ctx context.Context) (int64, error) {
+	return func(context.Context) (int64, error) { return value.Load(), nil }
+}
+
+func (s *Server) registeredRelayers(ctx context.Context) (int64, error) {
+	pool, err := s.multiplexer.PoolStats(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return pool.Registered, nil
+}
+
 // Run starts the gRPC server and handles graceful shutdown.
 func (s *Server) Run(ctx context.Context) error {
 	g, ctx := errgroup.WithContext(ctx)
 
 	g.Go(func() error {
+		s.servingMux.Lock()
 		s.serving = true
+		s.servingMux.Unlock()
+
+		s.reportHealth()
 
 		return s.gRPCServer.Serve(s.listener)
 	})
@@ -92,9 +215,10 @@ func (s *Server) Run(ctx context.Context) error {
 		s.gRPCServer.Stop()
 
 		s.servingMux.Lock()
-		defer s.servingMux.Unlock()
-
 		s.serving = false
+		s.servingMux.Unlock()
+
+		s.reportHealth()
 
 		return nil
 	})
@@ -102,10 +226,31 @@ func (s *Server) Run(ctx context.Context) error {
 	return g.Wait()
 }
 
+// Drain stops accepting new streams, the open ones carry on until their clients are done.
+func (s *Server) Drain() {
+	if !s.draining.Swap(true) {
+		s.logger.Info("Draining, new streams are rejected")
+	}
+
+	s.reportHealth()
+}
+
+// Draining reports whether Drain was called.
+func (s *Server) Draining() bool {
+	return s.draining.Load()
+}
+
+// Stats returns a snapshot of the streams being served, a stream holds its goroutines until they have returned.
+func (s *Server) Stats() StreamStats {
+	return StreamStats{Streams: s.streams.Load(), Goroutines: s.goroutines.Load()}
+}
+
 func (s *Server) Shutdown() {
 	s.gRPCServer.GracefulStop()
 }
 
+// HealthCheck tells whether the server is ready for new streams: it is not before it serves, after it stopped and
+// while it drains.
 func (s *Server) HealthCheck() error {
 	s.servingMux.Lock()
 	defer s.servingMux.Unlock()
@@ -114,5 +259,25 @@ func (s *Server) HealthCheck() error {
 		return fmt.Errorf("not serving")
 	}
 
+	if s.draining.Load() {
+		return fmt.Errorf("draining")
+	}
+
 	return nil
 }
+
+// reportHealth sets the status of the gRPC health service, for the server as a whole and for
+// EchoSphereTransmissionService, to SERVING when HealthCheck passes and NOT_SERVING otherwise.
+func (s *Server) reportHealth() {
+	// the state is read and reported at once, so that a stale status does not overwrite a newer one
+	s.healthMux.Lock()
+	defer s.healthMux.Unlock()
+
+	status := healthpb.HealthCheckResponse_SERVING
+	if s.HealthCheck() != nil {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	s.health.SetServingStatus("", status)
+	s.health.SetServingStatus(v1.EchoSphereTransmissionService_ServiceDesc.ServiceName, status)
+}
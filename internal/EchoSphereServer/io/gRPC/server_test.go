@@ -4,17 +4,27 @@ import (
 	"context"
 	"github.com/google/uuid"
 	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/core"
 	essGRPC "github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/gRPC"
 	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/gRPC/internal/mocks"
 	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/multiplexer"
 	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/mock/gomock"
 	"go.uber.org/zap"
 	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
+	"io"
 	"net"
 	"testing"
 	"time"
@@ -32,6 +42,37 @@ type grpcIntegrationSuite struct {
 
 	useCase     *mocks.MockUseCase
 	multiplexer *multiplexer.Multiplexer
+	spans       *tracetest.SpanRecorder
+}
+
+// originator is the span of the client that sent the frames carrying a trace context.
+var originator = trace.NewSpanContext(trace.SpanContextConfig{
+	TraceID:    trace.TraceID{1},
+	SpanID:     trace.SpanID{1},
+	TraceFlags: trace.FlagsSampled,
+	Remote:     true,
+})
+
+// assertContinued asserts the span named name continues the trace of the originator, is linked to the span
+// of its stream and is the one whose trace context the use case was given.
+func (g *grpcIntegrationSuite) assertContinued(name string, traceContext map[string]string) {
+	var span sdktrace.ReadOnlySpan
+
+	g.Require().Eventually(func() bool {
+		for _, ended := range g.spans.Ended() {
+			if ended.Name() == name && ended.Parent().Equal(originator) {
+				span = ended
+
+				return true
+			}
+		}
+
+		return false
+	}, time.Second, time.Millisecond)
+
+	g.Equal(span.SpanContext().SpanID(), trace.SpanContextFromContext(v1.ExtractTraceContext(context.Background(), traceContext)).SpanID())
+	g.Require().Len(span.Links(), 1)
+	g.NotEqual(originator.TraceID(), span.Links()[0].SpanContext.TraceID(), "the stream has a trace of its own")
 }
 
 func (g *grpcIntegrationSuite) TearDownSuite() {
@@ -49,12 +90,16 @@ func (g *grpcIntegrationSuite) SetupSuite() {
 
 	g.multiplexer = multiplexer.New()
 
+	g.spans = tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(g.spans)))
+
 	g.SUT = essGRPC.NewServer(
 		essGRPC.Config{
-			Listener: listen,
-			Router:   g.multiplexer,
-			Logger:   zap.NewNop(),
-			UseCases: g.useCase,
+			Listener:  listen,
+			Router:    g.multiplexer,
+			Logger:    zap.NewNop(),
+			UseCases:  g.useCase,
+			RateLimit: &essGRPC.RateLimitConfig{PerOwner: 1, PerOwnerBurst: 1},
 		})
 
 	ctx, cancelFunc := context.WithCancel(context.Background())
@@ -109,8 +154,9 @@ func (g *grpcIntegrationSuite) TestTransmitMessage() {
 
 	msg := &v1.EchoSphereTransmissionServiceTransmitRequest{IncomingData: &v1.EchoSphereTransmissionServiceTransmitRequest_Message{
 		Message: &v1.Message{
-			From:    clientID.String(),
-			Content: "x",
+			From:         clientID.String(),
+			Content:      "x",
+			TraceContext: v1.InjectTraceContext(trace.ContextWithRemoteSpanContext(context.Background(), originator)),
 		},
 	}}
 	err = transmit.Send(msg)
@@ -119,6 +165,7 @@ func (g *grpcIntegrationSuite) TestTransmitMessage() {
 	cmd := <-x
 	g.Require().Equal(msg.GetMessage().GetFrom(), cmd.From)
 	g.Require().Equal(msg.GetMessage().GetContent(), cmd.Content)
+	g.assertContinued("Relay message", cmd.TraceContext)
 
 	err = transmit.CloseSend()
 	g.Require().NoError(err)
@@ -154,9 +201,10 @@ func (g *grpcIntegrationSuite) TestTransmitAck() {
 
 	msg := &v1.EchoSphereTransmissionServiceTransmitRequest{IncomingData: &v1.EchoSphereTransmissionServiceTransmitRequest_Ack{
 		Ack: &v1.Ack{
-			From:    clientID2.String(),
-			To:      clientID.String(),
-			Content: "x",
+			From:         clientID2.String(),
+			To:           clientID.String(),
+			Content:      "x",
+			TraceContext: v1.InjectTraceContext(trace.ContextWithRemoteSpanContext(context.Background(), originator)),
 		},
 	}}
 	err = transmit.Send(msg)
@@ -165,11 +213,371 @@ func (g *grpcIntegrationSuite) TestTransmitAck() {
 	cmd := <-x
 	g.Require().Equal(msg.GetAck().GetFrom(), cmd.From)
 	g.Require().Equal(msg.GetAck().GetContent(), cmd.Content)
+	g.assertContinued("Forward ack", cmd.TraceContext)
 
 	err = transmit.CloseSend()
 	g.Require().NoError(err)
 }
 
+func (g *grpcIntegrationSuite) TestTransmitNack() {
+	transmit, err := g.client1.Transmit(context.Background())
+	g.Require().NoError(err)
+
+	x := make(chan usecase.NackCMD, 1)
+	unregistered := make(chan usecase.UnregisterCMD, 1)
+
+	g.useCase.EXPECT().UnregisterHandler(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+		func(_ any, cmd usecase.UnregisterCMD) error {
+			unregistered <- cmd
+
+			return nil
+		},
+	)
+
+	g.useCase.EXPECT().NackHandler(gomock.Any(), gomock.Any()).DoAndReturn(func(_ any, cmd usecase.NackCMD) error {
+		x <- cmd
+
+		return nil
+	})
+
+	msg := &v1.EchoSphereTransmissionServiceTransmitRequest{IncomingData: &v1.EchoSphereTransmissionServiceTransmitRequest_Nack{
+		Nack: &v1.Nack{
+			From:    uuid.NewString(),
+			To:      uuid.NewString(),
+			Content: "x",
+			Reason:  v1.NackReason_NACK_REASON_TIMEOUT,
+		},
+	}}
+	g.Require().NoError(transmit.Send(msg))
+
+	cmd := <-x
+	g.Require().Equal(msg.GetNack().GetTo(), cmd.To)
+	g.Require().Equal(msg.GetNack().GetContent(), cmd.Content)
+	// clients can only refuse a message
+	g.Require().Equal(v1.NackReason_NACK_REASON_REJECTED, cmd.Reason)
+
+	g.Require().NoError(transmit.CloseSend())
+
+	// the nacking client is the one unregistered once its stream ends
+	select {
+	case cmd := <-unregistered:
+		g.Require().Equal(usecase.UnregisterCMD{OwnerID: msg.GetNack().GetFrom()}, cmd)
+	case <-time.After(time.Second):
+		g.Fail("the nacking client was not unregistered")
+	}
+}
+
+func (g *grpcIntegrationSuite) TestTransmitRateLimited() {
+	clientID, err := uuid.NewV7()
+	g.Require().NoError(err)
+
+	transmit, err := g.client1.Transmit(context.Background())
+	g.Require().NoError(err)
+
+	closeChan := make(chan struct{})
+
+	g.useCase.EXPECT().AckHandler(gomock.Any(), gomock.Any()).Times(1)
+	g.useCase.EXPECT().UnregisterHandler(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(any, any) error {
+		close(closeChan)
+		return nil
+	})
+
+	msg := &v1.EchoSphereTransmissionServiceTransmitRequest{IncomingData: &v1.EchoSphereTransmissionServiceTransmitRequest_Ack{
+		Ack: &v1.Ack{
+			From:    clientID.String(),
+			To:      uuid.NewString(),
+			Content: "x",
+		},
+	}}
+
+	g.Require().NoError(transmit.Send(msg))
+	g.Require().NoError(transmit.Send(msg))
+
+	// the second frame is over the limit, it is answered and the stream survives
+	recv, err := transmit.Recv()
+	g.Require().NoError(err)
+	g.Require().Equal(v1.ErrorCode_ERROR_CODE_RATE_LIMITED, recv.GetError().GetCode())
+	g.Require().Contains(recv.GetError().GetMessage(), clientID.String())
+
+	g.Require().NoError(transmit.CloseSend())
+	g.Eventually(func() bool {
+		select {
+		case <-closeChan:
+			return true
+		default:
+			return false
+		}
+	}, time.Second, time.Millisecond, "did not receive cancel signal")
+}
+
+func (g *grpcIntegrationSuite) TestTransmitUnknownAckTarget() {
+	transmit, err := g.client1.Transmit(context.Background())
+	g.Require().NoError(err)
+
+	closeChan := make(chan struct{})
+
+	g.useCase.EXPECT().AckHandler(gomock.Any(), gomock.Any()).Return(core.ErrFailedToGetRelayer)
+	g.useCase.EXPECT().UnregisterHandler(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(any, any) error {
+		close(closeChan)
+		return nil
+	})
+
+	msg := &v1.EchoSphereTransmissionServiceTransmitRequest{IncomingData: &v1.EchoSphereTransmissionServiceTransmitRequest_Ack{
+		Ack: &v1.Ack{
+			From:    uuid.NewString(),
+			To:      uuid.NewString(),
+			Content: "x",
+		},
+	}}
+
+	g.Require().NoError(transmit.Send(msg))
+
+	recv, err := transmit.Recv()
+	g.Require().NoError(err)
+	g.Require().Equal(v1.ErrorCode_ERROR_CODE_UNKNOWN_ACK_TARGET, recv.GetError().GetCode())
+	g.Require().Equal(msg.GetAck().GetTo(), recv.GetError().GetOffendingAck().GetTo())
+
+	g.Require().NoError(transmit.CloseSend())
+	g.Eventually(func() bool {
+		select {
+		case <-closeChan:
+			return true
+		default:
+			return false
+		}
+	}, time.Second, time.Millisecond, "did not receive cancel signal")
+}
+
+func (g *grpcIntegrationSuite) TestTransmitRelayerUnavailable() {
+	transmit, err := g.client1.Transmit(context.Background())
+	g.Require().NoError(err)
+
+	closeChan := make(chan struct{})
+
+	g.useCase.EXPECT().RegisterHandler(gomock.Any(), gomock.Any()).Times(1)
+	// the relayer of the sender is leased by another stream, its echo cannot be sent
+	g.useCase.EXPECT().RelayHandler(gomock.Any(), gomock.Any()).Return(core.ErrFailedToGetRelayer)
+	g.useCase.EXPECT().UnregisterHandler(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(any, any) error {
+		close(closeChan)
+		return nil
+	})
+
+	msg := &v1.EchoSphereTransmissionServiceTransmitRequest{IncomingData: &v1.EchoSphereTransmissionServiceTransmitRequest_Message{
+		Message: &v1.Message{From: uuid.NewString(), Content: "x"},
+	}}
+
+	g.Require().NoError(transmit.Send(msg))
+
+	recv, err := transmit.Recv()
+	g.Require().NoError(err)
+	g.Require().Equal(v1.ErrorCode_ERROR_CODE_RELAY_FAILED, recv.GetError().GetCode())
+	g.Require().Equal(msg.GetMessage().GetContent(), recv.GetError().GetOffendingMessage().GetContent())
+
+	g.Require().NoError(transmit.CloseSend())
+	g.Eventually(func() bool {
+		select {
+		case <-closeChan:
+			return true
+		default:
+			return false
+		}
+	}, time.Second, time.Millisecond, "did not receive cancel signal")
+}
+
+func (g *grpcIntegrationSuite) TestTransmitInvalidFrame() {
+	transmit, err := g.client1.Transmit(context.Background())
+	g.Require().NoError(err)
+
+	g.Require().NoError(transmit.Send(&v1.EchoSphereTransmissionServiceTransmitRequest{}))
+
+	recv, err := transmit.Recv()
+	g.Require().NoError(err)
+	g.Require().Equal(v1.ErrorCode_ERROR_CODE_INVALID_FRAME, recv.GetError().GetCode())
+
+	// the frame did not tell who the client is, the stream ends without unregistering anyone
+	g.Require().NoError(transmit.CloseSend())
+
+	_, err = transmit.Recv()
+	g.Require().ErrorIs(err, io.EOF)
+}
+
+func (g *grpcIntegrationSuite) TestTransmitTooManyRelayers() {
+	transmit, err := g.client1.Transmit(context.Background())
+	g.Require().NoError(err)
+
+	g.useCase.EXPECT().RegisterHandler(gomock.Any(), gomock.Any()).Return(core.ErrTooManyRelayers)
+	g.useCase.EXPECT().UnregisterHandler(gomock.Any(), gomock.Any()).Return(core.ErrFailedToGetRelayer)
+
+	g.Require().NoError(transmit.Send(&v1.EchoSphereTransmissionServiceTransmitRequest{
+		IncomingData: &v1.EchoSphereTransmissionServiceTransmitRequest_Message{
+			Message: &v1.Message{From: uuid.NewString(), Content: "x"},
+		},
+	}))
+
+	_, err = transmit.Recv()
+	g.Require().Equal(codes.ResourceExhausted, status.Code(err))
+}
+
+func (g *grpcIntegrationSuite) TestTransmitDisconnected() {
+	transmit, err := g.client1.Transmit(context.Background())
+	g.Require().NoError(err)
+
+	registered := make(chan usecase.RegisterCMD, 1)
+
+	g.useCase.EXPECT().RegisterHandler(gomock.Any(), gomock.Any()).DoAndReturn(func(_ any, cmd usecase.RegisterCMD) error {
+		registered <- cmd
+
+		return nil
+	})
+	g.useCase.EXPECT().RelayHandler(gomock.Any(), gomock.Any())
+
+	g.Require().NoError(transmit.Send(&v1.EchoSphereTransmissionServiceTransmitRequest{
+		IncomingData: &v1.EchoSphereTransmissionServiceTransmitRequest_Message{
+			Message: &v1.Message{From: uuid.NewString(), Content: "x"},
+		},
+	}))
+
+	disconnecter, ok := core.AsDisconnecter((<-registered).StreamSender)
+	g.Require().True(ok)
+	g.Positive(g.SUT.Stats().Streams)
+
+	// a kicked client was unregistered already, the stream just ends
+	disconnecter.Disconnect()
+
+	_, err = transmit.Recv()
+	g.Require().Equal(codes.Aborted, status.Code(err))
+
+	// the receiving goroutine outlives the handler until the stream is torn down, then nothing is left
+	g.Eventually(func() bool { return g.SUT.Stats() == essGRPC.StreamStats{} }, time.Second, time.Millisecond)
+}
+
 func TestGRPCLayer(t *testing.T) {
 	suite.Run(t, new(grpcIntegrationSuite))
 }
+
+func TestHealthCheck_Readiness(t *testing.T) {
+	srv := essGRPC.NewServer(essGRPC.Config{
+		Listener: bufconn.Listen(1024),
+		Router:   multiplexer.New(),
+		Logger:   zap.NewNop(),
+	})
+
+	require.EqualError(t, srv.HealthCheck(), "not serving")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error, 1)
+
+	go func() { stopped <- srv.Run(ctx) }()
+
+	require.Eventually(t, func() bool { return srv.HealthCheck() == nil }, time.Second, time.Millisecond)
+
+	// a draining server is up but not ready for new streams
+	srv.Drain()
+	require.EqualError(t, srv.HealthCheck(), "draining")
+
+	cancel()
+	require.NoError(t, <-stopped)
+	require.EqualError(t, srv.HealthCheck(), "not serving")
+}
+
+func TestHealthService(t *testing.T) {
+	listener := bufconn.Listen(1024 * 1024)
+	srv := essGRPC.NewServer(essGRPC.Config{
+		Listener: listener,
+		Router:   multiplexer.New(),
+		Logger:   zap.NewNop(),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() { _ = srv.Run(ctx) }() //nolint:errcheck
+
+	conn, err := grpc.NewClient(
+		"passthrough:///server.echosphere.io",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := healthpb.NewHealthClient(conn)
+
+	statusOf := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		res, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			return healthpb.HealthCheckResponse_UNKNOWN
+		}
+
+		return res.GetStatus()
+	}
+
+	service := v1.EchoSphereTransmissionService_ServiceDesc.ServiceName
+
+	require.Eventually(t, func() bool {
+		return statusOf("") == healthpb.HealthCheckResponse_SERVING &&
+			statusOf(service) == healthpb.HealthCheckResponse_SERVING
+	}, time.Second, time.Millisecond)
+
+	srv.Drain()
+
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, statusOf(""))
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, statusOf(service))
+}
+
+func TestHealthService_Watch(t *testing.T) {
+	listener := bufconn.Listen(1024 * 1024)
+	srv := essGRPC.NewServer(essGRPC.Config{
+		Listener:  listener,
+		Router:    multiplexer.New(),
+		Logger:    zap.NewNop(),
+		RateLimit: &essGRPC.RateLimitConfig{PerAddress: 1, PerAddressBurst: 1},
+		Admission: &essGRPC.AdmissionConfig{MaxStreams: 1},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() { _ = srv.Run(ctx) }() //nolint:errcheck
+
+	conn, err := grpc.NewClient(
+		"passthrough:///server.echosphere.io",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := healthpb.NewHealthClient(conn)
+	service := v1.EchoSphereTransmissionService_ServiceDesc.ServiceName
+
+	require.Eventually(t, func() bool {
+		res, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+
+		return err == nil && res.GetStatus() == healthpb.HealthCheckResponse_SERVING
+	}, time.Second, time.Millisecond)
+
+	// the health streams go through neither the frame interceptors nor the admission and rate limits
+	watches := make([]healthpb.Health_WatchClient, 2)
+
+	for i := range watches {
+		watches[i], err = client.Watch(ctx, &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+
+		res, err := watches[i].Recv()
+		require.NoError(t, err)
+		require.Equal(t, healthpb.HealthCheckResponse_SERVING, res.GetStatus())
+	}
+
+	srv.Drain()
+
+	for _, watch := range watches {
+		res, err := watch.Recv()
+		require.NoError(t, err)
+		require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, res.GetStatus())
+	}
+}
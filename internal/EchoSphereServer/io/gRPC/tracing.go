@@ -0,0 +1,38 @@
+package grpc
+
+import (
+	"context"
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "server.echosphere.io/relay"
+
+// startFrameSpan starts the span of a relayed frame as a child of the span of the client that sent it,
+// so the trace follows the message across the clients, and links it to the span of the stream it arrived on.
+// Without a trace context in the frame the span is a child of the stream's span.
+func startFrameSpan(ctx context.Context, name string, traceContext map[string]string, attrs ...attribute.KeyValue) (context.Context, trace.Span) { //nolint:ireturn
+	opts := []trace.SpanStartOption{trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attrs...)}
+
+	stream := trace.SpanContextFromContext(ctx)
+	parent := v1.ExtractTraceContext(ctx, traceContext)
+
+	if stream.IsValid() && !trace.SpanContextFromContext(parent).Equal(stream) {
+		opts = append(opts, trace.WithLinks(trace.Link{SpanContext: stream}))
+	}
+
+	return otel.Tracer(tracerName).Start(parent, name, opts...)
+}
+
+// endFrameSpan ends the span of a frame, a failure to handle the frame is recorded on it.
+func endFrameSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -0,0 +1,126 @@
+package heavyhitters
+
+import (
+	"cmp"
+	"hash/maphash"
+	"slices"
+	"strings"
+)
+
+// countMinSketch estimates how many times each key was added in depth rows of width counters. An estimate is never
+// below the real count and exceeds it by at most e/width of the total with a probability of 1-e^-depth.
+type countMinSketch struct {
+	seeds    []maphash.Seed
+	counters [][]uint64
+}
+
+func newCountMinSketch(width, depth int) *countMinSketch {
+	s := &countMinSketch{seeds: make([]maphash.Seed, depth), counters: make([][]uint64, depth)}
+
+	for row := range depth {
+		s.seeds[row] = maphash.MakeSeed()
+		s.counters[row] = make([]uint64, width)
+	}
+
+	return s
+}
+
+// add counts n more of key and returns its estimate.
+func (s *countMinSketch) add(key string, n uint64) uint64 {
+	estimate := ^uint64(0)
+
+	for row, counters := range s.counters {
+		i := maphash.String(s.seeds[row], key) % uint64(len(counters))
+		counters[i] += n
+
+		estimate = min(estimate, counters[i])
+	}
+
+	return estimate
+}
+
+func (s *countMinSketch) reset() {
+	for _, counters := range s.counters {
+		clear(counters)
+	}
+}
+
+// Hitter is a key and the estimate of its count.
+type Hitter struct {
+	OwnerID string `json:"owner_id"`
+	Count   uint64 `json:"count"`
+}
+
+// topK keeps the k keys with the highest estimates seen so far. A key enters once its estimate is above the lowest
+// kept, so the keys counted often enough are kept whatever the number of the others.
+type topK struct {
+	k      int
+	counts map[string]uint64
+	// lowest is the kept key with the lowest estimate, valid once k keys are kept
+	lowest string
+}
+
+func newTopK(k int) *topK {
+	return &topK{k: k, counts: make(map[string]uint64, k)}
+}
+
+// offer records the estimate of key.
+func (t *topK) offer(key string, estimate uint64) {
+	if _, ok := t.counts[key]; ok {
+		t.counts[key] = estimate
+
+		if key == t.lowest {
+			t.findLowest()
+		}
+
+		return
+	}
+
+	if len(t.counts) < t.k {
+		t.counts[key] = estimate
+
+		if len(t.counts) == t.k {
+			t.findLowest()
+		}
+
+		return
+	}
+
+	if t.k == 0 || estimate <= t.counts[t.lowest] {
+		return
+	}
+
+	delete(t.counts, t.lowest)
+	t.counts[key] = estimate
+	t.findLowest()
+}
+
+func (t *topK) findLowest() {
+	first := true
+
+	for key, count := range t.counts {
+		if first || count < t.counts[t.lowest] {
+			t.lowest, first = key, false
+		}
+	}
+}
+
+// hitters returns the kept keys, the highest estimates first.
+func (t *topK) hitters() []Hitter {
+	hitters := make([]Hitter, 0, len(t.counts))
+
+	for key, count := range t.counts {
+		hitters = append(hitters, Hitter{OwnerID: key, Count: count})
+	}
+
+	slices.SortFunc(hitters, func(a, b Hitter) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), strings.Compare(a.OwnerID, b.OwnerID))
+	})
+
+	return hitters
+}
+
+func (t *topK) reset() {
+	clear(t.counts)
+	t.lowest = ""
+}
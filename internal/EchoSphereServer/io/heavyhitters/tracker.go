@@ -0,0 +1,173 @@
+// Package heavyhitters finds the owner IDs sending or receiving the most frames, without a counter per owner ID.
+package heavyhitters
+
+import (
+	"context"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
+	"sync"
+	"time"
+)
+
+// Config sizes the sketches of a Tracker, the zero fields take the defaults below.
+type Config struct {
+	// K is the number of owner IDs kept by dimension, 10 by default.
+	K int
+	// Width and Depth size the count-min sketches, 2048 counters in 4 rows by default. The counts exceed the real
+	// ones by at most e/Width of the frames counted in the window, unless unlucky with a probability of e^-Depth.
+	Width int
+	Depth int
+	// Window is how long the counts run before starting over, 0 never starts over.
+	Window time.Duration
+	// Now tells the time the windows are measured with, time.Now when nil.
+	Now func() time.Time
+}
+
+// TopK is the owner IDs counted the most in a window, the highest counts first.
+type TopK struct {
+	Since          time.Time `json:"since"`
+	MessagesSent   []Hitter  `json:"messages_sent"`
+	AcksSent       []Hitter  `json:"acks_sent"`
+	RelaysReceived []Hitter  `json:"relays_received"`
+}
+
+// Snapshot is the top-K of the current window and, once a window has ended, of the previous one.
+type Snapshot struct {
+	Current  TopK  `json:"current"`
+	Previous *TopK `json:"previous,omitempty"`
+}
+
+// Tracker counts the messages and the acks sent by each owner ID and the relays each receives, keeping the top-K of
+// each.
+//
+// It is meant to be a synchronous subscriber of the usecase.Bus, a count is a few hashes.
+type Tracker struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu             sync.Mutex
+	since          time.Time
+	previous       *TopK
+	messagesSent   *dimension
+	acksSent       *dimension
+	relaysReceived *dimension
+}
+
+// dimension is the sketch and the top-K of one of the counts.
+type dimension struct {
+	sketch *countMinSketch
+	top    *topK
+}
+
+func (d *dimension) add(ownerID string) {
+	if ownerID != "" {
+		d.top.offer(ownerID, d.sketch.add(ownerID, 1))
+	}
+}
+
+func (d *dimension) reset() {
+	d.sketch.reset()
+	d.top.reset()
+}
+
+// NewTracker creates a new Tracker with the sketches of cfg.
+func NewTracker(cfg Config) *Tracker {
+	if cfg.K <= 0 {
+		cfg.K = 10
+	}
+
+	if cfg.Width <= 0 {
+		cfg.Width = 2048
+	}
+
+	if cfg.Depth <= 0 {
+		cfg.Depth = 4
+	}
+
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+
+	newDimension := func() *dimension {
+		return &dimension{sketch: newCountMinSketch(cfg.Width, cfg.Depth), top: newTopK(cfg.K)}
+	}
+
+	return &Tracker{
+		window:         cfg.Window,
+		now:            cfg.Now,
+		since:          cfg.Now(),
+		messagesSent:   newDimension(),
+		acksSent:       newDimension(),
+		relaysReceived: newDimension(),
+	}
+}
+
+// Handle counts the message of a relay for its sender and its recipient, and the ack of a forwarded ack for its
+// sender. A dropped message or ack is still counted for its sender, nobody received it.
+func (t *Tracker) Handle(_ context.Context, event usecase.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.rotate()
+
+	switch event.Type {
+	case usecase.EventRelayed:
+		t.messagesSent.add(event.OwnerID)
+		t.relaysReceived.add(event.PeerID)
+	case usecase.EventAckForwarded:
+		t.acksSent.add(event.OwnerID)
+	case usecase.EventDropped:
+		switch event.Dropped {
+		case usecase.EventRelayed:
+			t.messagesSent.add(event.OwnerID)
+		case usecase.EventAckForwarded:
+			t.acksSent.add(event.OwnerID)
+		}
+	case usecase.EventRegistered, usecase.EventUnregistered, usecase.EventKicked, usecase.EventNackForwarded:
+	}
+}
+
+// Snapshot returns the top-K of the current window and of the previous one.
+func (t *Tracker) Snapshot() Snapshot {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.rotate()
+
+	return Snapshot{Current: t.top(), Previous: t.previous}
+}
+
+// rotate starts a new window once the current one has ended.
+func (t *Tracker) rotate() {
+	if t.window <= 0 {
+		return
+	}
+
+	windows := int64(t.now().Sub(t.since) / t.window)
+	if windows == 0 {
+		return
+	}
+
+	previous := t.top()
+
+	t.messagesSent.reset()
+	t.acksSent.reset()
+	t.relaysReceived.reset()
+
+	if windows > 1 {
+		// the windows since the last event counted none
+		t.since = t.since.Add(time.Duration(windows-1) * t.window)
+		previous = t.top()
+	}
+
+	t.previous = &previous
+	t.since = t.since.Add(t.window)
+}
+
+func (t *Tracker) top() TopK {
+	return TopK{
+		Since:          t.since,
+		MessagesSent:   t.messagesSent.top.hitters(),
+		AcksSent:       t.acksSent.top.hitters(),
+		RelaysReceived: t.relaysReceived.top.hitters(),
+	}
+}
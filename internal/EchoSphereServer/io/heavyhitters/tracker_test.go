@@ -0,0 +1,113 @@
+package heavyhitters_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/heavyhitters"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func relayed(from, to string) usecase.Event {
+	return usecase.Event{Type: usecase.EventRelayed, OwnerID: from, PeerID: to}
+}
+
+func TestTracker_TopK(t *testing.T) {
+	tracker := heavyhitters.NewTracker(heavyhitters.Config{K: 3, Width: 1 << 16})
+	ctx := context.Background()
+
+	// the noisy clients among many quiet ones
+	for i := range 10_000 {
+		tracker.Handle(ctx, relayed(fmt.Sprintf("quiet-%d", i), "recipient"))
+	}
+
+	for range 500 {
+		tracker.Handle(ctx, relayed("flooder", "recipient-0"))
+		tracker.Handle(ctx, usecase.Event{Type: usecase.EventAckForwarded, OwnerID: "acker", PeerID: "flooder"})
+	}
+
+	for range 200 {
+		tracker.Handle(ctx, relayed("chatty", "recipient"))
+	}
+
+	// neither a message nor an ack sent
+	tracker.Handle(ctx, usecase.Event{Type: usecase.EventNackForwarded, OwnerID: "nacker", PeerID: "flooder"})
+	tracker.Handle(ctx, usecase.Event{Type: usecase.EventDropped, Dropped: usecase.EventNackForwarded, OwnerID: "dropped"})
+
+	top := tracker.Snapshot().Current
+
+	// the counts are upper bounds, by less than e/65536 of the 10 700 messages but for bad luck
+	const slack = 2
+
+	require.Len(t, top.MessagesSent, 3)
+	assert.Equal(t, "flooder", top.MessagesSent[0].OwnerID)
+	assert.InDelta(t, 500, top.MessagesSent[0].Count, slack)
+	assert.Equal(t, "chatty", top.MessagesSent[1].OwnerID)
+	assert.InDelta(t, 200, top.MessagesSent[1].Count, slack)
+
+	require.NotEmpty(t, top.RelaysReceived)
+	assert.Equal(t, "recipient", top.RelaysReceived[0].OwnerID)
+	assert.InDelta(t, 10_200, top.RelaysReceived[0].Count, slack)
+	assert.Equal(t, "recipient-0", top.RelaysReceived[1].OwnerID)
+
+	assert.Equal(t, []heavyhitters.Hitter{{OwnerID: "acker", Count: 500}}, top.AcksSent)
+}
+
+func TestTracker_Dropped(t *testing.T) {
+	tracker := heavyhitters.NewTracker(heavyhitters.Config{K: 3})
+	ctx := context.Background()
+
+	// sent all the same, without anybody receiving them
+	for range 3 {
+		tracker.Handle(ctx, usecase.Event{Type: usecase.EventDropped, Dropped: usecase.EventRelayed, OwnerID: "sender"})
+	}
+
+	tracker.Handle(ctx, relayed("sender", "recipient"))
+	tracker.Handle(ctx, usecase.Event{Type: usecase.EventDropped, Dropped: usecase.EventAckForwarded, OwnerID: "acker", PeerID: "sender"})
+
+	top := tracker.Snapshot().Current
+
+	assert.Equal(t, []heavyhitters.Hitter{{OwnerID: "sender", Count: 4}}, top.MessagesSent)
+	assert.Equal(t, []heavyhitters.Hitter{{OwnerID: "recipient", Count: 1}}, top.RelaysReceived)
+	assert.Equal(t, []heavyhitters.Hitter{{OwnerID: "acker", Count: 1}}, top.AcksSent)
+}
+
+func TestTracker_Window(t *testing.T) {
+	start := time.Now()
+	now := start
+
+	tracker := heavyhitters.NewTracker(heavyhitters.Config{Window: time.Minute, Now: func() time.Time { return now }})
+
+	ctx := context.Background()
+
+	tracker.Handle(ctx, relayed("sender", "recipient"))
+
+	snapshot := tracker.Snapshot()
+	assert.Nil(t, snapshot.Previous)
+	assert.Equal(t, []heavyhitters.Hitter{{OwnerID: "sender", Count: 1}}, snapshot.Current.MessagesSent)
+
+	// the counts start over with the next window
+	now = start.Add(90 * time.Second)
+
+	tracker.Handle(ctx, relayed("other", "recipient"))
+
+	snapshot = tracker.Snapshot()
+	require.NotNil(t, snapshot.Previous)
+	assert.Equal(t, start, snapshot.Previous.Since)
+	assert.Equal(t, []heavyhitters.Hitter{{OwnerID: "sender", Count: 1}}, snapshot.Previous.MessagesSent)
+	assert.Equal(t, start.Add(time.Minute), snapshot.Current.Since)
+	assert.Equal(t, []heavyhitters.Hitter{{OwnerID: "other", Count: 1}}, snapshot.Current.MessagesSent)
+
+	// the windows without events are empty
+	now = start.Add(5 * time.Minute)
+
+	snapshot = tracker.Snapshot()
+	require.NotNil(t, snapshot.Previous)
+	assert.Equal(t, start.Add(4*time.Minute), snapshot.Previous.Since)
+	assert.Empty(t, snapshot.Previous.MessagesSent)
+	assert.Equal(t, start.Add(5*time.Minute), snapshot.Current.Since)
+	assert.Empty(t, snapshot.Current.MessagesSent)
+}
@@ -0,0 +1,39 @@
+// Package metrics records the metrics of the lifecycle events published by the use cases.
+package metrics
+
+import (
+	"context"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// AckLatencyHistogram is the name of the relay to ack latency histogram, in seconds.
+const AckLatencyHistogram = "relay_ack_latency_seconds"
+
+// Recorder records the events it handles.
+//
+// It is meant to be a synchronous subscriber of the usecase.Bus, recording is cheap and no event is lost.
+type Recorder struct {
+	ackLatency metric.Float64Histogram
+}
+
+// NewRecorder creates a new Recorder with its instruments on meter.
+func NewRecorder(meter metric.Meter) (*Recorder, error) {
+	ackLatency, err := meter.Float64Histogram(
+		AckLatencyHistogram,
+		metric.WithDescription("Time from a message relayed to a recipient to its ack forwarded to the originator."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Recorder{ackLatency: ackLatency}, nil
+}
+
+// Handle records the latency of the acks relayed by this server.
+func (r *Recorder) Handle(ctx context.Context, event usecase.Event) {
+	if event.Type == usecase.EventAckForwarded && event.Latency > 0 {
+		r.ackLatency.Record(ctx, event.Latency.Seconds())
+	}
+}
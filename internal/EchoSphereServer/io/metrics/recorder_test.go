@@ -0,0 +1,41 @@
+package metrics_test
+
+import (
+	"context"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/metrics"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"testing"
+	"time"
+)
+
+func TestRecorder_AckLatency(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+
+	recorder, err := metrics.NewRecorder(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	recorder.Handle(ctx, usecase.Event{Type: usecase.EventAckForwarded, Latency: 250 * time.Millisecond})
+	recorder.Handle(ctx, usecase.Event{Type: usecase.EventAckForwarded, Latency: 750 * time.Millisecond})
+	// neither relayed by this server nor an ack
+	recorder.Handle(ctx, usecase.Event{Type: usecase.EventAckForwarded})
+	recorder.Handle(ctx, usecase.Event{Type: usecase.EventNackForwarded, Latency: time.Second})
+
+	var data metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &data))
+	require.Len(t, data.ScopeMetrics, 1)
+	require.Len(t, data.ScopeMetrics[0].Metrics, 1)
+
+	histogram := data.ScopeMetrics[0].Metrics[0]
+	assert.Equal(t, metrics.AckLatencyHistogram, histogram.Name)
+
+	points := histogram.Data.(metricdata.Histogram[float64]).DataPoints
+	require.Len(t, points, 1)
+	assert.Equal(t, uint64(2), points[0].Count)
+	assert.InDelta(t, 1.0, points[0].Sum, 1e-9)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/core"
 	"github.com/samber/lo"
 	"golang.org/x/exp/rand"
+	"slices"
 	"sync"
 	"time"
 )
@@ -25,12 +26,39 @@ func (n NoopRelayer) SendMsg(context.Context, any) error {
 type Relayers struct {
 	mu       sync.Mutex
 	relayers map[string]core.Messager
+	// leased are the acquired relayers, withdrawn the leased ones unregistered or replaced meanwhile, which are
+	// dropped instead of coming back on release. An owner reconnecting several times while leased has several.
+	leased    map[string]core.Messager
+	withdrawn map[string][]core.Messager
+
+	registrations uint64
+	acquisitions  uint64
+	releases      uint64
+}
+
+// Stats is a snapshot of the relayers of a Multiplexer, the counters are totals since it was created.
+type Stats struct {
+	Available     int
+	Leased        int
+	Registrations uint64
+	Acquisitions  uint64
+	Releases      uint64
+}
+
+// State is a dump of the relayers of a Multiplexer, Available are the owner IDs whose relayer can be acquired.
+type State struct {
+	Available     []string `json:"available"`
+	Registrations uint64   `json:"registrations"`
+	Acquisitions  uint64   `json:"acquisitions"`
+	Releases      uint64   `json:"releases"`
 }
 
 // NewRelayers creates a new instance of Relayers.
 func NewRelayers() *Relayers {
 	return &Relayers{
-		relayers: make(map[string]core.Messager),
+		relayers:  make(map[string]core.Messager),
+		leased:    make(map[string]core.Messager),
+		withdrawn: make(map[string][]core.Messager),
 	}
 }
 
@@ -57,6 +85,8 @@ func (r *Relayers) acquire(ownerID string) (core.Messager, error) {
 	}
 
 	delete(r.relayers, ownerID)
+	r.leased[ownerID] = relayer
+	r.acquisitions++
 
 	return relayer, nil
 }
@@ -66,10 +96,6 @@ func (r *Relayers) acquireRandom(excludeRelayer string) (string, core.Messager,
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if len(r.relayers) <= 1 {
-		return noOpID, NoopRelayer{}, fmt.Errorf("%w: %s", core.ErrFailedToGetRelayer, "no relayers available")
-	}
-
 	keys := lo.Filter(
 		lo.Keys(r.relayers),
 		func(ownerID string, _ int) bool {
@@ -77,12 +103,19 @@ func (r *Relayers) acquireRandom(excludeRelayer string) (string, core.Messager,
 		},
 	)
 
+	// the excluded relayer is not necessarily registered here, e.g. when a peer asks for a relayer
+	if len(keys) == 0 {
+		return noOpID, NoopRelayer{}, fmt.Errorf("%w: %s", core.ErrFailedToGetRelayer, "no relayers available")
+	}
+
 	rand.Seed(uint64(time.Now().UnixNano()))
 	randomKey := keys[rand.Intn(len(keys))]
 
 	relayer := r.relayers[randomKey]
 
 	delete(r.relayers, randomKey)
+	r.leased[randomKey] = relayer
+	r.acquisitions++
 
 	return randomKey, relayer, nil
 }
@@ -96,7 +129,19 @@ func (r *Relayers) release(ownerID string, relayer core.Messager) {
 		return
 	}
 
+	if i := slices.Index(r.withdrawn[ownerID], relayer); i >= 0 {
+		r.withdrawn[ownerID] = slices.Delete(r.withdrawn[ownerID], i, i+1)
+
+		if len(r.withdrawn[ownerID]) == 0 {
+			delete(r.withdrawn, ownerID)
+		}
+
+		return
+	}
+
+	delete(r.leased, ownerID)
 	r.relayers[ownerID] = relayer
+	r.releases++
 }
 
 // Register adds a new relayer to the collection under the given ownerID.
@@ -104,7 +149,67 @@ func (r *Relayers) register(ownerID string, relayer core.Messager) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	// the relayer of a previous connection is still leased, it must not replace this one on release
+	r.withdraw(ownerID)
+
 	r.relayers[ownerID] = relayer
+	r.registrations++
+}
+
+// unregister removes the relayer of ownerID, a leased one is dropped on release.
+func (r *Relayers) unregister(ownerID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, available := r.relayers[ownerID]
+	delete(r.relayers, ownerID)
+
+	if !r.withdraw(ownerID) && !available {
+		return fmt.Errorf("%w: relayer not found", core.ErrFailedToGetRelayer)
+	}
+
+	return nil
+}
+
+// withdraw marks the relayer leased for ownerID, if any, to be dropped on release.
+func (r *Relayers) withdraw(ownerID string) bool {
+	relayer, ok := r.leased[ownerID]
+	if !ok {
+		return false
+	}
+
+	delete(r.leased, ownerID)
+	r.withdrawn[ownerID] = append(r.withdrawn[ownerID], relayer)
+
+	return true
+}
+
+func (r *Relayers) stats() Stats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return Stats{
+		Available:     len(r.relayers),
+		Leased:        len(r.leased),
+		Registrations: r.registrations,
+		Acquisitions:  r.acquisitions,
+		Releases:      r.releases,
+	}
+}
+
+func (r *Relayers) state() State {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	available := lo.Keys(r.relayers)
+	slices.Sort(available)
+
+	return State{
+		Available:     available,
+		Registrations: r.registrations,
+		Acquisitions:  r.acquisitions,
+		Releases:      r.releases,
+	}
 }
 
 // AcquireRelayer acquires a relayer associated with the given ownerID from the Multiplexer.
@@ -126,3 +231,32 @@ func (m *Multiplexer) ReleaseRelayer(_ context.Context, ownerID string, relayer
 func (m *Multiplexer) Register(_ context.Context, ownerID string, relayer core.Messager) {
 	m.relayers.register(ownerID, relayer)
 }
+
+// Unregister removes the relayer registered under the given ownerID from the Multiplexer. When it is acquired it is
+// not put back on release.
+func (m *Multiplexer) Unregister(_ context.Context, ownerID string) error {
+	return m.relayers.unregister(ownerID)
+}
+
+// Stats returns a snapshot of the relayers of the Multiplexer.
+func (m *Multiplexer) Stats() Stats {
+	return m.relayers.stats()
+}
+
+// PoolStats reports on the relayers of the Multiplexer.
+func (m *Multiplexer) PoolStats(context.Context) (core.PoolStats, error) {
+	stats := m.relayers.stats()
+
+	return core.PoolStats{
+		Registered:    int64(stats.Available + stats.Leased),
+		Available:     int64(stats.Available),
+		Registrations: stats.Registrations,
+		Acquisitions:  stats.Acquisitions,
+		Releases:      stats.Releases,
+	}, nil
+}
+
+// State returns a dump of the relayers of the Multiplexer, the acquired ones are not listed until released.
+func (m *Multiplexer) State() State {
+	return m.relayers.state()
+}
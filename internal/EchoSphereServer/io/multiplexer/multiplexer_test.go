@@ -2,6 +2,7 @@ package multiplexer
 
 import (
 	"context"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/core"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"sync"
@@ -125,6 +126,84 @@ func TestMultiplexer_ReleaseRelayer(t *testing.T) {
 	assert.Equal(t, relayer, mux.relayers.relayers[ownerID])
 }
 
+func TestMultiplexer_Unregister(t *testing.T) {
+	ctx := context.Background()
+	mux := New()
+
+	mux.Register(ctx, ownerID, &MockRelayer{})
+
+	require.NoError(t, mux.Unregister(ctx, ownerID))
+	assert.Empty(t, mux.relayers.relayers)
+
+	require.ErrorIs(t, mux.Unregister(ctx, ownerID), core.ErrFailedToGetRelayer)
+}
+
+func TestMultiplexer_Unregister_Leased(t *testing.T) {
+	ctx := context.Background()
+	mux := New()
+
+	mux.Register(ctx, ownerID, &MockRelayer{})
+
+	relayer, err := mux.AcquireRelayer(ctx, ownerID)
+	require.NoError(t, err)
+
+	require.NoError(t, mux.Unregister(ctx, ownerID))
+
+	// the release of the withdrawn relayer does not put it back
+	mux.ReleaseRelayer(ctx, ownerID, relayer)
+	assert.Empty(t, mux.relayers.relayers)
+	assert.Empty(t, mux.relayers.withdrawn)
+}
+
+func TestMultiplexer_Register_Leased(t *testing.T) {
+	ctx := context.Background()
+	mux := New()
+
+	mux.Register(ctx, ownerID, &MockRelayer{})
+
+	stale, err := mux.AcquireRelayer(ctx, ownerID)
+	require.NoError(t, err)
+
+	// the owner reconnects while its previous relayer is leased
+	current := &MockRelayer{}
+	mux.Register(ctx, ownerID, current)
+
+	relayer, err := mux.AcquireRelayer(ctx, ownerID)
+	require.NoError(t, err)
+
+	mux.ReleaseRelayer(ctx, ownerID, relayer)
+	mux.ReleaseRelayer(ctx, ownerID, stale)
+
+	assert.Same(t, current, mux.relayers.relayers[ownerID])
+}
+
+func TestMultiplexer_Register_LeasedTwice(t *testing.T) {
+	ctx := context.Background()
+	mux := New()
+
+	mux.Register(ctx, ownerID, &MockRelayer{})
+
+	first, err := mux.AcquireRelayer(ctx, ownerID)
+	require.NoError(t, err)
+
+	// the owner reconnects twice, its second connection being leased when the third one registers
+	mux.Register(ctx, ownerID, &MockRelayer{})
+
+	second, err := mux.AcquireRelayer(ctx, ownerID)
+	require.NoError(t, err)
+
+	current := &MockRelayer{}
+	mux.Register(ctx, ownerID, current)
+
+	// neither release brings back a previous connection
+	mux.ReleaseRelayer(ctx, ownerID, first)
+	assert.Same(t, current, mux.relayers.relayers[ownerID])
+
+	mux.ReleaseRelayer(ctx, ownerID, second)
+	assert.Same(t, current, mux.relayers.relayers[ownerID])
+	assert.Empty(t, mux.relayers.withdrawn)
+}
+
 func TestMultiplexer_Register(t *testing.T) {
 	mux := New()
 	relayer := &MockRelayer{}
@@ -132,3 +211,63 @@ func TestMultiplexer_Register(t *testing.T) {
 	mux.Register(context.Background(), ownerID, relayer)
 	assert.Equal(t, relayer, mux.relayers.relayers[ownerID])
 }
+
+func TestMultiplexer_Stats(t *testing.T) {
+	ctx := context.Background()
+	mux := New()
+
+	mux.Register(ctx, ownerID, &MockRelayer{})
+	mux.Register(ctx, "owner2", &MockRelayer{})
+
+	relayer, err := mux.AcquireRelayer(ctx, ownerID)
+	require.NoError(t, err)
+
+	assert.Equal(t, Stats{Available: 1, Leased: 1, Registrations: 2, Acquisitions: 1}, mux.Stats())
+
+	mux.ReleaseRelayer(ctx, ownerID, relayer)
+
+	assert.Equal(t, Stats{Available: 2, Registrations: 2, Acquisitions: 1, Releases: 1}, mux.Stats())
+}
+
+func TestMultiplexer_PoolStats(t *testing.T) {
+	ctx := context.Background()
+	mux := New()
+
+	mux.Register(ctx, ownerID, &MockRelayer{})
+	mux.Register(ctx, "owner2", &MockRelayer{})
+
+	_, err := mux.AcquireRelayer(ctx, ownerID)
+	require.NoError(t, err)
+
+	// the acquired relayer is still registered
+	stats, err := mux.PoolStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, core.PoolStats{Registered: 2, Available: 1, Registrations: 2, Acquisitions: 1}, stats)
+
+	require.NoError(t, mux.Unregister(ctx, ownerID))
+
+	stats, err = mux.PoolStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), stats.Registered)
+}
+
+func TestMultiplexer_State(t *testing.T) {
+	ctx := context.Background()
+	mux := New()
+
+	assert.Equal(t, State{Available: []string{}}, mux.State())
+
+	mux.Register(ctx, "owner2", &MockRelayer{})
+	mux.Register(ctx, ownerID, &MockRelayer{})
+	mux.Register(ctx, "owner3", &MockRelayer{})
+
+	relayer, err := mux.AcquireRelayer(ctx, "owner2")
+	require.NoError(t, err)
+
+	// the acquired relayer is not available until released
+	assert.Equal(t, State{Available: []string{ownerID, "owner3"}, Registrations: 3, Acquisitions: 1}, mux.State())
+
+	mux.ReleaseRelayer(ctx, "owner2", relayer)
+
+	assert.Equal(t, []string{ownerID, "owner2", "owner3"}, mux.State().Available)
+}
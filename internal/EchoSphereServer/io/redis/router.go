@@ -0,0 +1,476 @@
+// Package redis provides a core.RelayRouter whose relay pool is shared between servers through Redis.
+//
+// The connections themselves stay attached to the node that accepted them, Redis stores which node owns each
+// connection, which connections are available and the acks still pending. Frames for connections owned by another
+// node are published on that node's channel and delivered by its subscriber.
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/core"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/multiplexer"
+	goredis "github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"sync"
+	"sync/atomic"
+)
+
+// randomCandidates is the number of available relayers sampled on each AcquireRandomRelayer attempt.
+const randomCandidates = 8
+
+// The relay pool is changed by scripts, so an owner is never taken out of the pool without being leased.
+var (
+	// acquireScript takes ARGV[1] out of the pool and returns its node.
+	acquireScript = goredis.NewScript(`
+if redis.call('SREM', KEYS[1], ARGV[1]) == 0 then
+	return false
+end
+return redis.call('HGET', KEYS[2], ARGV[1])
+`)
+
+	// acquireRandomScript takes a random owner other than ARGV[1] out of the pool and returns it with its node.
+	acquireRandomScript = goredis.NewScript(`
+for _, owner in ipairs(redis.call('SRANDMEMBER', KEYS[1], tonumber(ARGV[2]))) do
+	if owner ~= ARGV[1] then
+		redis.call('SREM', KEYS[1], owner)
+		local node = redis.call('HGET', KEYS[2], owner)
+		if node then
+			return {owner, node}
+		end
+	end
+end
+return false
+`)
+
+	// registerScript makes ARGV[1] a member of the pool on the node ARGV[2]. An owner already registered on that node
+	// is either available or leased, it is left alone so a lease is never undone by the next frame of its client.
+	registerScript = goredis.NewScript(`
+if redis.call('HGET', KEYS[2], ARGV[1]) == ARGV[2] then
+	return 0
+end
+redis.call('HSET', KEYS[2], ARGV[1], ARGV[2])
+return redis.call('SADD', KEYS[1], ARGV[1])
+`)
+
+	// releaseScript puts ARGV[1] back into the pool, unless it was unregistered from the node ARGV[2] meanwhile.
+	releaseScript = goredis.NewScript(`
+if redis.call('HGET', KEYS[2], ARGV[1]) ~= ARGV[2] then
+	return 0
+end
+return redis.call('SADD', KEYS[1], ARGV[1])
+`)
+
+	// unregisterScript removes ARGV[1] from the pool when it is registered on the node ARGV[2], and the acks pending
+	// to or from it. They are listed by the hashes KEYS[4] and KEYS[5], mapping each to the other end under ARGV[3].
+	unregisterScript = goredis.NewScript(`
+local registered = redis.call('HGET', KEYS[2], ARGV[1]) == ARGV[2]
+if registered then
+	redis.call('SREM', KEYS[1], ARGV[1])
+	redis.call('HDEL', KEYS[2], ARGV[1])
+end
+for index, other in pairs({[KEYS[4]] = 'from:', [KEYS[5]] = 'to:'}) do
+	local pending = redis.call('HGETALL', index)
+	for i = 1, #pending, 2 do
+		redis.call('HDEL', KEYS[3], pending[i])
+		redis.call('HDEL', ARGV[3] .. other .. pending[i + 1], pending[i])
+	end
+	redis.call('DEL', index)
+end
+return registered and 1 or 0
+`)
+)
+
+// Router is a core.RelayRouter backed by Redis.
+type Router struct {
+	client *goredis.Client
+	nodeID string
+	prefix string
+	logger *zap.Logger
+
+	mu    sync.Mutex
+	local map[string]core.Messager
+
+	// the pool changes made by this node
+	registrations atomic.Uint64
+	acquisitions  atomic.Uint64
+	releases      atomic.Uint64
+}
+
+// Config represents the configuration for creating a new Router.
+type Config struct {
+	Client *goredis.Client
+	NodeID string
+	Prefix string
+	Logger *zap.Logger
+}
+
+// NewRouter creates a new Router.
+func NewRouter(cfg Config) *Router {
+	return &Router{
+		client: cfg.Client,
+		nodeID: cfg.NodeID,
+		prefix: cfg.Prefix,
+		logger: cfg.Logger,
+		local:  make(map[string]core.Messager),
+	}
+}
+
+// Register registers a connection of this node and makes it available to every node.
+func (r *Router) Register(ctx context.Context, ownerID string, relayer core.Messager) {
+	r.mu.Lock()
+	r.local[ownerID] = relayer
+	r.mu.Unlock()
+
+	r.registrations.Add(1)
+
+	if err := r.publishMembership(ctx, ownerID); err != nil {
+		r.logger.Error("Error registering relayer", zap.String("owner-id", ownerID), zap.Error(err))
+	}
+}
+
+// AcquireRelayer acquires the relayer of ownerID, wherever the connection lives.
+func (r *Router) AcquireRelayer(ctx context.Context, ownerID string) (core.Messager, error) { //nolint:ireturn
+	nodeID, err := acquireScript.Run(ctx, r.client, []string{r.relayersKey(), r.ownersKey()}, ownerID).Text()
+	if errors.Is(err, goredis.Nil) {
+		return nil, fmt.Errorf("%w: relayer not found", core.ErrFailedToGetRelayer)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	r.acquisitions.Add(1)
+
+	return r.lease(ownerID, nodeID)
+}
+
+// AcquireRandomRelayer acquires a random available relayer from any node, excluding the specified relayer.
+func (r *Router) AcquireRandomRelayer(ctx context.Context, excludeRelayer string) (string, core.Messager, error) { //nolint:ireturn
+	acquired, err := acquireRandomScript.Run(
+		ctx,
+		r.client,
+		[]string{r.relayersKey(), r.ownersKey()},
+		excludeRelayer,
+		randomCandidates,
+	).StringSlice()
+	if errors.Is(err, goredis.Nil) {
+		return "", multiplexer.NoopRelayer{}, fmt.Errorf("%w: %s", core.ErrFailedToGetRelayer, "no relayers available")
+	}
+
+	if err != nil {
+		return "", multiplexer.NoopRelayer{}, err
+	}
+
+	ownerID, nodeID := acquired[0], acquired[1]
+
+	r.acquisitions.Add(1)
+
+	relayer, err := r.lease(ownerID, nodeID)
+	if err != nil {
+		return "", multiplexer.NoopRelayer{}, err
+	}
+
+	return ownerID, relayer, nil
+}
+
+// ReleaseRelayer makes the relayer available again, unless it was unregistered while acquired.
+func (r *Router) ReleaseRelayer(ctx context.Context, ownerID string, relayer core.Messager) {
+	leased, ok := relayer.(*leasedRelayer)
+	if !ok {
+		return
+	}
+
+	if leased.local != nil {
+		r.mu.Lock()
+		current := r.local[ownerID]
+		r.mu.Unlock()
+
+		// the connection is gone, one replacing it is leased through this relayer and made available by its release
+		if current == nil {
+			return
+		}
+	}
+
+	err := releaseScript.Run(
+		context.WithoutCancel(ctx),
+		r.client,
+		[]string{r.relayersKey(), r.ownersKey()},
+		ownerID,
+		leased.nodeID,
+	).Err()
+	if err != nil {
+		r.logger.Error("Error releasing relayer", zap.String("owner-id", ownerID), zap.Error(err))
+
+		return
+	}
+
+	r.releases.Add(1)
+}
+
+// Unregister withdraws the connection of this node from the shared relay pool and drops the acks pending to or from
+// it. A relayer acquired meanwhile is not made available again on release.
+func (r *Router) Unregister(ctx context.Context, ownerID string) error {
+	r.mu.Lock()
+	delete(r.local, ownerID)
+	r.mu.Unlock()
+
+	registered, err := r.unregister(context.WithoutCancel(ctx), ownerID)
+	if err != nil {
+		return err
+	}
+
+	if !registered {
+		return fmt.Errorf("%w: relayer not found", core.ErrFailedToGetRelayer)
+	}
+
+	return nil
+}
+
+// HealthCheck tells whether Redis answers, the relay pool lives there.
+func (r *Router) HealthCheck(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
+}
+
+// PoolStats reports on the relay pool shared by every node, the counters only count the changes made by this node.
+func (r *Router) PoolStats(ctx context.Context) (core.PoolStats, error) {
+	var registered, available *goredis.IntCmd
+
+	_, err := r.client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+		registered = pipe.HLen(ctx, r.ownersKey())
+		available = pipe.SCard(ctx, r.relayersKey())
+
+		return nil
+	})
+	if err != nil {
+		return core.PoolStats{}, err
+	}
+
+	return core.PoolStats{
+		Registered:    registered.Val(),
+		Available:     available.Val(),
+		Registrations: r.registrations.Load(),
+		Acquisitions:  r.acquisitions.Load(),
+		Releases:      r.releases.Load(),
+	}, nil
+}
+
+// PendingAcks returns the number of relayed messages whose ack has not been forwarded yet.
+func (r *Router) PendingAcks(ctx context.Context) (int64, error) {
+	return r.client.HLen(ctx, r.pendingKey()).Result()
+}
+
+// Run delivers the frames other nodes publish for the connections of this node until the context is done.
+// On exit the connections of this node are withdrawn from the shared relay pool.
+func (r *Router) Run(ctx context.Context) error {
+	sub := r.client.Subscribe(ctx, r.nodeChannel(r.nodeID))
+	defer sub.Close()
+
+	defer r.withdraw()
+
+	if _, err := sub.Receive(ctx); err != nil {
+		return err
+	}
+
+	deliveries := sub.Channel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg, ok := <-deliveries:
+			if !ok {
+				return nil
+			}
+
+			r.deliver(ctx, msg.Payload)
+		}
+	}
+}
+
+// lease returns a relayer for ownerID, already taken out of the pool, whose connection is attached to nodeID.
+func (r *Router) lease(ownerID, nodeID string) (*leasedRelayer, error) {
+	leased := &leasedRelayer{router: r, ownerID: ownerID, nodeID: nodeID}
+
+	if nodeID != r.nodeID {
+		return leased, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	local, ok := r.local[ownerID]
+	if !ok {
+		return nil, fmt.Errorf("%w: relayer %s is not attached to this node", core.ErrFailedToGetRelayer, ownerID)
+	}
+
+	leased.local = local
+
+	return leased, nil
+}
+
+func (r *Router) unregister(ctx context.Context, ownerID string) (bool, error) {
+	keys := []string{
+		r.relayersKey(),
+		r.ownersKey(),
+		r.pendingKey(),
+		r.pendingToKey(ownerID),
+		r.pendingFromKey(ownerID),
+	}
+
+	registered, err := unregisterScript.Run(ctx, r.client, keys, ownerID, r.nodeID, r.pendingKey()+":").Int()
+
+	return registered == 1, err
+}
+
+func (r *Router) publishMembership(ctx context.Context, ownerID string) error {
+	return registerScript.Run(
+		context.WithoutCancel(ctx),
+		r.client,
+		[]string{r.relayersKey(), r.ownersKey()},
+		ownerID,
+		r.nodeID,
+	).Err()
+}
+
+func (r *Router) deliver(ctx context.Context, payload string) {
+	req := &v1.EchoSphereFederationServiceDeliverRequest{}
+	if err := proto.Unmarshal([]byte(payload), req); err != nil {
+		r.logger.Error("Error decoding delivery", zap.Error(err))
+
+		return
+	}
+
+	r.mu.Lock()
+	relayer, ok := r.local[req.GetOwnerId()]
+	r.mu.Unlock()
+
+	if !ok {
+		r.logger.Warn("Dropping delivery for unknown relayer", zap.String("owner-id", req.GetOwnerId()))
+
+		return
+	}
+
+	if err := relayer.SendMsg(ctx, req.GetFrame()); err != nil {
+		r.logger.Error("Error delivering frame", zap.String("owner-id", req.GetOwnerId()), zap.Error(err))
+	}
+}
+
+func (r *Router) withdraw() {
+	ctx := context.Background()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for ownerID := range r.local {
+		if _, err := r.unregister(ctx, ownerID); err != nil {
+			r.logger.Error("Error withdrawing relayer", zap.String("owner-id", ownerID), zap.Error(err))
+		}
+
+		delete(r.local, ownerID)
+	}
+}
+
+// track records the acks a frame makes pending, or settles, so they can be inspected across nodes. Each pending ack
+// is also listed under its recipient, with its originator, and under its originator, with its recipient, to be
+// dropped when either unregisters.
+func (r *Router) track(ctx context.Context, ownerID string, frame *v1.EchoSphereTransmissionServiceTransmitResponse) error {
+	if message := frame.GetMessage(); message != nil && message.GetFrom() != ownerID {
+		field := pendingField(message.GetFrom(), message.GetContent())
+
+		_, err := r.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+			pipe.HSet(ctx, r.pendingKey(), field, ownerID)
+			pipe.HSet(ctx, r.pendingToKey(ownerID), field, message.GetFrom())
+			pipe.HSet(ctx, r.pendingFromKey(message.GetFrom()), field, ownerID)
+
+			return nil
+		})
+
+		return err
+	}
+
+	var recipient, originator, content string
+
+	switch {
+	case frame.GetAck() != nil:
+		recipient, originator, content = frame.GetAck().GetFrom(), frame.GetAck().GetTo(), frame.GetAck().GetContent()
+	case frame.GetNack() != nil:
+		recipient, originator, content = frame.GetNack().GetFrom(), frame.GetNack().GetTo(), frame.GetNack().GetContent()
+	default:
+		return nil
+	}
+
+	field := pendingField(originator, content)
+
+	_, err := r.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.HDel(ctx, r.pendingKey(), field)
+		pipe.HDel(ctx, r.pendingToKey(recipient), field)
+		pipe.HDel(ctx, r.pendingFromKey(originator), field)
+
+		return nil
+	})
+
+	return err
+}
+
+func (r *Router) relayersKey() string { return r.prefix + ":relayers" }
+
+func (r *Router) ownersKey() string { return r.prefix + ":owners" }
+
+func (r *Router) pendingKey() string { return r.prefix + ":pending" }
+
+func (r *Router) pendingToKey(ownerID string) string { return r.prefix + ":pending:to:" + ownerID }
+
+func (r *Router) pendingFromKey(ownerID string) string { return r.prefix + ":pending:from:" + ownerID }
+
+func (r *Router) nodeChannel(nodeID string) string { return r.prefix + ":node:" + nodeID }
+
+func pendingField(originator, content string) string { return originator + ":" + content }
+
+// leasedRelayer is an acquired relayer, local holds the connection when it is attached to this node.
+type leasedRelayer struct {
+	router  *Router
+	ownerID string
+	nodeID  string
+	local   core.Messager
+}
+
+// Unwrap returns the connection when it is attached to this node.
+func (l *leasedRelayer) Unwrap() core.Messager {
+	return l.local
+}
+
+// SendMsg sends the frame to the connection, publishing it to the owning node when it is not local.
+func (l *leasedRelayer) SendMsg(ctx context.Context, m any) error {
+	frame, ok := m.(*v1.EchoSphereTransmissionServiceTransmitResponse)
+	if !ok {
+		return fmt.Errorf("unsupported frame %T", m)
+	}
+
+	if err := l.router.track(ctx, l.ownerID, frame); err != nil {
+		return err
+	}
+
+	if l.local != nil {
+		return l.local.SendMsg(ctx, frame)
+	}
+
+	payload, err := proto.Marshal(&v1.EchoSphereFederationServiceDeliverRequest{OwnerId: l.ownerID, Frame: frame})
+	if err != nil {
+		return err
+	}
+
+	receivers, err := l.router.client.Publish(ctx, l.router.nodeChannel(l.nodeID), payload).Result()
+	if err != nil {
+		return err
+	}
+
+	if receivers == 0 {
+		return errors.New("node " + l.nodeID + " is not reachable")
+	}
+
+	return nil
+}
@@ -0,0 +1,394 @@
+package redis_test
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/core"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/multiplexer"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/redis"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"sync"
+	"testing"
+	"time"
+)
+
+type mockRelayer struct {
+	mu       sync.Mutex
+	messages []any
+}
+
+func (m *mockRelayer) SendMsg(_ context.Context, msg any) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = append(m.messages, msg)
+
+	return nil
+}
+
+func (m *mockRelayer) received() []any {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.messages
+}
+
+type redisRouterSuite struct {
+	suite.Suite
+	cancel   context.CancelFunc
+	errGroup *errgroup.Group
+
+	redis        *miniredis.Miniredis
+	nodeA, nodeB *redis.Router
+}
+
+func (r *redisRouterSuite) SetupTest() {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.errGroup = &errgroup.Group{}
+
+	r.redis = miniredis.RunT(r.T())
+
+	r.nodeA = r.startNode(ctx, "node-a")
+	r.nodeB = r.startNode(ctx, "node-b")
+}
+
+func (r *redisRouterSuite) TearDownTest() {
+	r.cancel()
+	r.Require().ErrorIs(r.errGroup.Wait(), context.Canceled)
+}
+
+func (r *redisRouterSuite) startNode(ctx context.Context, nodeID string) *redis.Router {
+	router := redis.NewRouter(redis.Config{
+		Client: goredis.NewClient(&goredis.Options{Addr: r.redis.Addr()}),
+		NodeID: nodeID,
+		Prefix: "echosphere",
+		Logger: zap.NewNop(),
+	})
+
+	r.errGroup.Go(func() error { return router.Run(ctx) })
+
+	r.Require().Eventually(
+		func() bool { return r.redis.PubSubNumSub("echosphere:node:" + nodeID)["echosphere:node:"+nodeID] == 1 },
+		time.Second,
+		time.Millisecond,
+	)
+
+	return router
+}
+
+func (r *redisRouterSuite) TestAcquireRandomRelayer_FromOtherNode() {
+	ctx := context.Background()
+
+	r.nodeA.Register(ctx, "owner-a", &mockRelayer{})
+
+	remote := &mockRelayer{}
+	r.nodeB.Register(ctx, "owner-b", remote)
+
+	ownerID, relayer, err := r.nodeA.AcquireRandomRelayer(ctx, "owner-a")
+	r.Require().NoError(err)
+	r.Require().Equal("owner-b", ownerID)
+
+	// while acquired, nobody else can get it
+	_, err = r.nodeB.AcquireRelayer(ctx, "owner-b")
+	r.Require().ErrorIs(err, core.ErrFailedToGetRelayer)
+
+	r.Require().NoError(relayer.SendMsg(ctx, newMessage("owner-a", "hello")))
+	r.Require().Eventually(func() bool { return len(remote.received()) == 1 }, time.Second, time.Millisecond)
+
+	pending, err := r.nodeA.PendingAcks(ctx)
+	r.Require().NoError(err)
+	r.Equal(int64(1), pending)
+
+	r.nodeA.ReleaseRelayer(ctx, ownerID, relayer)
+
+	_, err = r.nodeB.AcquireRelayer(ctx, "owner-b")
+	r.Require().NoError(err)
+}
+
+func (r *redisRouterSuite) TestAcquireRandomRelayer_NoRelayers() {
+	ctx := context.Background()
+
+	r.nodeA.Register(ctx, "owner-a", &mockRelayer{})
+
+	_, relayer, err := r.nodeB.AcquireRandomRelayer(ctx, "owner-a")
+	r.Require().ErrorIs(err, core.ErrFailedToGetRelayer)
+	r.IsType(multiplexer.NoopRelayer{}, relayer)
+
+	r.nodeB.ReleaseRelayer(ctx, "", relayer)
+}
+
+func (r *redisRouterSuite) TestAcquireRelayer_AckSettlesPending() {
+	ctx := context.Background()
+
+	originator := &mockRelayer{}
+	r.nodeA.Register(ctx, "owner-a", originator)
+
+	recipient := &mockRelayer{}
+	r.nodeB.Register(ctx, "owner-b", recipient)
+
+	ownerID, relayer, err := r.nodeA.AcquireRandomRelayer(ctx, "owner-a")
+	r.Require().NoError(err)
+	r.Require().NoError(relayer.SendMsg(ctx, newMessage("owner-a", "hello")))
+	r.nodeA.ReleaseRelayer(ctx, ownerID, relayer)
+
+	// owner-b acks through its own node, which forwards it to the node of owner-a
+	relayer, err = r.nodeB.AcquireRelayer(ctx, "owner-a")
+	r.Require().NoError(err)
+
+	r.Require().NoError(relayer.SendMsg(ctx, &v1.EchoSphereTransmissionServiceTransmitResponse{
+		OutgoingData: &v1.EchoSphereTransmissionServiceTransmitResponse_Ack{
+			Ack: &v1.Ack{From: "owner-b", To: "owner-a", Content: "hello"},
+		},
+	}))
+	r.nodeB.ReleaseRelayer(ctx, "owner-a", relayer)
+
+	r.Require().Eventually(func() bool { return len(originator.received()) == 1 }, time.Second, time.Millisecond)
+	r.Equal("hello", originator.received()[0].(*v1.EchoSphereTransmissionServiceTransmitResponse).GetAck().GetContent())
+
+	pending, err := r.nodeA.PendingAcks(ctx)
+	r.Require().NoError(err)
+	r.Zero(pending)
+}
+
+func (r *redisRouterSuite) TestAcquireRelayer_LocalUnregister() {
+	ctx := context.Background()
+
+	local := &mockRelayer{}
+	r.nodeA.Register(ctx, "owner-a", local)
+
+	relayer, err := r.nodeA.AcquireRelayer(ctx, "owner-a")
+	r.Require().NoError(err)
+
+	// a local connection is delivered directly
+	r.Require().NoError(relayer.SendMsg(ctx, newMessage("owner-a", "echo")))
+	r.Len(local.received(), 1)
+
+	// unregistering it while acquired, like a kick does, removes it from the shared pool for good
+	r.Require().NoError(r.nodeA.Unregister(ctx, "owner-a"))
+	r.nodeA.ReleaseRelayer(ctx, "owner-a", relayer)
+
+	r.Empty(r.redis.HGet("echosphere:owners", "owner-a"))
+
+	_, err = r.nodeB.AcquireRelayer(ctx, "owner-a")
+	r.Require().ErrorIs(err, core.ErrFailedToGetRelayer)
+
+	r.Require().ErrorIs(r.nodeA.Unregister(ctx, "owner-a"), core.ErrFailedToGetRelayer)
+}
+
+func (r *redisRouterSuite) TestReleaseRelayer_UnregisteredWhileLeased() {
+	ctx := context.Background()
+
+	r.nodeB.Register(ctx, "owner-b", &mockRelayer{})
+
+	relayer, err := r.nodeA.AcquireRelayer(ctx, "owner-b")
+	r.Require().NoError(err)
+
+	// the connection closes on its node while another node holds its relayer
+	r.Require().NoError(r.nodeB.Unregister(ctx, "owner-b"))
+
+	r.nodeA.ReleaseRelayer(ctx, "owner-b", relayer)
+
+	members, err := r.redis.Members("echosphere:relayers")
+	r.Require().ErrorIs(err, miniredis.ErrKeyNotFound)
+	r.Empty(members)
+
+	_, err = r.nodeA.AcquireRelayer(ctx, "owner-b")
+	r.Require().ErrorIs(err, core.ErrFailedToGetRelayer)
+}
+
+func (r *redisRouterSuite) TestRegister_WhileLeased() {
+	ctx := context.Background()
+
+	r.nodeB.Register(ctx, "owner-b", &mockRelayer{})
+
+	relayer, err := r.nodeA.AcquireRelayer(ctx, "owner-b")
+	r.Require().NoError(err)
+
+	// every frame of the client registers it again, and its connection may be replaced meanwhile
+	replacing := &mockRelayer{}
+	r.nodeB.Register(ctx, "owner-b", &mockRelayer{})
+	r.nodeB.Register(ctx, "owner-b", replacing)
+
+	// the owner stays leased until released
+	_, err = r.nodeB.AcquireRelayer(ctx, "owner-b")
+	r.Require().ErrorIs(err, core.ErrFailedToGetRelayer)
+
+	r.nodeA.ReleaseRelayer(ctx, "owner-b", relayer)
+
+	released, err := r.nodeB.AcquireRelayer(ctx, "owner-b")
+	r.Require().NoError(err)
+	r.Require().NoError(released.SendMsg(ctx, newMessage("owner-b", "echo")))
+	r.Len(replacing.received(), 1)
+
+	r.nodeB.ReleaseRelayer(ctx, "owner-b", released)
+}
+
+func (r *redisRouterSuite) TestAcquireRelayer_RedisFailure() {
+	ctx := context.Background()
+
+	r.nodeB.Register(ctx, "owner-b", &mockRelayer{})
+
+	r.redis.SetError("LOADING Redis is loading the dataset in memory")
+
+	_, err := r.nodeA.AcquireRelayer(ctx, "owner-b")
+	r.Require().Error(err)
+
+	_, _, err = r.nodeA.AcquireRandomRelayer(ctx, "owner-a")
+	r.Require().Error(err)
+
+	r.redis.SetError("")
+
+	// the failed acquisitions did not take the owner out of the pool
+	relayer, err := r.nodeA.AcquireRelayer(ctx, "owner-b")
+	r.Require().NoError(err)
+
+	r.nodeA.ReleaseRelayer(ctx, "owner-b", relayer)
+
+	ownerID, _, err := r.nodeA.AcquireRandomRelayer(ctx, "owner-a")
+	r.Require().NoError(err)
+	r.Equal("owner-b", ownerID)
+}
+
+func (r *redisRouterSuite) TestAcquireRandomRelayer_DanglingOwner() {
+	ctx := context.Background()
+
+	// an owner left in the pool without a node, e.g. by a node that crashed while leasing it
+	_, err := r.redis.SetAdd("echosphere:relayers", "owner-x")
+	r.Require().NoError(err)
+
+	_, _, err = r.nodeA.AcquireRandomRelayer(ctx, "owner-a")
+	r.Require().ErrorIs(err, core.ErrFailedToGetRelayer)
+
+	_, err = r.nodeA.AcquireRelayer(ctx, "owner-x")
+	r.Require().ErrorIs(err, core.ErrFailedToGetRelayer)
+}
+
+func (r *redisRouterSuite) TestUnregister_DropsPendingAcks() {
+	ctx := context.Background()
+
+	r.nodeA.Register(ctx, "owner-a", &mockRelayer{})
+	r.nodeB.Register(ctx, "owner-b", &mockRelayer{})
+	r.nodeB.Register(ctx, "owner-c", &mockRelayer{})
+
+	for _, recipient := range []string{"owner-b", "owner-c"} {
+		relayer, err := r.nodeA.AcquireRelayer(ctx, recipient)
+		r.Require().NoError(err)
+		r.Require().NoError(relayer.SendMsg(ctx, newMessage("owner-a", "to "+recipient)))
+		r.nodeA.ReleaseRelayer(ctx, recipient, relayer)
+	}
+
+	pending, err := r.nodeA.PendingAcks(ctx)
+	r.Require().NoError(err)
+	r.Equal(int64(2), pending)
+
+	// the recipient leaves without acking
+	r.Require().NoError(r.nodeB.Unregister(ctx, "owner-b"))
+
+	pending, err = r.nodeA.PendingAcks(ctx)
+	r.Require().NoError(err)
+	r.Equal(int64(1), pending)
+
+	// the originator leaves, its message will never be acked
+	r.Require().NoError(r.nodeA.Unregister(ctx, "owner-a"))
+
+	pending, err = r.nodeA.PendingAcks(ctx)
+	r.Require().NoError(err)
+	r.Zero(pending)
+
+	r.ElementsMatch([]string{"echosphere:owners", "echosphere:relayers"}, r.redis.Keys())
+}
+
+func (r *redisRouterSuite) TestNack_SettlesPending() {
+	ctx := context.Background()
+
+	r.nodeA.Register(ctx, "owner-a", &mockRelayer{})
+	r.nodeB.Register(ctx, "owner-b", &mockRelayer{})
+
+	relayer, err := r.nodeA.AcquireRelayer(ctx, "owner-b")
+	r.Require().NoError(err)
+	r.Require().NoError(relayer.SendMsg(ctx, newMessage("owner-a", "hello")))
+	r.nodeA.ReleaseRelayer(ctx, "owner-b", relayer)
+
+	relayer, err = r.nodeB.AcquireRelayer(ctx, "owner-a")
+	r.Require().NoError(err)
+	r.Require().NoError(relayer.SendMsg(ctx, &v1.EchoSphereTransmissionServiceTransmitResponse{
+		OutgoingData: &v1.EchoSphereTransmissionServiceTransmitResponse_Nack{
+			Nack: &v1.Nack{From: "owner-b", To: "owner-a", Content: "hello", Reason: v1.NackReason_NACK_REASON_TIMEOUT},
+		},
+	}))
+	r.nodeB.ReleaseRelayer(ctx, "owner-a", relayer)
+
+	pending, err := r.nodeA.PendingAcks(ctx)
+	r.Require().NoError(err)
+	r.Zero(pending)
+}
+
+func (r *redisRouterSuite) TestPoolStats() {
+	ctx := context.Background()
+
+	r.nodeA.Register(ctx, "owner-a", &mockRelayer{})
+	r.nodeB.Register(ctx, "owner-b", &mockRelayer{})
+
+	relayer, err := r.nodeA.AcquireRelayer(ctx, "owner-b")
+	r.Require().NoError(err)
+
+	// the pool is shared, the counters are those of the node
+	stats, err := r.nodeB.PoolStats(ctx)
+	r.Require().NoError(err)
+	r.Equal(core.PoolStats{Registered: 2, Available: 1, Registrations: 1}, stats)
+
+	r.nodeA.ReleaseRelayer(ctx, "owner-b", relayer)
+
+	stats, err = r.nodeA.PoolStats(ctx)
+	r.Require().NoError(err)
+	r.Equal(core.PoolStats{Registered: 2, Available: 2, Registrations: 1, Acquisitions: 1, Releases: 1}, stats)
+}
+
+func (r *redisRouterSuite) TestHealthCheck() {
+	r.Require().NoError(r.nodeA.HealthCheck(context.Background()))
+
+	r.redis.SetError("unavailable")
+	defer r.redis.SetError("")
+
+	r.Require().Error(r.nodeA.HealthCheck(context.Background()))
+}
+
+func (r *redisRouterSuite) TestRun_WithdrawsConnectionsOnExit() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	router := redis.NewRouter(redis.Config{
+		Client: goredis.NewClient(&goredis.Options{Addr: r.redis.Addr()}),
+		NodeID: "node-c",
+		Prefix: "echosphere",
+		Logger: zap.NewNop(),
+	})
+
+	done := make(chan error, 1)
+
+	go func() { done <- router.Run(ctx) }()
+
+	router.Register(ctx, "owner-c", &mockRelayer{})
+
+	cancel()
+	r.Require().ErrorIs(<-done, context.Canceled)
+
+	_, err := r.nodeA.AcquireRelayer(context.Background(), "owner-c")
+	r.Require().ErrorIs(err, core.ErrFailedToGetRelayer)
+}
+
+func newMessage(from, content string) *v1.EchoSphereTransmissionServiceTransmitResponse {
+	return &v1.EchoSphereTransmissionServiceTransmitResponse{
+		OutgoingData: &v1.EchoSphereTransmissionServiceTransmitResponse_Message{
+			Message: &v1.Message{From: from, Content: content},
+		},
+	}
+}
+
+func TestRedisRouter(t *testing.T) {
+	suite.Run(t, new(redisRouterSuite))
+}
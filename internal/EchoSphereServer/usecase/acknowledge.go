@@ -3,27 +3,51 @@ package usecase
 
 import (
 	"context"
+	"fmt"
 	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/core"
+	"time"
 )
 
-// AckCMD represents a command to acknowledge a message.
+// AckCMD represents a command to acknowledge a message, TraceContext is the W3C trace context the forwarded ack carries.
 type AckCMD struct {
-	From    string
-	To      string
-	Content string
+	From         string
+	To           string
+	Content      string
+	TraceContext map[string]string
 }
 
 // AckHandler handles the acknowledgment of a message for a specific owner.
 func (uc *UC) AckHandler(ctx context.Context, cmd AckCMD) error {
 	// this needs to be an ACID transaction we don't want a relayer being stole while we do actions
-	uc.mu.Lock()
-	defer uc.mu.Unlock()
+	defer uc.lock()()
+
+	event := Event{Type: EventAckForwarded, OwnerID: cmd.From, PeerID: cmd.To, Content: cmd.Content}
+
+	// forwarding the ack settles the message, when it was relayed is looked up first
+	relayedAt, relayed := uc.acks.relayedAt(cmd.To, cmd.Content)
 
 	relayer, err := uc.router.AcquireRelayer(ctx, cmd.To)
 	if err != nil {
+		event.drop(err.Error())
+		uc.bus.Publish(ctx, event)
+
 		return err
 	}
 	defer uc.router.ReleaseRelayer(ctx, cmd.To, relayer)
 
-	return sendRelayMessage(ctx, relayer, &v1.Ack{From: cmd.From, To: cmd.To, Content: cmd.Content})
+	if err := sendRelayMessage(ctx, relayer, &v1.Ack{From: cmd.From, To: cmd.To, Content: cmd.Content, TraceContext: cmd.TraceContext}); err != nil {
+		event.drop(err.Error())
+		uc.bus.Publish(ctx, event)
+
+		return fmt.Errorf("%w to %s: %w", core.ErrFailedToRelay, cmd.To, err)
+	}
+
+	if relayed {
+		event.Latency = time.Since(relayedAt)
+	}
+
+	uc.bus.Publish(ctx, event)
+
+	return nil
 }
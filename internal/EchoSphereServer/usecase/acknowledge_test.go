@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/core"
 	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
 	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase/internal/mocks"
 	"go.uber.org/mock/gomock"
@@ -14,9 +15,10 @@ func (u *useCaseSuite) TestAckHandler_Success() {
 	defer cancel()
 
 	cmd := usecase.AckCMD{
-		From:    "client-1",
-		To:      "client-2",
-		Content: "ack message",
+		From:         "client-1",
+		To:           "client-2",
+		Content:      "ack message",
+		TraceContext: map[string]string{"traceparent": "00-01000000000000000000000000000000-0100000000000000-01"},
 	}
 
 	ownerRelayer := mocks.NewMockMessager(gomock.NewController(u.T()))
@@ -27,7 +29,7 @@ func (u *useCaseSuite) TestAckHandler_Success() {
 		ctx,
 		&v1.EchoSphereTransmissionServiceTransmitResponse{
 			OutgoingData: &v1.EchoSphereTransmissionServiceTransmitResponse_Ack{
-				Ack: &v1.Ack{From: cmd.From, To: cmd.To, Content: cmd.Content},
+				Ack: &v1.Ack{From: cmd.From, To: cmd.To, Content: cmd.Content, TraceContext: cmd.TraceContext},
 			},
 		},
 	).Return(nil)
@@ -51,3 +53,17 @@ func (u *useCaseSuite) TestAckHandler_RelayerNotFound() {
 	err := u.SUT.AckHandler(ctx, cmd)
 	u.Error(err)
 }
+
+func (u *useCaseSuite) TestAckHandler_FailedToSend() {
+	ctx := context.Background()
+	cmd := usecase.AckCMD{From: "client-1", To: "client-2", Content: "ack message"}
+
+	ownerRelayer := mocks.NewMockMessager(gomock.NewController(u.T()))
+
+	u.router.EXPECT().AcquireRelayer(ctx, cmd.To).Return(ownerRelayer, nil)
+	u.router.EXPECT().ReleaseRelayer(ctx, cmd.To, ownerRelayer)
+	ownerRelayer.EXPECT().SendMsg(ctx, gomock.Any()).Return(errors.New("stream closed"))
+
+	err := u.SUT.AckHandler(ctx, cmd)
+	u.Require().ErrorIs(err, core.ErrFailedToRelay)
+}
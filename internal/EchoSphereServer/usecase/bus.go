@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// EventHandler is called with the events a subscriber is interested in.
+type EventHandler func(ctx context.Context, event Event)
+
+// Bus publishes the events of the handlers to their subscribers.
+//
+// Synchronous subscribers run in the publishing handler, under the use case lock, so they see the events in order
+// and must neither block nor call back into the use cases.
+// Asynchronous subscribers run in their own goroutine behind a buffer, the events that do not fit are dropped
+// rather than slowing the handlers down.
+type Bus struct {
+	mu   sync.RWMutex
+	next int
+	subs map[int]subscription
+}
+
+type subscription struct {
+	filter  EventFilter
+	deliver EventHandler
+}
+
+// NewBus creates a new Bus without subscribers.
+func NewBus() *Bus {
+	return &Bus{subs: make(map[int]subscription)}
+}
+
+// Subscribe calls handler synchronously for the events matching filter, until the returned func is called,
+// which must not be called from handler.
+func (b *Bus) Subscribe(filter EventFilter, handler EventHandler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.next
+	b.next++
+	b.subs[id] = subscription{filter: filter, deliver: handler}
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			delete(b.subs, id)
+		})
+	}
+}
+
+// SubscribeAsync calls handler from its own goroutine for the events matching filter, buffering up to buffer of them,
+// until the returned func is called, which waits for the buffered events to be handled.
+func (b *Bus) SubscribeAsync(filter EventFilter, buffer int, handler EventHandler) func() {
+	type delivery struct {
+		ctx   context.Context
+		event Event
+	}
+
+	deliveries, done := make(chan delivery, buffer), make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for d := range deliveries {
+			handler(d.ctx, d.event)
+		}
+	}()
+
+	unsubscribe := b.Subscribe(filter, func(ctx context.Context, event Event) {
+		select {
+		// the handler outlives the command, not its values
+		case deliveries <- delivery{ctx: context.WithoutCancel(ctx), event: event}:
+		default:
+		}
+	})
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			unsubscribe()
+			close(deliveries)
+			<-done
+		})
+	}
+}
+
+// Watch subscribes to the events matching filter, buffering up to buffer of them, until the returned func is called.
+func (b *Bus) Watch(buffer int, filter EventFilter) (<-chan Event, func()) {
+	events := make(chan Event, buffer)
+
+	unsubscribe := b.Subscribe(filter, func(_ context.Context, event Event) {
+		select {
+		case events <- event:
+		default:
+		}
+	})
+
+	var once sync.Once
+
+	return events, func() {
+		once.Do(func() {
+			unsubscribe()
+			close(events)
+		})
+	}
+}
+
+// Publish stamps the event and delivers it to the matching subscribers.
+func (b *Bus) Publish(ctx context.Context, event Event) {
+	if event.At.IsZero() {
+		event.At = time.Now()
+	}
+
+	if event.Content != "" && event.ContentHash == "" {
+		event.ContentHash = ContentHash(event.Content)
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, sub := range b.subs {
+		if sub.filter.matches(event) {
+			sub.deliver(ctx, event)
+		}
+	}
+}
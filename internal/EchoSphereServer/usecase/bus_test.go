@@ -0,0 +1,104 @@
+package usecase_test
+
+import (
+	"context"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+type busSuite struct {
+	suite.Suite
+
+	SUT *usecase.Bus
+}
+
+func (b *busSuite) SetupTest() {
+	b.SUT = usecase.NewBus()
+}
+
+func (b *busSuite) TestSubscribe() {
+	var got []usecase.Event
+
+	unsubscribe := b.SUT.Subscribe(usecase.EventFilter{}, func(_ context.Context, event usecase.Event) {
+		got = append(got, event)
+	})
+
+	b.SUT.Publish(context.Background(), usecase.Event{Type: usecase.EventRelayed, OwnerID: "a", Content: "hello"})
+	unsubscribe()
+	b.SUT.Publish(context.Background(), usecase.Event{Type: usecase.EventDropped, OwnerID: "a"})
+
+	b.Require().Len(got, 1)
+	b.Equal(usecase.EventRelayed, got[0].Type)
+	b.NotZero(got[0].At)
+	b.NotEmpty(got[0].ContentHash)
+}
+
+func (b *busSuite) TestSubscribe_Filter() {
+	var got []usecase.Event
+
+	defer b.SUT.Subscribe(
+		usecase.EventFilter{OwnerIDs: []string{"b"}, Types: []usecase.EventType{usecase.EventRelayed}},
+		func(_ context.Context, event usecase.Event) { got = append(got, event) },
+	)()
+
+	b.SUT.Publish(context.Background(), usecase.Event{Type: usecase.EventRelayed, OwnerID: "a", PeerID: "b"})
+	b.SUT.Publish(context.Background(), usecase.Event{Type: usecase.EventRelayed, OwnerID: "a", PeerID: "c"})
+	b.SUT.Publish(context.Background(), usecase.Event{Type: usecase.EventRegistered, OwnerID: "b"})
+
+	b.Require().Len(got, 1)
+	b.Equal("b", got[0].PeerID)
+}
+
+func (b *busSuite) TestSubscribeAsync() {
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+
+	var got []usecase.Event
+
+	unsubscribe := b.SUT.SubscribeAsync(usecase.EventFilter{}, 2, func(ctx context.Context, event usecase.Event) {
+		<-release
+		b.NoError(ctx.Err())
+
+		got = append(got, event)
+	})
+
+	b.SUT.Publish(ctx, usecase.Event{Type: usecase.EventRegistered, OwnerID: "a"})
+	b.SUT.Publish(ctx, usecase.Event{Type: usecase.EventRegistered, OwnerID: "b"})
+	// the publisher is not held up by the blocked subscriber, and its context may end meanwhile
+	cancel()
+	close(release)
+
+	// unsubscribing waits for the buffered events
+	unsubscribe()
+
+	b.Require().Len(got, 2)
+	b.Equal("a", got[0].OwnerID)
+	b.Equal("b", got[1].OwnerID)
+}
+
+func TestBus(t *testing.T) {
+	suite.Run(t, new(busSuite))
+}
+
+func (u *useCaseSuite) TestBus_SharedWithHandlers() {
+	ctx := context.Background()
+	bus := usecase.NewBus()
+	sut := usecase.New(usecase.Config{Router: u.router, Bus: bus})
+
+	var got []usecase.Event
+
+	defer bus.Subscribe(usecase.EventFilter{}, func(_ context.Context, event usecase.Event) { got = append(got, event) })()
+
+	u.router.EXPECT().Register(ctx, "client-1", gomock.Any())
+	u.router.EXPECT().Unregister(ctx, "client-1")
+
+	u.Require().NoError(sut.RegisterHandler(ctx, usecase.RegisterCMD{OwnerID: "client-1"}))
+	u.Require().NoError(sut.UnregisterHandler(ctx, usecase.UnregisterCMD{OwnerID: "client-1"}))
+
+	u.Require().Len(got, 2)
+	u.Equal(usecase.EventRegistered, got[0].Type)
+	u.Equal(usecase.EventUnregistered, got[1].Type)
+	u.Same(bus, sut.Bus())
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"slices"
+	"time"
+)
+
+// EventType classifies the lifecycle events of the connections and their messages.
+type EventType int
+
+const (
+	EventRegistered EventType = iota + 1
+	EventUnregistered
+	EventKicked
+	EventRelayed
+	EventAckForwarded
+	EventNackForwarded
+	EventDropped
+)
+
+var eventTypeNames = map[EventType]string{
+	EventRegistered:    "registered",
+	EventUnregistered:  "unregistered",
+	EventKicked:        "kicked",
+	EventRelayed:       "relayed",
+	EventAckForwarded:  "ack_forwarded",
+	EventNackForwarded: "nack_forwarded",
+	EventDropped:       "dropped",
+}
+
+func (t EventType) String() string {
+	if name, ok := eventTypeNames[t]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+// Event is something that happened to a connection or to one of its messages.
+type Event struct {
+	Type EventType
+	// OwnerID is the connection the event is about, the sender of the frame for relays, acks and nacks.
+	OwnerID string
+	// PeerID is the other end of a relay, ack or nack.
+	PeerID string
+	// Content is the message the event is about, subscribers exposing events outside the server
+	// should prefer ContentHash.
+	Content string
+	// ContentHash identifies the message without exposing its content.
+	ContentHash string
+	// Reason tells why a message was dropped or nacked.
+	Reason string
+	// Dropped is what an EventDropped would have been had its frame been delivered, EventRelayed,
+	// EventAckForwarded or EventNackForwarded.
+	Dropped EventType
+	// Latency is how long a forwarded ack took since its message was relayed, 0 when this server did not relay it.
+	Latency time.Duration
+	At      time.Time
+}
+
+// drop turns the event of a frame into the EventDropped of that frame for reason.
+func (e *Event) drop(reason string) {
+	e.Type, e.Dropped, e.Reason = EventDropped, e.Type, reason
+}
+
+// EventFilter selects the events of a subscription, an empty field matches every event.
+type EventFilter struct {
+	// OwnerIDs matches the events whose owner or peer is one of them.
+	OwnerIDs []string
+	Types    []EventType
+}
+
+func (f EventFilter) matches(event Event) bool {
+	if len(f.Types) > 0 && !slices.Contains(f.Types, event.Type) {
+		return false
+	}
+
+	return len(f.OwnerIDs) == 0 ||
+		slices.Contains(f.OwnerIDs, event.OwnerID) ||
+		(event.PeerID != "" && slices.Contains(f.OwnerIDs, event.PeerID))
+}
+
+// ContentHash returns a short, stable fingerprint of a message content, the one the events carry and the frame
+// logs and spans keep.
+func ContentHash(content string) string {
+	return v1.ContentHash(content)
+}
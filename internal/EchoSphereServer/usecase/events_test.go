@@ -0,0 +1,99 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase/internal/mocks"
+	"go.uber.org/mock/gomock"
+	"time"
+)
+
+func (u *useCaseSuite) TestWatch_Relayed() {
+	ctx := context.Background()
+	cmd := usecase.RelayCMD{From: "owner1", Content: "test message"}
+	relayer := mocks.NewMockMessager(gomock.NewController(u.T()))
+
+	events, unsubscribe := u.SUT.Watch(1, usecase.EventFilter{})
+	defer unsubscribe()
+
+	u.router.EXPECT().AcquireRandomRelayer(ctx, cmd.From).Return("random1", relayer, nil)
+	u.router.EXPECT().AcquireRelayer(ctx, cmd.From).Return(relayer, nil)
+	u.router.EXPECT().ReleaseRelayer(ctx, gomock.Any(), relayer).Times(2)
+	relayer.EXPECT().SendMsg(ctx, gomock.Any()).Return(nil).Times(2)
+
+	u.Require().NoError(u.SUT.RelayHandler(ctx, cmd))
+
+	event := <-events
+	u.Equal(usecase.EventRelayed, event.Type)
+	u.Equal(cmd.From, event.OwnerID)
+	u.Equal("random1", event.PeerID)
+	u.NotEmpty(event.ContentHash)
+	u.NotContains(event.ContentHash, cmd.Content)
+}
+
+func (u *useCaseSuite) TestWatch_AckDropped() {
+	ctx := context.Background()
+	cmd := usecase.AckCMD{From: "client-1", To: "client-2", Content: "ack message"}
+
+	events, unsubscribe := u.SUT.Watch(1, usecase.EventFilter{})
+	defer unsubscribe()
+
+	u.router.EXPECT().AcquireRelayer(ctx, cmd.To).Return(nil, errors.New("relayer not found"))
+
+	u.Require().Error(u.SUT.AckHandler(ctx, cmd))
+
+	event := <-events
+	u.Equal(usecase.EventDropped, event.Type)
+	u.Equal(usecase.EventAckForwarded, event.Dropped)
+	u.Equal(cmd.To, event.PeerID)
+	u.Equal("relayer not found", event.Reason)
+}
+
+func (u *useCaseSuite) TestWatch_Filter() {
+	ctx := context.Background()
+	relayer := mocks.NewMockMessager(gomock.NewController(u.T()))
+
+	byOwner, unsubscribeOwner := u.SUT.Watch(2, usecase.EventFilter{OwnerIDs: []string{"client-2"}})
+	defer unsubscribeOwner()
+
+	byType, unsubscribeType := u.SUT.Watch(2, usecase.EventFilter{Types: []usecase.EventType{usecase.EventRegistered}})
+	defer unsubscribeType()
+
+	u.router.EXPECT().Register(ctx, "client-1", gomock.Any())
+	u.router.EXPECT().AcquireRelayer(ctx, "client-2").Return(relayer, nil)
+	u.router.EXPECT().ReleaseRelayer(ctx, "client-2", relayer)
+	relayer.EXPECT().SendMsg(ctx, gomock.Any()).Return(nil)
+
+	u.Require().NoError(u.SUT.RegisterHandler(ctx, usecase.RegisterCMD{OwnerID: "client-1", StreamSender: relayer}))
+	u.Require().NoError(u.SUT.AckHandler(ctx, usecase.AckCMD{From: "client-1", To: "client-2", Content: "x"}))
+
+	// client-2 is the peer of the ack, not the owner of the registration
+	u.Require().Len(byOwner, 1)
+	u.Equal(usecase.EventAckForwarded, (<-byOwner).Type)
+
+	u.Require().Len(byType, 1)
+	u.Equal(usecase.EventRegistered, (<-byType).Type)
+}
+
+func (u *useCaseSuite) TestWatch_AckLatency() {
+	ctx := context.Background()
+	originator := mocks.NewMockMessager(gomock.NewController(u.T()))
+
+	u.relay(u.SUT, "originator", "recipient", "message")
+
+	events, unsubscribe := u.SUT.Watch(1, usecase.EventFilter{Types: []usecase.EventType{usecase.EventAckForwarded}})
+	defer unsubscribe()
+
+	u.router.EXPECT().AcquireRelayer(ctx, "originator").Return(originator, nil).Times(2)
+	u.router.EXPECT().ReleaseRelayer(ctx, "originator", originator).Times(2)
+	originator.EXPECT().SendMsg(ctx, gomock.Any()).Return(nil).Times(2)
+
+	time.Sleep(time.Millisecond)
+	u.Require().NoError(u.SUT.AckHandler(ctx, usecase.AckCMD{From: "recipient", To: "originator", Content: "message"}))
+	u.GreaterOrEqual((<-events).Latency, time.Millisecond)
+
+	// an ack for a message this server did not relay has no latency
+	u.Require().NoError(u.SUT.AckHandler(ctx, usecase.AckCMD{From: "recipient", To: "originator", Content: "other"}))
+	u.Zero((<-events).Latency)
+}
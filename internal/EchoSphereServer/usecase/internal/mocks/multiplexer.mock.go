@@ -108,6 +108,21 @@ func (mr *MockRelayRouterMockRecorder) AcquireRelayer(ctx, ownerID any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireRelayer", reflect.TypeOf((*MockRelayRouter)(nil).AcquireRelayer), ctx, ownerID)
 }
 
+// PoolStats mocks base method.
+func (m *MockRelayRouter) PoolStats(ctx context.Context) (core.PoolStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PoolStats", ctx)
+	ret0, _ := ret[0].(core.PoolStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PoolStats indicates an expected call of PoolStats.
+func (mr *MockRelayRouterMockRecorder) PoolStats(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PoolStats", reflect.TypeOf((*MockRelayRouter)(nil).PoolStats), ctx)
+}
+
 // Register mocks base method.
 func (m *MockRelayRouter) Register(ctx context.Context, ownerID string, relayer core.Messager) {
 	m.ctrl.T.Helper()
@@ -131,3 +146,52 @@ func (mr *MockRelayRouterMockRecorder) ReleaseRelayer(ctx, ownerID, relayer any)
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseRelayer", reflect.TypeOf((*MockRelayRouter)(nil).ReleaseRelayer), ctx, ownerID, relayer)
 }
+
+// Unregister mocks base method.
+func (m *MockRelayRouter) Unregister(ctx context.Context, ownerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unregister", ctx, ownerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unregister indicates an expected call of Unregister.
+func (mr *MockRelayRouterMockRecorder) Unregister(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unregister", reflect.TypeOf((*MockRelayRouter)(nil).Unregister), ctx, ownerID)
+}
+
+// MockDisconnecter is a mock of Disconnecter interface.
+type MockDisconnecter struct {
+	ctrl     *gomock.Controller
+	recorder *MockDisconnecterMockRecorder
+}
+
+// MockDisconnecterMockRecorder is the mock recorder for MockDisconnecter.
+type MockDisconnecterMockRecorder struct {
+	mock *MockDisconnecter
+}
+
+// NewMockDisconnecter creates a new mock instance.
+func NewMockDisconnecter(ctrl *gomock.Controller) *MockDisconnecter {
+	mock := &MockDisconnecter{ctrl: ctrl}
+	mock.recorder = &MockDisconnecterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDisconnecter) EXPECT() *MockDisconnecterMockRecorder {
+	return m.recorder
+}
+
+// Disconnect mocks base method.
+func (m *MockDisconnecter) Disconnect() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Disconnect")
+}
+
+// Disconnect indicates an expected call of Disconnect.
+func (mr *MockDisconnecterMockRecorder) Disconnect() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disconnect", reflect.TypeOf((*MockDisconnecter)(nil).Disconnect))
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/core"
+)
+
+// KickCMD represents a command to disconnect a client.
+type KickCMD struct {
+	OwnerID string
+}
+
+// KickHandler unregisters the owner and closes its connection, which must be attached to this server.
+func (uc *UC) KickHandler(ctx context.Context, cmd KickCMD) error {
+	defer uc.lock()()
+
+	relayer, err := uc.router.AcquireRelayer(ctx, cmd.OwnerID)
+	if err != nil {
+		return err
+	}
+
+	disconnecter, ok := core.AsDisconnecter(relayer)
+	if !ok {
+		uc.router.ReleaseRelayer(ctx, cmd.OwnerID, relayer)
+
+		return fmt.Errorf("%w: %s is not connected to this server", core.ErrFailedToGetRelayer, cmd.OwnerID)
+	}
+
+	// the relayer is withdrawn, releasing it drops the lease
+	err = uc.router.Unregister(ctx, cmd.OwnerID)
+	uc.router.ReleaseRelayer(ctx, cmd.OwnerID, relayer)
+
+	uc.disconnect(cmd.OwnerID)
+	uc.bus.Publish(ctx, Event{Type: EventKicked, OwnerID: cmd.OwnerID})
+
+	err = errors.Join(err, uc.nackOrphaned(ctx, cmd.OwnerID))
+
+	disconnecter.Disconnect()
+
+	return err
+}
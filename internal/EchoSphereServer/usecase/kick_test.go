@@ -0,0 +1,48 @@
+package usecase_test
+
+import (
+	"context"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/core"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+type disconnectableSender struct {
+	mockStreamSender
+	disconnected bool
+}
+
+func (d *disconnectableSender) Disconnect() { d.disconnected = true }
+
+func (u *useCaseSuite) TestKickHandler() {
+	ctx := context.Background()
+	sender := &disconnectableSender{}
+	sut := usecase.New(usecase.Config{Router: u.router})
+
+	var registered core.Messager
+
+	u.router.EXPECT().Register(ctx, "client-1", gomock.Any()).Do(func(_ context.Context, _ string, m core.Messager) {
+		registered = m
+	})
+	u.Require().NoError(sut.RegisterHandler(ctx, usecase.RegisterCMD{OwnerID: "client-1", StreamSender: sender}))
+	u.Require().Len(sut.Connections(), 1)
+
+	u.router.EXPECT().AcquireRelayer(ctx, "client-1").Return(registered, nil)
+	u.router.EXPECT().Unregister(ctx, "client-1")
+	u.router.EXPECT().ReleaseRelayer(ctx, "client-1", registered)
+
+	u.Require().NoError(sut.KickHandler(ctx, usecase.KickCMD{OwnerID: "client-1"}))
+	u.True(sender.disconnected)
+	u.Empty(sut.Connections())
+}
+
+func (u *useCaseSuite) TestKickHandler_NotLocal() {
+	ctx := context.Background()
+	remote := mocks.NewMockMessager(gomock.NewController(u.T()))
+
+	u.router.EXPECT().AcquireRelayer(ctx, "client-1").Return(remote, nil)
+	u.router.EXPECT().ReleaseRelayer(ctx, "client-1", remote)
+
+	u.Require().ErrorIs(u.SUT.KickHandler(ctx, usecase.KickCMD{OwnerID: "client-1"}), core.ErrFailedToGetRelayer)
+}
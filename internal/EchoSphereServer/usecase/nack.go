@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/core"
+	"strings"
+)
+
+// NackCMD represents a command to refuse a message.
+type NackCMD struct {
+	From    string
+	To      string
+	Content string
+	Reason  v1.NackReason
+}
+
+// NackHandler forwards the refusal of a message to its originator.
+func (uc *UC) NackHandler(ctx context.Context, cmd NackCMD) error {
+	defer uc.lock()()
+
+	return uc.sendNack(ctx, &v1.Nack{From: cmd.From, To: cmd.To, Content: cmd.Content, Reason: cmd.Reason})
+}
+
+// nackTimeout tells the originator that the recipient did not acknowledge in time.
+func (uc *UC) nackTimeout(key pendingKey, recipient string) {
+	defer uc.lock()()
+
+	// the originator may be gone as well, there is nobody left to tell
+	_ = uc.sendNack(context.Background(), &v1.Nack{ //nolint:errcheck
+		From:    recipient,
+		To:      key.originator,
+		Content: key.content,
+		Reason:  v1.NackReason_NACK_REASON_TIMEOUT,
+	})
+}
+
+func (uc *UC) sendNack(ctx context.Context, nack *v1.Nack) error {
+	event := Event{
+		Type:    EventNackForwarded,
+		OwnerID: nack.GetFrom(),
+		PeerID:  nack.GetTo(),
+		Content: nack.GetContent(),
+		Reason:  strings.ToLower(strings.TrimPrefix(nack.GetReason().String(), "NACK_REASON_")),
+	}
+
+	relayer, err := uc.router.AcquireRelayer(ctx, nack.GetTo())
+	if err != nil {
+		event.drop(err.Error())
+		uc.bus.Publish(ctx, event)
+
+		return err
+	}
+	defer uc.router.ReleaseRelayer(ctx, nack.GetTo(), relayer)
+
+	if err := sendRelayMessage(ctx, relayer, nack); err != nil {
+		event.drop(err.Error())
+		uc.bus.Publish(ctx, event)
+
+		return fmt.Errorf("%w to %s: %w", core.ErrFailedToRelay, nack.GetTo(), err)
+	}
+
+	uc.bus.Publish(ctx, event)
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/core"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase/internal/mocks"
+	"go.uber.org/mock/gomock"
+	"time"
+)
+
+func newNackResponse(from, to, content string, reason v1.NackReason) *v1.EchoSphereTransmissionServiceTransmitResponse {
+	return &v1.EchoSphereTransmissionServiceTransmitResponse{
+		OutgoingData: &v1.EchoSphereTransmissionServiceTransmitResponse_Nack{
+			Nack: &v1.Nack{From: from, To: to, Content: content, Reason: reason},
+		},
+	}
+}
+
+// relay relays content from originator to recipient, leaving the message pending.
+func (u *useCaseSuite) relay(sut *usecase.UC, originator, recipient, content string) {
+	ctx := context.Background()
+
+	randomRelayer := mocks.NewMockMessager(gomock.NewController(u.T()))
+	ownerRelayer := mocks.NewMockMessager(gomock.NewController(u.T()))
+
+	u.router.EXPECT().AcquireRandomRelayer(ctx, originator).Return(recipient, randomRelayer, nil)
+	u.router.EXPECT().AcquireRelayer(ctx, originator).Return(ownerRelayer, nil)
+	u.router.EXPECT().ReleaseRelayer(ctx, gomock.Any(), gomock.Any()).Times(2)
+	randomRelayer.EXPECT().SendMsg(ctx, gomock.Any()).Return(nil)
+	ownerRelayer.EXPECT().SendMsg(ctx, gomock.Any()).Return(nil)
+
+	u.Require().NoError(sut.RelayHandler(ctx, usecase.RelayCMD{From: originator, Content: content}))
+}
+
+func (u *useCaseSuite) TestNackHandler_Success() {
+	ctx := context.Background()
+	cmd := usecase.NackCMD{From: "client-1", To: "client-2", Content: "message", Reason: v1.NackReason_NACK_REASON_REJECTED}
+
+	originator := mocks.NewMockMessager(gomock.NewController(u.T()))
+
+	u.router.EXPECT().AcquireRelayer(ctx, cmd.To).Return(originator, nil)
+	u.router.EXPECT().ReleaseRelayer(ctx, cmd.To, originator)
+	originator.EXPECT().SendMsg(ctx, newNackResponse(cmd.From, cmd.To, cmd.Content, cmd.Reason)).Return(nil)
+
+	u.Require().NoError(u.SUT.NackHandler(ctx, cmd))
+}
+
+func (u *useCaseSuite) TestNackHandler_RelayerNotFound() {
+	ctx := context.Background()
+	cmd := usecase.NackCMD{From: "client-1", To: "client-2", Content: "message"}
+
+	u.router.EXPECT().AcquireRelayer(ctx, cmd.To).Return(nil, core.ErrFailedToGetRelayer)
+
+	u.Require().ErrorIs(u.SUT.NackHandler(ctx, cmd), core.ErrFailedToGetRelayer)
+}
+
+func (u *useCaseSuite) TestAckTimeout_NacksOriginator() {
+	ctx := context.Background()
+	sut := usecase.New(usecase.Config{Router: u.router, AckTimeout: 10 * time.Millisecond})
+
+	received := make(chan any, 2)
+	recipient := mocks.NewMockMessager(gomock.NewController(u.T()))
+	originator := mocks.NewMockMessager(gomock.NewController(u.T()))
+
+	// every expectation is set up front, the deadline fires on its own goroutine
+	u.router.EXPECT().AcquireRandomRelayer(ctx, "originator").Return("recipient", recipient, nil)
+	u.router.EXPECT().AcquireRelayer(ctx, "originator").Return(originator, nil).Times(2)
+	u.router.EXPECT().ReleaseRelayer(ctx, gomock.Any(), gomock.Any()).Times(3)
+	recipient.EXPECT().SendMsg(ctx, gomock.Any()).Return(nil)
+	originator.EXPECT().SendMsg(ctx, gomock.Any()).Times(2).DoAndReturn(func(_ context.Context, m any) error {
+		received <- m
+
+		return nil
+	})
+
+	u.Require().NoError(sut.RelayHandler(ctx, usecase.RelayCMD{From: "originator", Content: "message"}))
+
+	// the echo of its own message comes first
+	<-received
+
+	select {
+	case m := <-received:
+		u.Equal(newNackResponse("recipient", "originator", "message", v1.NackReason_NACK_REASON_TIMEOUT), m)
+	case <-time.After(time.Second):
+		u.Fail("originator was not nacked")
+	}
+
+	u.Zero(sut.PendingAcks())
+}
+
+func (u *useCaseSuite) TestAckSettlesPending() {
+	ctx := context.Background()
+	sut := usecase.New(usecase.Config{Router: u.router, AckTimeout: time.Hour})
+
+	var registered core.Messager
+
+	u.router.EXPECT().Register(ctx, "originator", gomock.Any()).Do(func(_ context.Context, _ string, m core.Messager) {
+		registered = m
+	})
+	u.Require().NoError(sut.RegisterHandler(ctx, usecase.RegisterCMD{OwnerID: "originator", StreamSender: mockStreamSender{}}))
+
+	u.relay(sut, "originator", "recipient", "message")
+	u.Equal(int64(1), sut.PendingAcks())
+
+	// the ack reaches the originator through its registered sender, whichever node relays it
+	u.Require().NoError(registered.SendMsg(ctx, &v1.EchoSphereTransmissionServiceTransmitResponse{
+		OutgoingData: &v1.EchoSphereTransmissionServiceTransmitResponse_Ack{
+			Ack: &v1.Ack{From: "recipient", To: "originator", Content: "message"},
+		},
+	}))
+
+	u.Zero(sut.PendingAcks())
+}
+
+func (u *useCaseSuite) TestUnregisterHandler_NacksRecipientGone() {
+	ctx := context.Background()
+	sut := usecase.New(usecase.Config{Router: u.router, AckTimeout: time.Hour})
+
+	u.relay(sut, "originator", "recipient", "message")
+
+	originator := mocks.NewMockMessager(gomock.NewController(u.T()))
+
+	u.router.EXPECT().Unregister(ctx, "recipient")
+	u.router.EXPECT().AcquireRelayer(ctx, "originator").Return(originator, nil)
+	u.router.EXPECT().ReleaseRelayer(ctx, "originator", originator)
+	originator.
+		EXPECT().
+		SendMsg(ctx, newNackResponse("recipient", "originator", "message", v1.NackReason_NACK_REASON_RECIPIENT_GONE)).
+		Return(errors.New("stream closed"))
+
+	err := sut.UnregisterHandler(ctx, usecase.UnregisterCMD{OwnerID: "recipient"})
+	u.Require().ErrorIs(err, core.ErrFailedToRelay)
+	u.Zero(sut.PendingAcks())
+}
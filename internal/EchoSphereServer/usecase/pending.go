@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/core"
+	"slices"
+	"sync"
+	"time"
+)
+
+// pendingKey identifies a relayed message the same way its ack does.
+type pendingKey struct {
+	originator string
+	content    string
+}
+
+type pendingAck struct {
+	recipient string
+	relayedAt time.Time
+	timer     *time.Timer
+}
+
+// Pending is a relayed message waiting for its ack.
+type Pending struct {
+	Originator string
+	Recipient  string
+	Content    string
+	RelayedAt  time.Time
+}
+
+// ackTracker keeps the relayed messages still waiting for their ack and calls onTimeout for the ones
+// not acknowledged within timeout, a zero timeout never expires them.
+type ackTracker struct {
+	mu        sync.Mutex
+	timeout   time.Duration
+	entries   map[pendingKey]*pendingAck
+	onTimeout func(key pendingKey, recipient string)
+}
+
+func newAckTracker(timeout time.Duration, onTimeout func(key pendingKey, recipient string)) *ackTracker {
+	return &ackTracker{timeout: timeout, entries: make(map[pendingKey]*pendingAck), onTimeout: onTimeout}
+}
+
+// track records that the message of originator was relayed to recipient, a resend restarts its deadline.
+func (t *ackTracker) track(originator, content, recipient string) {
+	key := pendingKey{originator: originator, content: content}
+	entry := &pendingAck{recipient: recipient, relayedAt: time.Now()}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if previous, ok := t.entries[key]; ok && previous.timer != nil {
+		previous.timer.Stop()
+	}
+
+	if t.timeout > 0 {
+		entry.timer = time.AfterFunc(t.timeout, func() {
+			if t.remove(key, entry) {
+				t.onTimeout(key, recipient)
+			}
+		})
+	}
+
+	t.entries[key] = entry
+}
+
+// settle forgets the message once the originator got its ack or nack.
+func (t *ackTracker) settle(originator, content string) {
+	key := pendingKey{originator: originator, content: content}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if entry, ok := t.entries[key]; ok {
+		if entry.timer != nil {
+			entry.timer.Stop()
+		}
+
+		delete(t.entries, key)
+	}
+}
+
+// relayedAt returns when the message of originator was relayed, if it is still waiting for its ack.
+func (t *ackTracker) relayedAt(originator, content string) (time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entry, ok := t.entries[pendingKey{originator: originator, content: content}]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return entry.relayedAt, true
+}
+
+// drop forgets the messages sent by or relayed to ownerID and returns the ones that were relayed to it.
+func (t *ackTracker) drop(ownerID string) []pendingKey {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var orphaned []pendingKey
+
+	for key, entry := range t.entries {
+		if key.originator != ownerID && entry.recipient != ownerID {
+			continue
+		}
+
+		if entry.timer != nil {
+			entry.timer.Stop()
+		}
+
+		delete(t.entries, key)
+
+		if key.originator != ownerID {
+			orphaned = append(orphaned, key)
+		}
+	}
+
+	return orphaned
+}
+
+func (t *ackTracker) list() []Pending {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	pending := make([]Pending, 0, len(t.entries))
+
+	for key, entry := range t.entries {
+		pending = append(pending, Pending{
+			Originator: key.originator,
+			Recipient:  entry.recipient,
+			Content:    key.content,
+			RelayedAt:  entry.relayedAt,
+		})
+	}
+
+	slices.SortFunc(pending, func(a, b Pending) int { return a.RelayedAt.Compare(b.RelayedAt) })
+
+	return pending
+}
+
+func (t *ackTracker) len() int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return int64(len(t.entries))
+}
+
+// remove deletes the entry unless it was settled or replaced in the meantime.
+func (t *ackTracker) remove(key pendingKey, entry *pendingAck) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.entries[key] != entry {
+		return false
+	}
+
+	delete(t.entries, key)
+
+	return true
+}
+
+// settlingMessager settles the pending messages of its owner as their acks and nacks are delivered to it,
+// whichever node or handler relays them.
+type settlingMessager struct {
+	core.Messager
+
+	ownerID string
+	tracker *ackTracker
+}
+
+func (s settlingMessager) SendMsg(ctx context.Context, m any) error {
+	if frame, ok := m.(*v1.EchoSphereTransmissionServiceTransmitResponse); ok {
+		if ack := frame.GetAck(); ack != nil && ack.GetTo() == s.ownerID {
+			s.tracker.settle(s.ownerID, ack.GetContent())
+		}
+
+		if nack := frame.GetNack(); nack != nil && nack.GetTo() == s.ownerID {
+			s.tracker.settle(s.ownerID, nack.GetContent())
+		}
+	}
+
+	return s.Messager.SendMsg(ctx, m)
+}
+
+// Unwrap returns the sender of the connection.
+func (s settlingMessager) Unwrap() core.Messager {
+	return s.Messager
+}
@@ -11,10 +11,18 @@ type RegisterCMD struct {
 }
 
 func (uc *UC) RegisterHandler(ctx context.Context, cmd RegisterCMD) error {
-	uc.mu.Lock()
-	defer uc.mu.Unlock()
+	defer uc.lock()()
 
-	uc.router.Register(ctx, cmd.OwnerID, cmd.StreamSender)
+	connected, err := uc.connect(cmd.OwnerID)
+	if err != nil {
+		return err
+	}
+
+	if connected {
+		uc.bus.Publish(ctx, Event{Type: EventRegistered, OwnerID: cmd.OwnerID})
+	}
+
+	uc.router.Register(ctx, cmd.OwnerID, settlingMessager{Messager: cmd.StreamSender, ownerID: cmd.OwnerID, tracker: uc.acks})
 
 	return nil
 }
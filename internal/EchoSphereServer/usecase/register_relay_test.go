@@ -2,6 +2,7 @@ package usecase_test
 
 import (
 	"context"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/core"
 	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
 	"go.uber.org/mock/gomock"
 )
@@ -22,3 +23,19 @@ func (u *useCaseSuite) TestRegisterHandler() {
 	err := u.SUT.RegisterHandler(ctx, cmd)
 	u.Require().NoError(err)
 }
+
+func (u *useCaseSuite) TestRegisterHandler_MaxConnections() {
+	ctx := context.Background()
+	sut := usecase.New(usecase.Config{Router: u.router, MaxConnections: 1})
+
+	u.router.EXPECT().Register(ctx, "client-1", gomock.Any()).Times(2)
+
+	u.Require().NoError(sut.RegisterHandler(ctx, usecase.RegisterCMD{OwnerID: "client-1", StreamSender: mockStreamSender{}}))
+
+	err := sut.RegisterHandler(ctx, usecase.RegisterCMD{OwnerID: "client-2", StreamSender: mockStreamSender{}})
+	u.Require().ErrorIs(err, core.ErrTooManyRelayers)
+
+	// the registered client keeps sending
+	u.Require().NoError(sut.RegisterHandler(ctx, usecase.RegisterCMD{OwnerID: "client-1", StreamSender: mockStreamSender{}}))
+	u.Equal(1, sut.Stats().Connections)
+}
@@ -4,37 +4,56 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	v1 "github.com/k4l1ma/EchoSphere/api/v1"
 	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/core"
 )
 
-// RelayCMD represents a command to relay a message.
+// RelayCMD represents a command to relay a message, TraceContext is the W3C trace context the relayed message carries.
 type RelayCMD struct {
-	From    string
-	Content string
+	From         string
+	Content      string
+	TraceContext map[string]string
 }
 
 // RelayHandler handles the relay of a message from one owner to a random relayer and back.
 func (uc *UC) RelayHandler(ctx context.Context, cmd RelayCMD) error {
+	uc.relaying.Add(1)
+	defer uc.relaying.Add(-1)
+
 	// this needs to be an ACID transaction we don't want a relayer being stole while we do actions
-	uc.mu.Lock()
-	defer uc.mu.Unlock()
+	defer uc.lock()()
 
-	randomOwnerID, randomRelayer, err := uc.router.AcquireRandomRelayer(ctx, cmd.From)
-	if err != nil && !errors.Is(err, core.ErrFailedToGetRelayer) {
-		return err
+	randomOwnerID, randomRelayer, acquireErr := uc.router.AcquireRandomRelayer(ctx, cmd.From)
+	if acquireErr != nil && !errors.Is(acquireErr, core.ErrFailedToGetRelayer) {
+		return acquireErr
 	}
 	defer uc.router.ReleaseRelayer(ctx, randomOwnerID, randomRelayer)
 
-	if err := sendRelayMessage(ctx, randomRelayer, &v1.Message{From: cmd.From, Content: cmd.Content}); err != nil {
-		return err
+	event := Event{Type: EventRelayed, OwnerID: cmd.From, PeerID: randomOwnerID, Content: cmd.Content}
+
+	if err := sendRelayMessage(ctx, randomRelayer, &v1.Message{From: cmd.From, Content: cmd.Content, TraceContext: cmd.TraceContext}); err != nil {
+		event.drop(err.Error())
+		uc.bus.Publish(ctx, event)
+
+		return fmt.Errorf("%w to %s: %w", core.ErrFailedToRelay, randomOwnerID, err)
+	}
+
+	// with nobody to relay to there is no ack to wait for
+	if acquireErr == nil {
+		uc.acks.track(cmd.From, cmd.Content, randomOwnerID)
+	} else {
+		event.PeerID = ""
+		event.drop("no recipient available")
 	}
 
+	uc.bus.Publish(ctx, event)
+
 	ownerRelayer, err := uc.router.AcquireRelayer(ctx, cmd.From)
 	if err != nil {
 		return err
 	}
 	defer uc.router.ReleaseRelayer(ctx, cmd.From, ownerRelayer)
 
-	return sendRelayMessage(ctx, ownerRelayer, &v1.Message{From: cmd.From, Content: cmd.Content})
+	return sendRelayMessage(ctx, ownerRelayer, &v1.Message{From: cmd.From, Content: cmd.Content, TraceContext: cmd.TraceContext})
 }
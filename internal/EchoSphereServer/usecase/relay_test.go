@@ -14,7 +14,11 @@ func (u *useCaseSuite) TestRelayHandler_Success() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	cmd := usecase.RelayCMD{From: "owner1", Content: "test message"}
+	cmd := usecase.RelayCMD{
+		From:         "owner1",
+		Content:      "test message",
+		TraceContext: map[string]string{"traceparent": "00-01000000000000000000000000000000-0100000000000000-01"},
+	}
 	randomOwnerID := "random1"
 
 	ownerRelayer := mocks.NewMockMessager(gomock.NewController(u.T()))
@@ -29,7 +33,8 @@ func (u *useCaseSuite) TestRelayHandler_Success() {
 		ctx,
 		&v1.EchoSphereTransmissionServiceTransmitResponse{
 			OutgoingData: &v1.EchoSphereTransmissionServiceTransmitResponse_Message{
-				Message: &v1.Message{From: cmd.From, Content: cmd.Content},
+				// the relayed message carries the trace on
+				Message: &v1.Message{From: cmd.From, Content: cmd.Content, TraceContext: cmd.TraceContext},
 			},
 		},
 	).Return(nil)
@@ -42,7 +47,7 @@ func (u *useCaseSuite) TestRelayHandler_Success() {
 		ctx,
 		&v1.EchoSphereTransmissionServiceTransmitResponse{
 			OutgoingData: &v1.EchoSphereTransmissionServiceTransmitResponse_Message{
-				Message: &v1.Message{From: cmd.From, Content: cmd.Content},
+				Message: &v1.Message{From: cmd.From, Content: cmd.Content, TraceContext: cmd.TraceContext},
 			},
 		},
 	).Return(nil)
@@ -93,3 +98,17 @@ func (u *useCaseSuite) TestRelayHandler_FailedToGetRandomRelayer() {
 	err := u.SUT.RelayHandler(ctx, cmd)
 	u.Require().NoError(err)
 }
+
+func (u *useCaseSuite) TestRelayHandler_FailedToSendToRandomRelayer() {
+	ctx := context.Background()
+	cmd := usecase.RelayCMD{From: "owner1", Content: "test message"}
+	randomRelayer := mocks.NewMockMessager(gomock.NewController(u.T()))
+
+	u.router.EXPECT().AcquireRandomRelayer(ctx, cmd.From).Return("random1", randomRelayer, nil)
+	u.router.EXPECT().ReleaseRelayer(ctx, "random1", randomRelayer)
+
+	randomRelayer.EXPECT().SendMsg(ctx, gomock.Any()).Return(errors.New("stream closed"))
+
+	err := u.SUT.RelayHandler(ctx, cmd)
+	u.Require().ErrorIs(err, core.ErrFailedToRelay)
+}
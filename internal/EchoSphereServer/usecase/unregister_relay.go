@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
 )
 
 type UnregisterCMD struct {
@@ -9,10 +11,29 @@ type UnregisterCMD struct {
 }
 
 func (uc *UC) UnregisterHandler(ctx context.Context, cmd UnregisterCMD) error {
-	uc.mu.Lock()
-	defer uc.mu.Unlock()
+	defer uc.lock()()
 
-	_, err := uc.router.AcquireRelayer(ctx, cmd.OwnerID)
+	err := uc.router.Unregister(ctx, cmd.OwnerID)
+
+	if uc.disconnect(cmd.OwnerID) {
+		uc.bus.Publish(ctx, Event{Type: EventUnregistered, OwnerID: cmd.OwnerID})
+	}
+
+	return errors.Join(err, uc.nackOrphaned(ctx, cmd.OwnerID))
+}
+
+// nackOrphaned tells the originators of the messages relayed to ownerID that they will never be acked.
+func (uc *UC) nackOrphaned(ctx context.Context, ownerID string) error {
+	var err error
+
+	for _, orphaned := range uc.acks.drop(ownerID) {
+		err = errors.Join(err, uc.sendNack(ctx, &v1.Nack{
+			From:    ownerID,
+			To:      orphaned.originator,
+			Content: orphaned.content,
+			Reason:  v1.NackReason_NACK_REASON_RECIPIENT_GONE,
+		}))
+	}
 
 	return err
 }
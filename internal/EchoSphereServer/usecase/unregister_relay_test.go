@@ -12,7 +12,7 @@ func (u *useCaseSuite) TestUnregisterHandler() {
 		OwnerID: "client-1",
 	}
 
-	u.router.EXPECT().AcquireRelayer(gomock.Any(), gomock.Any()).Times(1)
+	u.router.EXPECT().Unregister(gomock.Any(), gomock.Any()).Times(1)
 
 	err := u.SUT.UnregisterHandler(ctx, cmd)
 	u.Require().NoError(err)
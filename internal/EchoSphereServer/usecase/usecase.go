@@ -2,27 +2,177 @@ package usecase
 
 import (
 	"context"
+	"fmt"
 	v1 "github.com/k4l1ma/EchoSphere/api/v1"
 	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/core"
+	"slices"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type UC struct {
 	router core.RelayRouter
 	mu     *sync.Mutex
+	queued *atomic.Int64
+	acks   *ackTracker
+	// relaying counts the relay commands received and not handled yet
+	relaying *atomic.Int64
+
+	// connections holds when each owner connected to this server, guarded by connMu so they are listed without
+	// waiting for the handlers
+	connMu      *sync.Mutex
+	connections map[string]time.Time
+	// connected is the size of connections, readable without waiting for the handlers
+	connected *atomic.Int64
+	// maxConnections caps connections, 0 leaves it unbounded
+	maxConnections int64
+	bus            *Bus
+}
+
+// Stats is a snapshot of the work held by the use cases, every field is zero once the clients are gone.
+type Stats struct {
+	Connections   int
+	PendingRelays int64
+	PendingAcks   int64
+	QueueDepth    int64
+}
+
+// Connection is a client connected to this server.
+type Connection struct {
+	OwnerID     string
+	ConnectedAt time.Time
+}
+
+// Config represents the configuration for creating a new UC.
+type Config struct {
+	Router core.RelayRouter
+	// AckTimeout is how long a relayed message waits for its ack before its originator is nacked, 0 disables it.
+	AckTimeout time.Duration
+	// Bus receives the events of the handlers, a private one is created if nil.
+	Bus *Bus
+	// MaxConnections caps the relayers registered by this server, 0 leaves it unbounded.
+	MaxConnections int64
+}
+
+func New(cfg Config) *UC {
+	if cfg.Bus == nil {
+		cfg.Bus = NewBus()
+	}
+
+	uc := &UC{router: cfg.Router, mu: &sync.Mutex{}, queued: &atomic.Int64{}, relaying: &atomic.Int64{}, connected: &atomic.Int64{}, connMu: &sync.Mutex{}, connections: make(map[string]time.Time), maxConnections: cfg.MaxConnections, bus: cfg.Bus}
+	uc.acks = newAckTracker(cfg.AckTimeout, uc.nackTimeout)
+
+	return uc
+}
+
+// QueueDepth returns the number of commands being handled or waiting for their turn.
+func (uc *UC) QueueDepth() int64 {
+	return uc.queued.Load()
+}
+
+// Connections returns the clients connected to this server, oldest first, it does not wait for the handlers.
+func (uc *UC) Connections() []Connection {
+	uc.connMu.Lock()
+	defer uc.connMu.Unlock()
+
+	connections := make([]Connection, 0, len(uc.connections))
+
+	for ownerID, connectedAt := range uc.connections {
+		connections = append(connections, Connection{OwnerID: ownerID, ConnectedAt: connectedAt})
+	}
+
+	slices.SortFunc(connections, func(a, b Connection) int { return a.ConnectedAt.Compare(b.ConnectedAt) })
+
+	return connections
+}
+
+// Watch subscribes to the lifecycle events matching filter, buffering up to buffer of them,
+// until the returned func is called.
+func (uc *UC) Watch(buffer int, filter EventFilter) (<-chan Event, func()) {
+	return uc.bus.Watch(buffer, filter)
+}
+
+// Bus returns the Bus the handlers publish their events to.
+func (uc *UC) Bus() *Bus {
+	return uc.bus
+}
+
+// Pending returns the relayed messages whose originator has not been acked or nacked yet, oldest first.
+func (uc *UC) Pending() []Pending {
+	return uc.acks.list()
+}
+
+// PendingAcks returns the number of relayed messages whose originator has not been acked or nacked yet.
+func (uc *UC) PendingAcks() int64 {
+	return uc.acks.len()
+}
+
+// Stats returns a snapshot of the work held by the use cases, it does not wait for the handlers.
+func (uc *UC) Stats() Stats {
+	return Stats{
+		Connections:   int(uc.connected.Load()),
+		PendingRelays: uc.relaying.Load(),
+		PendingAcks:   uc.acks.len(),
+		QueueDepth:    uc.queued.Load(),
+	}
+}
+
+// connect records that ownerID connected to this server, it reports false if it already was and fails when the
+// server has maxConnections already, mu must be held.
+func (uc *UC) connect(ownerID string) (bool, error) {
+	uc.connMu.Lock()
+	defer uc.connMu.Unlock()
+
+	if _, ok := uc.connections[ownerID]; ok {
+		return false, nil
+	}
+
+	if uc.maxConnections > 0 && int64(len(uc.connections)) >= uc.maxConnections {
+		return false, fmt.Errorf("%w: %d connections registered", core.ErrTooManyRelayers, uc.maxConnections)
+	}
+
+	uc.connections[ownerID] = time.Now()
+	uc.connected.Add(1)
+
+	return true, nil
+}
+
+// disconnect forgets ownerID, it reports false if it was not connected, mu must be held.
+func (uc *UC) disconnect(ownerID string) bool {
+	uc.connMu.Lock()
+	defer uc.connMu.Unlock()
+
+	if _, ok := uc.connections[ownerID]; !ok {
+		return false
+	}
+
+	delete(uc.connections, ownerID)
+	uc.connected.Add(-1)
+
+	return true
 }
 
-func New(router core.RelayRouter) *UC {
-	return &UC{router: router, mu: &sync.Mutex{}}
+// lock serializes the handlers, counting the commands in the queue, and returns the matching unlock.
+func (uc *UC) lock() func() {
+	uc.queued.Add(1)
+	uc.mu.Lock()
+
+	return func() {
+		uc.mu.Unlock()
+		uc.queued.Add(-1)
+	}
 }
 
 // sendRelayMessage sends a message through the provided relayer.
-func sendRelayMessage[T *v1.Message | *v1.Ack](ctx context.Context, relayer core.Messager, msg T) error {
+func sendRelayMessage[T *v1.Message | *v1.Ack | *v1.Nack](ctx context.Context, relayer core.Messager, msg T) error {
 	switch x := any(msg).(type) {
 	case *v1.Message:
 		return relayer.SendMsg(ctx, &v1.EchoSphereTransmissionServiceTransmitResponse{OutgoingData: &v1.EchoSphereTransmissionServiceTransmitResponse_Message{Message: x}})
 	case *v1.Ack:
 		return relayer.SendMsg(ctx, &v1.EchoSphereTransmissionServiceTransmitResponse{OutgoingData: &v1.EchoSphereTransmissionServiceTransmitResponse_Ack{Ack: x}})
+	case *v1.Nack:
+		return relayer.SendMsg(ctx, &v1.EchoSphereTransmissionServiceTransmitResponse{OutgoingData: &v1.EchoSphereTransmissionServiceTransmitResponse_Nack{Nack: x}})
 	}
 
 	return nil
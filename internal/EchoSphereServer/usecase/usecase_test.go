@@ -1,6 +1,8 @@
 package usecase_test
 
 import (
+	"context"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/core"
 	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
 	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase/internal/mocks"
 	"github.com/stretchr/testify/suite"
@@ -22,9 +24,57 @@ func (u *useCaseSuite) SetupSuite() {
 
 	u.router = mocks.NewMockRelayRouter(ctrl)
 
-	u.SUT = usecase.New(u.router)
+	u.SUT = usecase.New(usecase.Config{Router: u.router})
 }
 
 func TestUseCases(t *testing.T) {
 	suite.Run(t, new(useCaseSuite))
 }
+
+func (u *useCaseSuite) TestStats() {
+	ctx := context.Background()
+	// the stats start from zero
+	sut := usecase.New(usecase.Config{Router: u.router})
+
+	u.router.EXPECT().Register(ctx, "originator", gomock.Any())
+	u.Require().NoError(sut.RegisterHandler(ctx, usecase.RegisterCMD{OwnerID: "originator", StreamSender: mockStreamSender{}}))
+
+	// the relay blocks on the recipient so it shows up while in flight
+	sending, release := make(chan struct{}), make(chan struct{})
+	recipient := mocks.NewMockMessager(gomock.NewController(u.T()))
+	originator := mocks.NewMockMessager(gomock.NewController(u.T()))
+
+	u.router.EXPECT().AcquireRandomRelayer(ctx, "originator").Return("recipient", recipient, nil)
+	u.router.EXPECT().AcquireRelayer(ctx, "originator").Return(originator, nil)
+	u.router.EXPECT().ReleaseRelayer(ctx, gomock.Any(), gomock.Any()).Times(2)
+	recipient.EXPECT().SendMsg(ctx, gomock.Any()).DoAndReturn(func(context.Context, any) error {
+		close(sending)
+		<-release
+
+		return nil
+	})
+	originator.EXPECT().SendMsg(ctx, gomock.Any()).Return(nil)
+
+	relayed := make(chan error, 1)
+
+	go func() { relayed <- sut.RelayHandler(ctx, usecase.RelayCMD{From: "originator", Content: "message"}) }()
+
+	<-sending
+	u.Equal(int64(1), sut.Stats().PendingRelays)
+	// listing the connections does not wait for the relay either
+	u.Len(sut.Connections(), 1)
+
+	close(release)
+	u.Require().NoError(<-relayed)
+	u.Equal(usecase.Stats{Connections: 1, PendingAcks: 1}, sut.Stats())
+
+	u.router.EXPECT().Unregister(ctx, "recipient").Return(core.ErrFailedToGetRelayer)
+	u.router.EXPECT().AcquireRelayer(ctx, "originator").Return(originator, nil)
+	u.router.EXPECT().ReleaseRelayer(ctx, "originator", originator)
+	u.router.EXPECT().Unregister(ctx, "originator")
+	originator.EXPECT().SendMsg(ctx, gomock.Any()).Return(nil)
+
+	u.Require().ErrorIs(sut.UnregisterHandler(ctx, usecase.UnregisterCMD{OwnerID: "recipient"}), core.ErrFailedToGetRelayer)
+	u.Require().NoError(sut.UnregisterHandler(ctx, usecase.UnregisterCMD{OwnerID: "originator"}))
+	u.Zero(sut.Stats())
+}
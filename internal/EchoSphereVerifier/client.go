@@ -0,0 +1,120 @@
+package verifier
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
+	"io"
+)
+
+// maxLineSize bounds a client log line, the frames are logged whole.
+const maxLineSize = 1 << 20
+
+// clientLine is the part of a client log line the Verifier needs, the frames are logged by the stream logger of
+// the client once they are sent or received. With the full capture policy the line holds the whole frame, under
+// Content for a message and Ack for an ack. With the others it holds the Type, the parties and the ContentHash of
+// the frame, and Content is the kept part of the content, if any.
+type clientLine struct {
+	Msg      string `json:"msg"`
+	ClientID string `json:"client_id"`
+	Error    string `json:"error"`
+	Message  struct {
+		Type        string
+		From        string
+		To          string
+		ContentHash string
+		Content     json.RawMessage
+		Ack         *struct {
+			From    string
+			To      string
+			Content string
+		}
+	} `json:"message"`
+}
+
+// clientFrame is a message or an ack logged by a client, identified by the hash of its content.
+type clientFrame struct {
+	typ, from, to, contentHash string
+}
+
+// frame returns the message or ack of the line, false for the other frames and those captured without their
+// content hash.
+func (l clientLine) frame() (clientFrame, bool) {
+	m := l.Message
+
+	if ack := m.Ack; ack != nil {
+		return clientFrame{typ: "ack", from: ack.From, to: ack.To, contentHash: usecase.ContentHash(ack.Content)}, true
+	}
+
+	// a whole message is an object, the content kept by the other policies a string
+	var message struct {
+		From    string
+		Content string
+	}
+
+	if len(m.Content) > 0 && m.Content[0] == '{' {
+		if json.Unmarshal(m.Content, &message) != nil {
+			return clientFrame{}, false
+		}
+
+		return clientFrame{typ: "message", from: message.From, contentHash: usecase.ContentHash(message.Content)}, true
+	}
+
+	if (m.Type != "message" && m.Type != "ack") || m.ContentHash == "" {
+		return clientFrame{}, false
+	}
+
+	return clientFrame{typ: m.Type, from: m.From, to: m.To, contentHash: m.ContentHash}, true
+}
+
+// ReadClientLog adds the frames logged by one or more clients, the lines that are not frames are skipped.
+func (v *Verifier) ReadClientLog(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	for scanner.Scan() {
+		var line clientLine
+
+		// the clients may interleave other output with their logs
+		if json.Unmarshal(scanner.Bytes(), &line) != nil || line.Error != "" {
+			continue
+		}
+
+		switch line.Msg {
+		case "Finished handling SendMsg":
+			v.addClientSent(line)
+		case "Finished handling RecvMsg":
+			v.addClientReceived(line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read client log: %w", err)
+	}
+
+	return nil
+}
+
+func (v *Verifier) addClientSent(line clientLine) {
+	if frame, ok := line.frame(); ok && frame.typ == "message" {
+		v.send(message{originator: frame.from, contentHash: frame.contentHash}, SourceClient)
+	}
+}
+
+func (v *Verifier) addClientReceived(line clientLine) {
+	frame, ok := line.frame()
+	if !ok {
+		return
+	}
+
+	if frame.typ == "ack" {
+		v.ack(message{originator: frame.to, contentHash: frame.contentHash}, SourceClient)
+	}
+
+	// the server echoes every message to its originator as well, only the relays to others are deliveries
+	if frame.typ == "message" && line.ClientID != "" && frame.from != line.ClientID {
+		msg := message{originator: frame.from, contentHash: frame.contentHash}
+		v.deliveries[delivery{message: msg, source: SourceClient, recipient: line.ClientID}]++
+	}
+}
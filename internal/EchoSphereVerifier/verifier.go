@@ -0,0 +1,174 @@
+// Package verifier checks the protocol invariants over the traffic recorded by a run: the audit logs of the servers
+// and the logs of the clients.
+//
+// Every message sent must eventually be acked to its originator, every ack must answer a message, no recipient
+// should get the same message twice, and every connection must be gone once the run is over.
+package verifier
+
+import (
+	"cmp"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/audit"
+	"slices"
+)
+
+// Source tells which recording an observation comes from.
+type Source string
+
+const (
+	SourceServer Source = "server"
+	SourceClient Source = "client"
+)
+
+// Finding is a message, or a delivery of it, that breaks an invariant.
+type Finding struct {
+	Source     Source `json:"source"`
+	Originator string `json:"originator"`
+	// Recipient is the connection the message was delivered to, for duplicate deliveries.
+	Recipient   string `json:"recipient,omitempty"`
+	ContentHash string `json:"content_hash"`
+	// Count is how many times the message was delivered, for duplicate deliveries.
+	Count int `json:"count,omitempty"`
+}
+
+// Report lists the invariants broken by the recorded traffic.
+type Report struct {
+	// UnmatchedMessages were sent but their originator never got the ack.
+	UnmatchedMessages []Finding `json:"unmatched_messages"`
+	// AcksWithoutMessages answer a message their originator never sent.
+	AcksWithoutMessages []Finding `json:"acks_without_messages"`
+	// DuplicateDeliveries reached the same recipient more than once.
+	DuplicateDeliveries []Finding `json:"duplicate_deliveries"`
+	// LeftRegistered are the connections still registered at the end of the server logs.
+	LeftRegistered []string `json:"left_registered"`
+}
+
+// OK tells whether every invariant holds.
+func (r Report) OK() bool {
+	return len(r.UnmatchedMessages) == 0 &&
+		len(r.AcksWithoutMessages) == 0 &&
+		len(r.DuplicateDeliveries) == 0 &&
+		len(r.LeftRegistered) == 0
+}
+
+// message identifies a message by its originator and the hash of its content.
+type message struct {
+	originator  string
+	contentHash string
+}
+
+type delivery struct {
+	message
+	source    Source
+	recipient string
+}
+
+// Verifier accumulates the recorded traffic and reports the broken invariants.
+type Verifier struct {
+	sent       map[message]Source
+	acked      map[message]Source
+	deliveries map[delivery]int
+	registered map[string]bool
+}
+
+// New creates a new Verifier without any traffic.
+func New() *Verifier {
+	return &Verifier{
+		sent:       make(map[message]Source),
+		acked:      make(map[message]Source),
+		deliveries: make(map[delivery]int),
+		registered: make(map[string]bool),
+	}
+}
+
+// AddServerRecord adds an audit record of a server.
+func (v *Verifier) AddServerRecord(record audit.Record) {
+	switch record.Type {
+	case "registered":
+		v.registered[record.OwnerID] = true
+	case "unregistered", "kicked":
+		delete(v.registered, record.OwnerID)
+	case "relayed":
+		msg := message{originator: record.OwnerID, contentHash: record.ContentHash}
+		v.send(msg, SourceServer)
+		v.deliveries[delivery{message: msg, source: SourceServer, recipient: record.PeerID}]++
+	case "ack_forwarded":
+		v.ack(message{originator: record.PeerID, contentHash: record.ContentHash}, SourceServer)
+	}
+}
+
+// ReadServerLog adds every record of the audit log at path, rotated files included.
+func (v *Verifier) ReadServerLog(path string) error {
+	return audit.Replay(path, func(record audit.Record) error {
+		v.AddServerRecord(record)
+
+		return nil
+	})
+}
+
+// Report returns the invariants broken by the traffic added so far, sorted for stable output.
+func (v *Verifier) Report() Report {
+	report := Report{
+		UnmatchedMessages:   []Finding{},
+		AcksWithoutMessages: []Finding{},
+		DuplicateDeliveries: []Finding{},
+		LeftRegistered:      []string{},
+	}
+
+	for msg, source := range v.sent {
+		if _, ok := v.acked[msg]; !ok {
+			report.UnmatchedMessages = append(report.UnmatchedMessages, msg.finding(source))
+		}
+	}
+
+	for msg, source := range v.acked {
+		if _, ok := v.sent[msg]; !ok {
+			report.AcksWithoutMessages = append(report.AcksWithoutMessages, msg.finding(source))
+		}
+	}
+
+	for d, count := range v.deliveries {
+		if count > 1 {
+			finding := d.finding(d.source)
+			finding.Recipient, finding.Count = d.recipient, count
+			report.DuplicateDeliveries = append(report.DuplicateDeliveries, finding)
+		}
+	}
+
+	for ownerID := range v.registered {
+		report.LeftRegistered = append(report.LeftRegistered, ownerID)
+	}
+
+	for _, findings := range [][]Finding{report.UnmatchedMessages, report.AcksWithoutMessages, report.DuplicateDeliveries} {
+		slices.SortFunc(findings, compareFindings)
+	}
+
+	slices.Sort(report.LeftRegistered)
+
+	return report
+}
+
+// send records a sent message, the server source wins as it sees every client.
+func (v *Verifier) send(msg message, source Source) {
+	if _, ok := v.sent[msg]; !ok || source == SourceServer {
+		v.sent[msg] = source
+	}
+}
+
+func (v *Verifier) ack(msg message, source Source) {
+	if _, ok := v.acked[msg]; !ok || source == SourceServer {
+		v.acked[msg] = source
+	}
+}
+
+func (m message) finding(source Source) Finding {
+	return Finding{Source: source, Originator: m.originator, ContentHash: m.contentHash}
+}
+
+func compareFindings(a, b Finding) int {
+	return cmp.Or(
+		cmp.Compare(a.Originator, b.Originator),
+		cmp.Compare(a.ContentHash, b.ContentHash),
+		cmp.Compare(a.Recipient, b.Recipient),
+		cmp.Compare(a.Source, b.Source),
+	)
+}
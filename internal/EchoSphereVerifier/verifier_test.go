@@ -0,0 +1,171 @@
+package verifier_test
+
+import (
+	"bytes"
+	"context"
+	v1 "github.com/k4l1ma/EchoSphere/api/v1"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/io/audit"
+	"github.com/k4l1ma/EchoSphere/internal/EchoSphereServer/usecase"
+	verifier "github.com/k4l1ma/EchoSphere/internal/EchoSphereVerifier"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"path/filepath"
+	"testing"
+)
+
+type verifierSuite struct {
+	suite.Suite
+
+	SUT *verifier.Verifier
+	// capture is the policy the clients log their frames with
+	capture v1.CapturePolicy
+}
+
+func (v *verifierSuite) SetupTest() {
+	v.SUT = verifier.New()
+}
+
+// clientLog logs frames the way the stream logger of a client does.
+func (v *verifierSuite) clientLog(clientID string, log func(logger *zap.Logger)) *bytes.Buffer {
+	out := &bytes.Buffer{}
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(out), zap.InfoLevel)
+
+	log(zap.New(core).With(zap.String("client_id", clientID)))
+
+	return out
+}
+
+func (v *verifierSuite) sent(logger *zap.Logger, req *v1.EchoSphereTransmissionServiceTransmitRequest) {
+	logger.Info("Finished handling SendMsg", zap.Object("message", v.capture.Object(req)))
+}
+
+func (v *verifierSuite) received(logger *zap.Logger, res *v1.EchoSphereTransmissionServiceTransmitResponse) {
+	logger.Info("Finished handling RecvMsg", zap.Object("message", v.capture.Object(res)))
+}
+
+func messageReq(from, content string) *v1.EchoSphereTransmissionServiceTransmitRequest {
+	return &v1.EchoSphereTransmissionServiceTransmitRequest{IncomingData: &v1.EchoSphereTransmissionServiceTransmitRequest_Message{
+		Message: &v1.Message{From: from, Content: content},
+	}}
+}
+
+func messageRes(from, content string) *v1.EchoSphereTransmissionServiceTransmitResponse {
+	return &v1.EchoSphereTransmissionServiceTransmitResponse{OutgoingData: &v1.EchoSphereTransmissionServiceTransmitResponse_Message{
+		Message: &v1.Message{From: from, Content: content},
+	}}
+}
+
+func ackRes(from, to, content string) *v1.EchoSphereTransmissionServiceTransmitResponse {
+	return &v1.EchoSphereTransmissionServiceTransmitResponse{OutgoingData: &v1.EchoSphereTransmissionServiceTransmitResponse_Ack{
+		Ack: &v1.Ack{From: from, To: to, Content: content},
+	}}
+}
+
+func (v *verifierSuite) TestServerLog_RoundTrip() {
+	path := filepath.Join(v.T().TempDir(), "audit.jsonl")
+
+	sink, err := audit.NewSink(audit.Config{Path: path, Redaction: audit.RedactHash, Logger: zap.NewNop()})
+	v.Require().NoError(err)
+
+	bus := usecase.NewBus()
+	bus.Subscribe(usecase.EventFilter{}, sink.Handle)
+
+	ctx := context.Background()
+	bus.Publish(ctx, usecase.Event{Type: usecase.EventRegistered, OwnerID: "a"})
+	bus.Publish(ctx, usecase.Event{Type: usecase.EventRegistered, OwnerID: "b"})
+	bus.Publish(ctx, usecase.Event{Type: usecase.EventRelayed, OwnerID: "a", PeerID: "b", Content: "x"})
+	bus.Publish(ctx, usecase.Event{Type: usecase.EventAckForwarded, OwnerID: "b", PeerID: "a", Content: "x"})
+	bus.Publish(ctx, usecase.Event{Type: usecase.EventUnregistered, OwnerID: "a"})
+	bus.Publish(ctx, usecase.Event{Type: usecase.EventKicked, OwnerID: "b"})
+	v.Require().NoError(sink.Close())
+
+	v.Require().NoError(v.SUT.ReadServerLog(path))
+
+	report := v.SUT.Report()
+	v.True(report.OK(), "%+v", report)
+}
+
+func (v *verifierSuite) TestServerLog_Violations() {
+	records := []audit.Record{
+		{Type: "registered", OwnerID: "a"},
+		{Type: "registered", OwnerID: "b"},
+		{Type: "relayed", OwnerID: "a", PeerID: "b", ContentHash: "x"},
+		{Type: "relayed", OwnerID: "a", PeerID: "b", ContentHash: "x"},
+		{Type: "ack_forwarded", OwnerID: "a", PeerID: "b", ContentHash: "y"},
+		{Type: "unregistered", OwnerID: "a"},
+	}
+
+	for _, record := range records {
+		v.SUT.AddServerRecord(record)
+	}
+
+	report := v.SUT.Report()
+	v.False(report.OK())
+	v.Equal([]verifier.Finding{{Source: verifier.SourceServer, Originator: "a", ContentHash: "x"}}, report.UnmatchedMessages)
+	v.Equal([]verifier.Finding{{Source: verifier.SourceServer, Originator: "b", ContentHash: "y"}}, report.AcksWithoutMessages)
+	v.Equal(
+		[]verifier.Finding{{Source: verifier.SourceServer, Originator: "a", Recipient: "b", ContentHash: "x", Count: 2}},
+		report.DuplicateDeliveries,
+	)
+	v.Equal([]string{"b"}, report.LeftRegistered)
+}
+
+func (v *verifierSuite) TestClientLog() {
+	for _, capture := range []v1.Capture{v1.CaptureHash, v1.CaptureTruncated, v1.CaptureFull} {
+		v.Run(string(capture), func() {
+			v.SUT, v.capture = verifier.New(), v1.CapturePolicy{Capture: capture, TruncateAt: 4}
+
+			logs := []*bytes.Buffer{
+				v.clientLog("a", func(logger *zap.Logger) {
+					v.sent(logger, messageReq("a", "hello"))
+					// the echo of its own message is not a delivery
+					v.received(logger, messageRes("a", "hello"))
+					v.received(logger, ackRes("b", "a", "hello"))
+				}),
+				v.clientLog("b", func(logger *zap.Logger) {
+					v.sent(logger, messageReq("b", "lost"))
+					v.received(logger, messageRes("a", "hello"))
+					v.received(logger, messageRes("a", "hello"))
+					v.received(logger, ackRes("a", "b", "never sent"))
+				}),
+			}
+
+			for _, log := range logs {
+				v.Require().NoError(v.SUT.ReadClientLog(log))
+			}
+
+			report := v.SUT.Report()
+			v.Equal(
+				[]verifier.Finding{{Source: verifier.SourceClient, Originator: "b", ContentHash: usecase.ContentHash("lost")}},
+				report.UnmatchedMessages,
+			)
+			v.Equal(
+				[]verifier.Finding{{Source: verifier.SourceClient, Originator: "b", ContentHash: usecase.ContentHash("never sent")}},
+				report.AcksWithoutMessages,
+			)
+			v.Equal(
+				[]verifier.Finding{{
+					Source:      verifier.SourceClient,
+					Originator:  "a",
+					Recipient:   "b",
+					ContentHash: usecase.ContentHash("hello"),
+					Count:       2,
+				}},
+				report.DuplicateDeliveries,
+			)
+			v.Empty(report.LeftRegistered)
+		})
+	}
+}
+
+func (v *verifierSuite) TestClientLog_SkipsOtherOutput() {
+	log := bytes.NewBufferString("starting\n{\"msg\":\"Starting EchoSphere Client\"}\n")
+
+	v.Require().NoError(v.SUT.ReadClientLog(log))
+	v.True(v.SUT.Report().OK())
+}
+
+func TestVerifier(t *testing.T) {
+	suite.Run(t, new(verifierSuite))
+}
@@ -2,15 +2,18 @@ package load_test
 
 import (
 	"context"
+	"encoding/json"
 	"github.com/google/uuid"
 	v1 "github.com/k4l1ma/EchoSphere/api/v1"
 	"github.com/k4l1ma/EchoSphere/build/server"
 	esc "github.com/k4l1ma/EchoSphere/internal/EchoSphereClient/io/gRPC"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"golang.org/x/exp/rand"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"net/http"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -121,6 +124,26 @@ func (l *loadTestSuite) TestLoad100k() {
 	failed := failedCons.Load()
 	l.T().Logf("Successful Connections: %d\n", load-failed)
 	l.T().Logf("Failed Connections: %d\n", failed)
+
+	// with every client gone the server must let go of their relayers, streams and pending work
+	l.EventuallyWithT(func(c *assert.CollectT) {
+		assert.Equal(c, server.Stats{}, l.stats(c))
+	}, time.Minute, 100*time.Millisecond)
+}
+
+// stats fetches the zero state of the server from its sidecar.
+func (l *loadTestSuite) stats(c *assert.CollectT) server.Stats {
+	var stats server.Stats
+
+	res, err := http.Get("http://localhost:9090/debug/stats")
+	if !assert.NoError(c, err) {
+		return stats
+	}
+	defer res.Body.Close()
+
+	assert.NoError(c, json.NewDecoder(res.Body).Decode(&stats))
+
+	return stats
 }
 
 func TestLoad(t *testing.T) {